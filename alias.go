@@ -0,0 +1,69 @@
+package orderedobject
+
+import (
+	"maps"
+	"slices"
+)
+
+// Alias registers alias as an alternative name for the canonical key.
+// Lookups and updates through the alias operate on the canonical entry,
+// and decoded input using the alias is stored under the canonical key.
+// Aliasing an existing alias resolves to its canonical key.
+// Returns the object for chaining.
+func (object *Object[V]) Alias(alias, canonical string) *Object[V] {
+	canonical = object.resolveKey(canonical)
+	if alias == canonical {
+		return object
+	}
+	ext := object.extend()
+	if ext.aliases == nil {
+		ext.aliases = make(map[string]string)
+	}
+	ext.aliases[alias] = canonical
+	return object
+}
+
+// Unalias removes a previously registered alias.
+// Returns the object for chaining.
+func (object *Object[V]) Unalias(alias string) *Object[V] {
+	if object.ext != nil {
+		delete(object.ext.aliases, alias)
+	}
+	return object
+}
+
+// EmitAliases controls whether marshaling writes every alias of a key
+// alongside the canonical key. By default only the canonical key is emitted.
+// Returns the object for chaining.
+func (object *Object[V]) EmitAliases(emit bool) *Object[V] {
+	object.extend().emitAliases = emit
+	return object
+}
+
+// resolveKey returns the canonical key for key, or key itself if it is not an alias.
+// The key is normalized first when NormalizeKeys is set.
+func (object *Object[V]) resolveKey(key string) string {
+	ext := object.ext
+	if ext == nil {
+		return key
+	}
+	if ext.normalizeKey != nil {
+		key = ext.normalizeKey(key)
+	}
+	if canonical, ok := ext.aliases[key]; ok {
+		return canonical
+	}
+	return key
+}
+
+// aliasesOf returns the aliases of the canonical key in sorted order.
+func (object *Object[V]) aliasesOf(canonical string) []string {
+	var aliases []string
+	registered := object.ext.aliases
+	for _, alias := range slices.Sorted(maps.Keys(registered)) {
+		if registered[alias] == canonical {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
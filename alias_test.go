@@ -0,0 +1,79 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlias(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("timeout", 30).
+		Alias("timeout_seconds", "timeout")
+
+	value, found := obj.Get("timeout_seconds")
+	assert.True(t, found)
+	assert.Equal(t, 30, value)
+	assert.True(t, obj.Has("timeout_seconds"))
+
+	obj.Set("timeout_seconds", 60)
+	assert.Equal(t, []string{"timeout"}, obj.Keys())
+	value, _ = obj.Get("timeout")
+	assert.Equal(t, 60, value)
+
+	obj.Delete("timeout_seconds")
+	assert.Equal(t, 0, obj.Length())
+}
+
+func TestAliasSetCreatesCanonicalKey(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[string]().
+		Alias("old", "new").
+		Alias("older", "old")
+
+	obj.Set("older", "value")
+	assert.Equal(t, []string{"new"}, obj.Keys())
+
+	obj.Unalias("older")
+	assert.False(t, obj.Has("older"))
+	assert.True(t, obj.Has("old"))
+}
+
+func TestAliasMarshal(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Alias("host_name", "host").
+		Alias("hostname", "host").
+		Set("host", "localhost").
+		Set("port", 80)
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"host":"localhost","port":80}`, string(data))
+
+	obj.EmitAliases(true)
+	data, err = obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"host":"localhost","host_name":"localhost","hostname":"localhost","port":80}`, string(data))
+}
+
+func TestAliasUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().Alias("host_name", "host")
+	err := obj.UnmarshalJSON([]byte(`{"port":80,"host_name":"example.com"}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"port", "host"}, obj.Keys())
+	value, _ := obj.Get("host")
+	assert.Equal(t, "example.com", value)
+
+	// Aliases survive cloning
+	clone := obj.Clone()
+	assert.True(t, clone.Has("host_name"))
+}
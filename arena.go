@@ -0,0 +1,179 @@
+package orderedobject
+
+import (
+	"bytes"
+	"fmt"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// DefaultArenaSlabSize is the number of objects and entries allocated at once by an
+// Arena created without an explicit slab size.
+const DefaultArenaSlabSize = 1024
+
+// ArenaMaxKeys is the number of distinct keys an Arena interns between resets. Keys
+// seen once the limit is reached are allocated per object, so documents with
+// attacker-controlled keys cannot grow the arena without bound.
+const ArenaMaxKeys = 4096
+
+// arenaMaxKeyLen is the length of the longest key an Arena interns.
+const arenaMaxKeyLen = 64
+
+// Arena batch-allocates the objects, entries and keys of decoded documents, for
+// decode-heavy services creating millions of small ordered objects. Objects decoded
+// through an arena share a few large slabs instead of one allocation each, and equal
+// keys share a single string. The slabs are freed together once every object allocated
+// from them is unreachable. Interned keys live until Reset and are limited to
+// ArenaMaxKeys short keys, so a long-lived arena should be Reset when the set of
+// keys it decodes changes.
+//
+// Objects from an arena behave like any other object: entries added later are stored
+// outside the slabs. An Arena is not safe for concurrent use.
+type Arena struct {
+	slabSize int
+	objects  []Object[any]
+	entries  []Entry[any]
+	// scratch collects the entries of the objects being decoded, innermost last
+	scratch []Entry[any]
+	keys    map[string]string
+	// dec and reader are reused across documents
+	dec    jsontext.Decoder
+	reader bytes.Reader
+}
+
+// NewArena creates an arena allocating slabs of slabSize objects and entries.
+// If slabSize is omitted or not positive, DefaultArenaSlabSize is used.
+func NewArena(slabSize ...int) *Arena {
+	size := DefaultArenaSlabSize
+	if len(slabSize) > 0 && slabSize[0] > 0 {
+		size = slabSize[0]
+	}
+	return &Arena{slabSize: size, keys: make(map[string]string)}
+}
+
+// FromJSON creates an ordered object from JSON, decoding nested objects as ordered
+// objects like FromJSONDeep, with objects, entries and keys allocated from the arena.
+func (a *Arena) FromJSON(data []byte) (*Object[any], error) {
+	clear(a.scratch)
+	a.scratch = a.scratch[:0]
+	a.reader.Reset(data)
+	a.dec.Reset(&a.reader)
+	value, err := a.decode(&a.dec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	obj, ok := value.(*Object[any])
+	if !ok {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w, got %T", ErrExpectedObjectStart, value)
+	}
+	return obj, nil
+}
+
+// Reset makes the arena start new slabs and forget its interned keys. Objects already
+// allocated remain valid and keep their slabs alive until they are unreachable.
+func (a *Arena) Reset() {
+	a.objects = nil
+	a.entries = nil
+	a.scratch = nil
+	clear(a.keys)
+}
+
+// decode decodes the next JSON value from dec, allocating objects from the arena.
+func (a *Arena) decode(dec *jsontext.Decoder) (any, error) {
+	switch dec.PeekKind() {
+	case '{':
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		start := len(a.scratch)
+		for dec.PeekKind() != '}' {
+			key, err := a.readKey(dec)
+			if err != nil {
+				return nil, err
+			}
+			value, err := a.decode(dec)
+			if err != nil {
+				return nil, err
+			}
+			a.scratch = append(a.scratch, Entry[any]{Key: key, Value: value})
+		}
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		obj := a.newObject(a.scratch[start:])
+		clear(a.scratch[start:])
+		a.scratch = a.scratch[:start]
+		return obj, nil
+	case '[':
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		values := []any{}
+		for dec.PeekKind() != ']' {
+			value, err := a.decode(dec)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+	var value any
+	if err := json.UnmarshalDecode(dec, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// readKey reads an object key from dec, returning the arena's copy of equal keys.
+func (a *Arena) readKey(dec *jsontext.Decoder) (string, error) {
+	raw, err := dec.ReadValue()
+	if err != nil {
+		return "", err
+	}
+	if raw.Kind() != '"' {
+		return "", fmt.Errorf("%w, got %v", ErrExpectedStringKey, raw.Kind())
+	}
+
+	var key string
+	if bytes.IndexByte(raw, '\\') < 0 {
+		// Without escapes the quoted bytes are the key, which can be looked up without allocating
+		if interned, ok := a.keys[string(raw[1:len(raw)-1])]; ok {
+			return interned, nil
+		}
+		key = string(raw[1 : len(raw)-1])
+	} else if err := json.Unmarshal(raw, &key); err != nil {
+		return "", err
+	}
+
+	if interned, ok := a.keys[key]; ok {
+		return interned, nil
+	}
+	if len(a.keys) < ArenaMaxKeys && len(key) <= arenaMaxKeyLen {
+		a.keys[key] = key
+	}
+	return key, nil
+}
+
+// newObject allocates an object from the arena holding a copy of entries.
+func (a *Arena) newObject(entries []Entry[any]) *Object[any] {
+	if len(a.objects) == cap(a.objects) {
+		a.objects = make([]Object[any], 0, a.slabSize)
+	}
+	a.objects = a.objects[:len(a.objects)+1]
+	obj := &a.objects[len(a.objects)-1]
+
+	n := len(entries)
+	if n > cap(a.entries)-len(a.entries) {
+		a.entries = make([]Entry[any], 0, max(a.slabSize, n))
+	}
+	start := len(a.entries)
+	a.entries = append(a.entries, entries...)
+	// Cap the slice so appending to the object never overwrites its neighbours
+	obj.entries = a.entries[start : start+n : start+n]
+	return obj
+}
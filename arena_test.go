@@ -0,0 +1,116 @@
+package orderedobject
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArenaFromJSON(t *testing.T) {
+	t.Parallel()
+
+	arena := NewArena(4)
+	input := `{"z":1,"ab":{"y":"v","list":[{"k":true},null]},"empty":{},"last":"x"}`
+	obj, err := arena.FromJSON([]byte(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"z", "ab", "empty", "last"}, obj.Keys())
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"z":1,"ab":{"y":"v","list":[{"k":true},null]},"empty":{},"last":"x"}`, string(data))
+
+	expected, err := FromJSONDeep([]byte(input))
+	require.NoError(t, err)
+	assert.True(t, obj.Equal(expected))
+}
+
+func TestArenaObjectsAreIndependent(t *testing.T) {
+	t.Parallel()
+
+	arena := NewArena()
+	first, err := arena.FromJSON([]byte(`{"a":1,"b":2}`))
+	require.NoError(t, err)
+	second, err := arena.FromJSON([]byte(`{"c":3}`))
+	require.NoError(t, err)
+
+	// Growing an arena object must not overwrite the entries of its neighbour
+	first.Set("x", 9)
+	first.Delete("a")
+	assert.Equal(t, []string{"b", "x"}, first.Keys())
+	assert.Equal(t, []string{"c"}, second.Keys())
+	value, _ := second.Get("c")
+	assert.Equal(t, float64(3), value)
+}
+
+func TestArenaInternsKeys(t *testing.T) {
+	t.Parallel()
+
+	arena := NewArena()
+	var objects []*Object[any]
+	for i := range 3 {
+		obj, err := arena.FromJSON(fmt.Appendf(nil, `{"name":"item%d"}`, i))
+		require.NoError(t, err)
+		objects = append(objects, obj)
+	}
+	keyData := func(obj *Object[any]) *byte { return unsafe.StringData(obj.Keys()[0]) }
+	assert.Equal(t, keyData(objects[0]), keyData(objects[2]))
+
+	arena.Reset()
+	assert.Empty(t, arena.keys)
+	obj, err := arena.FromJSON([]byte(`{"name":"after reset"}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name"}, obj.Keys())
+	assert.Equal(t, []string{"name"}, objects[0].Keys())
+
+	// Interning stops at the limit and skips long keys
+	for i := range ArenaMaxKeys + 10 {
+		_, err := arena.FromJSON(fmt.Appendf(nil, `{"k%d":1}`, i))
+		require.NoError(t, err)
+	}
+	assert.Len(t, arena.keys, ArenaMaxKeys)
+	arena.Reset()
+	long := strings.Repeat("x", arenaMaxKeyLen+1)
+	obj, err = arena.FromJSON([]byte(`{"` + long + `":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{long}, obj.Keys())
+	assert.Empty(t, arena.keys)
+}
+
+func TestArenaErrors(t *testing.T) {
+	t.Parallel()
+
+	arena := NewArena()
+	_, err := arena.FromJSON([]byte(`[1]`))
+	require.ErrorIs(t, err, ErrExpectedObjectStart)
+
+	_, err = arena.FromJSON([]byte(`{"a":{"b":`))
+	require.Error(t, err)
+
+	// The arena remains usable after a failed decode
+	obj, err := arena.FromJSON([]byte(`{"a":{"b":1}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, obj.Keys())
+}
+
+func BenchmarkArenaFromJSON(b *testing.B) {
+	data := []byte(`{"id":1,"name":"item","tags":["a","b"],"meta":{"created":"2024-01-01","owner":"x"}}`)
+	arena := NewArena()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = arena.FromJSON(data)
+	}
+}
+
+func BenchmarkFromJSONDeep(b *testing.B) {
+	data := []byte(`{"id":1,"name":"item","tags":["a","b"],"meta":{"created":"2024-01-01","owner":"x"}}`)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = FromJSONDeep(data)
+	}
+}
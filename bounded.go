@@ -0,0 +1,132 @@
+package orderedobject
+
+import "github.com/kaptinlin/orderedobject/internal/jsontext"
+
+// EvictionPolicy selects which key a BoundedObject evicts when it is full.
+type EvictionPolicy int
+
+const (
+	// EvictLRU evicts the least recently used key. Get and Set move a key to the end.
+	EvictLRU EvictionPolicy = iota
+	// EvictOldest evicts the key inserted first. Keys keep their insertion order.
+	EvictOldest
+)
+
+// BoundedObject is an ordered object holding at most a fixed number of entries, which
+// makes it a small ordered cache. Its keys are ordered from the next to be evicted to
+// the most recently inserted or, with EvictLRU, used.
+type BoundedObject[V any] struct {
+	object   *Object[V]
+	capacity int
+	policy   EvictionPolicy
+	onEvict  func(key string, value V)
+}
+
+// NewBoundedObject returns an empty object holding at most capacity entries.
+// A capacity below one is treated as one.
+func NewBoundedObject[V any](capacity int, policy EvictionPolicy) *BoundedObject[V] {
+	capacity = max(capacity, 1)
+	return &BoundedObject[V]{
+		object:   NewObject[V](capacity),
+		capacity: capacity,
+		policy:   policy,
+	}
+}
+
+// OnEvict sets a callback invoked with each entry evicted to make room for a new key.
+// Entries removed by Delete are not reported.
+// Returns the object for chaining.
+func (bounded *BoundedObject[V]) OnEvict(fn func(key string, value V)) *BoundedObject[V] {
+	bounded.onEvict = fn
+	return bounded
+}
+
+// Set sets the value for a key. A new key is appended, evicting the first key if the
+// object is full; with EvictLRU an existing key is moved to the end as well.
+// Returns the object for chaining.
+func (bounded *BoundedObject[V]) Set(key string, value V) *BoundedObject[V] {
+	if idx := bounded.object.findKeyIndex(key); idx >= 0 {
+		bounded.object.entries[idx].Value = value
+		bounded.touch(idx)
+		return bounded
+	}
+	if len(bounded.object.entries) >= bounded.capacity {
+		evicted := bounded.object.entries[0]
+		bounded.object.removeIndex(0)
+		if bounded.onEvict != nil {
+			bounded.onEvict(evicted.Key, evicted.Value)
+		}
+	}
+	bounded.object.Set(key, value)
+	return bounded
+}
+
+// Get returns the value for a key and whether it exists. With EvictLRU the key is
+// moved to the end.
+func (bounded *BoundedObject[V]) Get(key string) (V, bool) {
+	idx := bounded.object.findKeyIndex(key)
+	if idx < 0 {
+		var zero V
+		return zero, false
+	}
+	value := bounded.object.entries[idx].Value
+	bounded.touch(idx)
+	return value, true
+}
+
+// Peek returns the value for a key and whether it exists without moving the key.
+func (bounded *BoundedObject[V]) Peek(key string) (V, bool) {
+	return bounded.object.Get(key)
+}
+
+// Has returns whether the key exists, without moving it.
+func (bounded *BoundedObject[V]) Has(key string) bool {
+	return bounded.object.Has(key)
+}
+
+// Delete removes a key. Returns the object for chaining.
+func (bounded *BoundedObject[V]) Delete(key string) *BoundedObject[V] {
+	bounded.object.Delete(key)
+	return bounded
+}
+
+// Length returns the number of entries.
+func (bounded *BoundedObject[V]) Length() int {
+	return bounded.object.Length()
+}
+
+// Capacity returns the maximum number of entries.
+func (bounded *BoundedObject[V]) Capacity() int {
+	return bounded.capacity
+}
+
+// Keys returns the keys from the next to be evicted to the most recent.
+func (bounded *BoundedObject[V]) Keys() []string {
+	return bounded.object.Keys()
+}
+
+// Object returns a copy of the entries as an unbounded ordered object.
+func (bounded *BoundedObject[V]) Object() *Object[V] {
+	return bounded.object.Clone()
+}
+
+// MarshalJSON encodes the entries as a JSON object in their current order.
+func (bounded *BoundedObject[V]) MarshalJSON() ([]byte, error) {
+	return bounded.object.MarshalJSON()
+}
+
+// MarshalJSONTo encodes the entries as a JSON object in their current order.
+func (bounded *BoundedObject[V]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return bounded.object.MarshalJSONTo(enc)
+}
+
+// touch moves the entry at idx to the end under the EvictLRU policy.
+func (bounded *BoundedObject[V]) touch(idx int) {
+	entries := bounded.object.entries
+	if bounded.policy != EvictLRU || idx == len(entries)-1 {
+		return
+	}
+	entry := entries[idx]
+	copy(entries[idx:], entries[idx+1:])
+	entries[len(entries)-1] = entry
+}
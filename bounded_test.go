@@ -0,0 +1,81 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoundedObject(t *testing.T) {
+	t.Parallel()
+
+	t.Run("LRU evicts least recently used", func(t *testing.T) {
+		var evicted []string
+		cache := NewBoundedObject[int](2, EvictLRU).
+			OnEvict(func(key string, _ int) { evicted = append(evicted, key) })
+
+		cache.Set("a", 1).Set("b", 2)
+		_, ok := cache.Get("a")
+		require.True(t, ok)
+		cache.Set("c", 3)
+
+		assert.Equal(t, []string{"b"}, evicted)
+		assert.Equal(t, []string{"a", "c"}, cache.Keys())
+	})
+
+	t.Run("Oldest evicts in insertion order", func(t *testing.T) {
+		var evicted []string
+		cache := NewBoundedObject[int](2, EvictOldest).
+			OnEvict(func(key string, _ int) { evicted = append(evicted, key) })
+
+		cache.Set("a", 1).Set("b", 2)
+		cache.Get("a")
+		cache.Set("a", 10)
+		cache.Set("c", 3)
+
+		assert.Equal(t, []string{"a"}, evicted)
+		assert.Equal(t, []string{"b", "c"}, cache.Keys())
+	})
+
+	t.Run("Peek and Has do not touch", func(t *testing.T) {
+		cache := NewBoundedObject[int](2, EvictLRU).Set("a", 1).Set("b", 2)
+
+		value, ok := cache.Peek("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, value)
+		assert.True(t, cache.Has("a"))
+		cache.Set("c", 3)
+
+		assert.Equal(t, []string{"b", "c"}, cache.Keys())
+	})
+
+	t.Run("Delete frees room without eviction", func(t *testing.T) {
+		evictions := 0
+		cache := NewBoundedObject[int](2, EvictLRU).
+			OnEvict(func(string, int) { evictions++ }).
+			Set("a", 1).Set("b", 2)
+
+		cache.Delete("a").Set("c", 3)
+
+		assert.Zero(t, evictions)
+		assert.Equal(t, 2, cache.Length())
+	})
+
+	t.Run("Capacity is at least one", func(t *testing.T) {
+		cache := NewBoundedObject[int](0, EvictLRU).Set("a", 1).Set("b", 2)
+
+		assert.Equal(t, 1, cache.Capacity())
+		assert.Equal(t, []string{"b"}, cache.Keys())
+	})
+
+	t.Run("Marshal in current order", func(t *testing.T) {
+		cache := NewBoundedObject[int](3, EvictLRU).Set("a", 1).Set("b", 2)
+		cache.Get("a")
+
+		data, err := cache.MarshalJSON()
+		require.NoError(t, err)
+		assert.Equal(t, `{"b":2,"a":1}`, string(data))
+		assert.Equal(t, []string{"b", "a"}, cache.Object().Keys())
+	})
+}
@@ -0,0 +1,45 @@
+package orderedobject
+
+import "slices"
+
+// Cap returns the number of entries the object can hold without reallocating.
+func (object *Object[V]) Cap() int {
+	return cap(object.entries)
+}
+
+// Reserve ensures the object can hold at least n entries in total without reallocating.
+// Returns the object for chaining.
+func (object *Object[V]) Reserve(n int) *Object[V] {
+	if n > len(object.entries) {
+		object.entries = slices.Grow(object.entries, n-len(object.entries))
+	}
+	return object
+}
+
+// Grow ensures the object can hold n more entries without reallocating, for example
+// before a bulk insert. Returns the object for chaining.
+func (object *Object[V]) Grow(n int) *Object[V] {
+	if n > 0 {
+		object.entries = slices.Grow(object.entries, n)
+	}
+	return object
+}
+
+// Shrink releases unused capacity, for example after deleting many entries, by moving
+// the entries to storage of exactly their length. Returns the object for chaining.
+func (object *Object[V]) Shrink() *Object[V] {
+	if cap(object.entries) > len(object.entries) {
+		entries := make([]Entry[V], len(object.entries))
+		copy(entries, object.entries)
+		object.entries = entries
+	}
+	return object
+}
+
+// Clip removes unused capacity without moving the entries, like slices.Clip, so the
+// next insert reallocates instead of reusing the old storage. Unlike Shrink it does not
+// free the storage itself. Returns the object for chaining.
+func (object *Object[V]) Clip() *Object[V] {
+	object.entries = slices.Clip(object.entries)
+	return object
+}
@@ -0,0 +1,83 @@
+package orderedobject
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReserve(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("a", 1)
+	obj.Reserve(100)
+	assert.GreaterOrEqual(t, obj.Cap(), 100)
+	assert.Equal(t, []string{"a"}, obj.Keys())
+
+	// Reserving less than the current capacity is a no-op
+	capacity := obj.Cap()
+	obj.Reserve(10).Reserve(-1)
+	assert.Equal(t, capacity, obj.Cap())
+}
+
+func TestGrow(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("a", 1).Set("b", 2)
+	obj.Grow(50)
+	assert.GreaterOrEqual(t, obj.Cap(), 52)
+
+	capacity := obj.Cap()
+	for i := range 50 {
+		obj.Set(fmt.Sprintf("k%d", i), i)
+	}
+	assert.Equal(t, capacity, obj.Cap())
+	assert.Equal(t, 52, obj.Length())
+
+	obj.Grow(0).Grow(-5)
+	assert.Equal(t, capacity, obj.Cap())
+}
+
+func TestShrink(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int](1000)
+	for i := range 10 {
+		obj.Set(fmt.Sprintf("k%d", i), i)
+	}
+	obj.Delete("k0").Delete("k5")
+
+	obj.Shrink()
+	assert.Equal(t, 8, obj.Cap())
+	assert.Equal(t, []string{"k1", "k2", "k3", "k4", "k6", "k7", "k8", "k9"}, obj.Keys())
+
+	// The object keeps working after shrinking
+	obj.Set("new", 10)
+	value, ok := obj.Get("new")
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+
+	// Shrink keeps an exact capacity even when the size class would round it up
+	odd := NewObject[int](64)
+	for i := range 11 {
+		odd.Set(fmt.Sprintf("k%d", i), i)
+	}
+	assert.Equal(t, 11, odd.Shrink().Cap())
+}
+
+func TestClip(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int](100).Set("a", 1).Set("b", 2)
+	first := &obj.entries[0]
+	obj.Clip()
+	assert.Equal(t, 2, obj.Cap())
+	assert.Same(t, first, &obj.entries[0])
+
+	clone := obj.Clone()
+	obj.Set("c", 3)
+	assert.Equal(t, []string{"a", "b", "c"}, obj.Keys())
+	assert.Equal(t, []string{"a", "b"}, clone.Keys())
+	assert.Equal(t, 0, NewObject[int]().Clip().Cap())
+}
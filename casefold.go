@@ -0,0 +1,61 @@
+package orderedobject
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CaseFallback controls whether lookups fall back to a stored key written in another
+// case convention when the requested key is not found: both keys are folded to
+// snake_case, so "maxConnections" matches a stored "max_connections", "user_id" a
+// stored "userID", and vice versa. Updates through another form keep the stored key,
+// and marshaling always emits the stored form.
+// Returns the object for chaining.
+func (object *Object[V]) CaseFallback(enabled bool) *Object[V] {
+	object.extend().caseFallback = enabled
+	return object
+}
+
+// indexOfFolded returns the index of the first key that folds to the same form as
+// key, or -1 if there is none.
+func (object *Object[V]) indexOfFolded(key string) int {
+	folded := foldCase(key)
+	for i, entry := range object.entries {
+		if foldCase(entry.Key) == folded {
+			return i
+		}
+	}
+	return -1
+}
+
+// foldCase returns the snake_case form of key, which keys differing only in their
+// case convention share.
+func foldCase(key string) string {
+	if snake := toSnakeCase(key); snake != "" {
+		return snake
+	}
+	return key
+}
+
+// toSnakeCase converts a camelCase or PascalCase key to snake_case.
+// Runs of capitals such as "ID" in "userID" are treated as a single word.
+func toSnakeCase(key string) string {
+	runes := []rune(key)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	if result := sb.String(); result != key {
+		return result
+	}
+	return ""
+}
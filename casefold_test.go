@@ -0,0 +1,81 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaseFallback(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("max_connections", 10).
+		Set("requestTimeout", 5)
+
+	_, found := obj.Get("maxConnections")
+	assert.False(t, found)
+
+	obj.CaseFallback(true)
+	value, found := obj.Get("maxConnections")
+	assert.True(t, found)
+	assert.Equal(t, 10, value)
+	assert.True(t, obj.Has("request_timeout"))
+
+	obj.Set("request_timeout", 30)
+	assert.Equal(t, []string{"max_connections", "requestTimeout"}, obj.Keys())
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"max_connections":10,"requestTimeout":30}`, string(data))
+}
+
+func TestCaseFallbackDecode(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().CaseFallback(true)
+	require.NoError(t, obj.UnmarshalJSON([]byte(`{"user_id":1,"userId":2,"name":"x"}`)))
+	assert.Equal(t, []string{"user_id", "name"}, obj.Keys())
+	value, _ := obj.Get("userId")
+	assert.Equal(t, float64(2), value)
+}
+
+func TestCaseFallbackBothDirections(t *testing.T) {
+	t.Parallel()
+
+	pairs := [][2]string{
+		{"userID", "user_id"},
+		{"maxConnections", "max_connections"},
+		{"HTTPServer", "http_server"},
+		{"userId", "userID"},
+	}
+	for _, pair := range pairs {
+		for _, keys := range [][2]string{pair, {pair[1], pair[0]}} {
+			obj := NewObject[int]().CaseFallback(true).Set(keys[0], 1)
+			value, ok := obj.Get(keys[1])
+			assert.True(t, ok, "%s finds %s", keys[1], keys[0])
+			assert.Equal(t, 1, value)
+			assert.Equal(t, []string{keys[0]}, obj.Set(keys[1], 2).Keys())
+		}
+	}
+	assert.False(t, NewObject[int]().CaseFallback(true).Set("user_id", 1).Has("username"))
+}
+
+func TestFoldCase(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"maxConnections":  "max_connections",
+		"max_connections": "max_connections",
+		"userID":          "user_id",
+		"UserId":          "user_id",
+		"HTTPServer":      "http_server",
+		"ipv4Address":     "ipv4_address",
+		"name":            "name",
+		"_private":        "_private",
+	}
+	for key, want := range tests {
+		assert.Equal(t, want, foldCase(key), key)
+	}
+}
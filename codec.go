@@ -0,0 +1,103 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+)
+
+// ErrUnknownCodec is returned when a codec is not registered
+var ErrUnknownCodec = errors.New("unknown codec")
+
+// DefaultCodec is the name of the built-in codec backed by the package's JSON v2 implementation.
+const DefaultCodec = "json"
+
+// Codec encodes and decodes JSON values, so the values stored in ordered objects can be
+// handled by an alternative JSON library. The ordered object itself is always written and
+// read entry by entry to keep its key order; the codec handles the values of the entries.
+type Codec interface {
+	// Marshal returns the JSON encoding of v.
+	Marshal(v any) ([]byte, error)
+	// Unmarshal decodes the JSON value in data into v.
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{DefaultCodec: jsonCodec{}}
+
+	// activeCodec holds the codec selected with UseCodec, or nil for the built-in codec.
+	activeCodec atomic.Pointer[Codec]
+)
+
+// RegisterCodec registers a codec under name, replacing any codec with the same name.
+func RegisterCodec(name string, codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[name] = codec
+}
+
+// LookupCodec returns the codec registered under name.
+func LookupCodec(name string) (Codec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, ok := codecs[name]
+	return codec, ok
+}
+
+// Codecs returns the names of all registered codecs in sorted order.
+func Codecs() []string {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	return slices.Sorted(maps.Keys(codecs))
+}
+
+// UseCodec selects the codec registered under name for encoding and decoding entry values
+// in the whole application. Values implementing OrderedMarshaler are still encoded by
+// themselves, and options passed to json.Marshal do not reach a codec other than DefaultCodec.
+func UseCodec(name string) error {
+	codec, ok := LookupCodec(name)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownCodec, name)
+	}
+	if _, ok := codec.(jsonCodec); ok {
+		activeCodec.Store(nil)
+	} else {
+		activeCodec.Store(&codec)
+	}
+	return nil
+}
+
+// CurrentCodec returns the codec selected with UseCodec.
+func CurrentCodec() Codec {
+	if codec := customCodec(); codec != nil {
+		return codec
+	}
+	return jsonCodec{}
+}
+
+// customCodec returns the selected codec, or nil if the built-in codec is in use.
+func customCodec() Codec {
+	if codec := activeCodec.Load(); codec != nil {
+		return *codec
+	}
+	return nil
+}
+
+// jsonCodec is the built-in codec.
+type jsonCodec struct{}
+
+// Marshal returns the JSON encoding of v with map keys sorted.
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v, json.Deterministic(true))
+}
+
+// Unmarshal decodes the JSON value in data into v.
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
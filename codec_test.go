@@ -0,0 +1,64 @@
+package orderedobject
+
+import (
+	stdjson "encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// v1Codec encodes values with encoding/json, which escapes HTML characters by default.
+type v1Codec struct {
+	decoded int
+}
+
+func (*v1Codec) Marshal(v any) ([]byte, error) { return stdjson.Marshal(v) }
+
+func (c *v1Codec) Unmarshal(data []byte, v any) error {
+	c.decoded++
+	return stdjson.Unmarshal(data, v)
+}
+
+func TestCodecRegistry(t *testing.T) {
+	t.Parallel()
+
+	assert.Contains(t, Codecs(), DefaultCodec)
+	codec, ok := LookupCodec(DefaultCodec)
+	require.True(t, ok)
+
+	data, err := codec.Marshal(map[string]any{"b": 1, "a": "<"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":"<","b":1}`, string(data))
+
+	_, ok = LookupCodec("missing")
+	assert.False(t, ok)
+	require.ErrorIs(t, UseCodec("missing"), ErrUnknownCodec)
+}
+
+func TestUseCodec(t *testing.T) {
+	codec := &v1Codec{}
+	RegisterCodec("v1", codec)
+	require.NoError(t, UseCodec("v1"))
+	defer func() { require.NoError(t, UseCodec(DefaultCodec)) }()
+	assert.Same(t, codec, CurrentCodec())
+
+	obj := NewObject[any]().
+		Set("html", "<b>").
+		Set("nested", NewObject[any]().Set("z", 1).Set("a", 2))
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"html":"<b>","nested":{"z":1,"a":2}}`, string(data))
+
+	decoded := NewObject[any]()
+	require.NoError(t, decoded.UnmarshalJSON([]byte(`{"z":1,"a":[true]}`)))
+	assert.Equal(t, []string{"z", "a"}, decoded.Keys())
+	assert.Equal(t, 2, codec.decoded)
+
+	// Responses keep the key order and encode values like ToJSON
+	rec := httptest.NewRecorder()
+	require.NoError(t, obj.WriteJSON(rec, 200))
+	assert.Equal(t, string(data)+"\n", rec.Body.String())
+}
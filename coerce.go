@@ -0,0 +1,225 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrKeyNotFound is returned when a typed getter is called for a missing key
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrTypeMismatch is returned when a value cannot be converted to the requested type
+	ErrTypeMismatch = errors.New("type mismatch")
+)
+
+// defaultTimeLayouts are the layouts GetTime tries when none are given.
+var defaultTimeLayouts = []string{time.RFC3339Nano, time.RFC3339, time.DateTime, time.DateOnly}
+
+// GetString returns the value for key as a string. Strings, byte slices,
+// fmt.Stringer values, booleans and numbers are converted.
+func (object *Object[V]) GetString(key string) (string, error) {
+	value, err := object.getAny(key)
+	if err != nil {
+		return "", err
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case rv.CanUint():
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case rv.CanFloat():
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64), nil
+	}
+	return "", mismatch(key, value, "string")
+}
+
+// GetInt returns the value for key as an int. Integers, floats without a
+// fractional part (such as decoded JSON numbers) and numeric strings are converted.
+func (object *Object[V]) GetInt(key string) (int, error) {
+	n, err := object.GetInt64(key)
+	if err != nil {
+		return 0, err
+	}
+	if n < math.MinInt || n > math.MaxInt {
+		return 0, fmt.Errorf("%w: %q: %d overflows int", ErrTypeMismatch, key, n)
+	}
+	return int(n), nil
+}
+
+// GetInt64 returns the value for key as an int64. Integers, floats without a
+// fractional part (such as decoded JSON numbers) and numeric strings are converted.
+func (object *Object[V]) GetInt64(key string) (int64, error) {
+	value, err := object.getAny(key)
+	if err != nil {
+		return 0, err
+	}
+	if n, ok := coerceInt64(value); ok {
+		return n, nil
+	}
+	return 0, mismatch(key, value, "int")
+}
+
+// GetFloat64 returns the value for key as a float64. Numbers and numeric strings are converted.
+func (object *Object[V]) GetFloat64(key string) (float64, error) {
+	value, err := object.getAny(key)
+	if err != nil {
+		return 0, err
+	}
+	if f, ok := coerceFloat64(value); ok {
+		return f, nil
+	}
+	return 0, mismatch(key, value, "float64")
+}
+
+// GetBool returns the value for key as a bool. Strings accepted by strconv.ParseBool
+// are converted, and numbers are true when non-zero.
+func (object *Object[V]) GetBool(key string) (bool, error) {
+	value, err := object.getAny(key)
+	if err != nil {
+		return false, err
+	}
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, mismatch(key, value, "bool")
+		}
+		return b, nil
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return rv.Int() != 0, nil
+	case rv.CanUint():
+		return rv.Uint() != 0, nil
+	case rv.CanFloat():
+		return rv.Float() != 0, nil
+	}
+	return false, mismatch(key, value, "bool")
+}
+
+// GetTime returns the value for key as a time.Time. Strings are parsed with the given
+// layouts, tried in order, defaulting to RFC 3339, time.DateTime and time.DateOnly.
+// Numbers are interpreted as Unix seconds.
+func (object *Object[V]) GetTime(key string, layouts ...string) (time.Time, error) {
+	value, err := object.getAny(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		if len(layouts) == 0 {
+			layouts = defaultTimeLayouts
+		}
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, mismatch(key, value, "time.Time")
+	}
+	seconds, ok := coerceFloat64(value)
+	if !ok {
+		return time.Time{}, mismatch(key, value, "time.Time")
+	}
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac*float64(time.Second))), nil
+}
+
+// GetDuration returns the value for key as a time.Duration. Strings are parsed with
+// time.ParseDuration, such as "1m30s", and numbers are interpreted as nanoseconds.
+func (object *Object[V]) GetDuration(key string) (time.Duration, error) {
+	value, err := object.getAny(key)
+	if err != nil {
+		return 0, err
+	}
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, mismatch(key, value, "time.Duration")
+		}
+		return d, nil
+	}
+	n, ok := coerceInt64(value)
+	if !ok {
+		return 0, mismatch(key, value, "time.Duration")
+	}
+	return time.Duration(n), nil
+}
+
+// getAny returns the value for key as any, or ErrKeyNotFound.
+func (object *Object[V]) getAny(key string) (any, error) {
+	value, ok := object.Get(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, key)
+	}
+	return any(value), nil
+}
+
+// coerceInt64 converts integers, whole floats and numeric strings to an int64.
+func coerceInt64(value any) (int64, bool) {
+	if s, ok := value.(string); ok {
+		n, err := strconv.ParseInt(s, 10, 64)
+		return n, err == nil
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return rv.Int(), true
+	case rv.CanUint():
+		if rv.Uint() <= math.MaxInt64 {
+			return int64(rv.Uint()), true
+		}
+	case rv.CanFloat():
+		f := rv.Float()
+		if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f), true
+		}
+	}
+	return 0, false
+}
+
+// coerceFloat64 converts numbers and numeric strings to a float64.
+func coerceFloat64(value any) (float64, bool) {
+	if s, ok := value.(string); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		return f, err == nil
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanFloat():
+		return rv.Float(), true
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+// mismatch returns an ErrTypeMismatch error for a value that cannot be converted.
+func mismatch(key string, value any, want string) error {
+	return fmt.Errorf("%w: %q: cannot convert %T to %s", ErrTypeMismatch, key, value, want)
+}
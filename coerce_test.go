@@ -0,0 +1,98 @@
+package orderedobject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedGetters(t *testing.T) {
+	t.Parallel()
+
+	obj, err := FromJSON[any]([]byte(`{
+		"name": "app",
+		"port": 8080,
+		"ratio": 0.5,
+		"workers": "4",
+		"debug": "true",
+		"enabled": 1,
+		"created": "2024-01-02T03:04:05Z",
+		"day": "2024-01-02",
+		"epoch": 1700000000,
+		"timeout": "1m30s"
+	}`))
+	require.NoError(t, err)
+
+	name, err := obj.GetString("name")
+	require.NoError(t, err)
+	assert.Equal(t, "app", name)
+
+	portString, err := obj.GetString("port")
+	require.NoError(t, err)
+	assert.Equal(t, "8080", portString)
+
+	port, err := obj.GetInt("port")
+	require.NoError(t, err)
+	assert.Equal(t, 8080, port)
+
+	workers, err := obj.GetInt64("workers")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), workers)
+
+	ratio, err := obj.GetFloat64("ratio")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, ratio, 0)
+
+	debug, err := obj.GetBool("debug")
+	require.NoError(t, err)
+	assert.True(t, debug)
+
+	enabled, err := obj.GetBool("enabled")
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	created, err := obj.GetTime("created")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), created)
+
+	day, err := obj.GetTime("day")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), day)
+
+	epoch, err := obj.GetTime("epoch")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000), epoch.Unix())
+
+	timeout, err := obj.GetDuration("timeout")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, timeout)
+}
+
+func TestTypedGetterErrors(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("ratio", 0.5).
+		Set("name", "app").
+		Set("list", []any{1})
+
+	_, err := obj.GetInt("ratio")
+	require.ErrorIs(t, err, ErrTypeMismatch)
+
+	_, err = obj.GetBool("name")
+	require.ErrorIs(t, err, ErrTypeMismatch)
+
+	_, err = obj.GetTime("name", time.Kitchen)
+	require.ErrorIs(t, err, ErrTypeMismatch)
+
+	_, err = obj.GetDuration("name")
+	require.ErrorIs(t, err, ErrTypeMismatch)
+
+	_, err = obj.GetString("list")
+	require.ErrorIs(t, err, ErrTypeMismatch)
+
+	_, err = obj.GetString("missing")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
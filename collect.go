@@ -0,0 +1,40 @@
+package orderedobject
+
+import (
+	"errors"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// CollectErrors sets whether UnmarshalJSON and UnmarshalJSONFrom keep decoding after a
+// value fails to decode into V. Every failure is then recorded as a *PathError naming
+// the key, and all of them are returned together as a joined error (errors.Join) once
+// the whole object has been read, so validation can report every problem in one pass.
+// The keys whose values failed are left out; the other entries are kept, so the object
+// holds everything that could be decoded. Syntax errors still stop decoding.
+// Returns the object for chaining.
+func (object *Object[V]) CollectErrors(enabled bool) *Object[V] {
+	object.extend().collectErrors = enabled
+	return object
+}
+
+// SkipInvalid sets a callback for entries whose values fail to decode into V, or are
+// rejected by a decode hook. Such entries are left out of the object and passed to fn
+// with their raw JSON and a *PathError describing the failure, and decoding goes on as
+// if they were absent, so one malformed field does not discard the whole document.
+// Syntax errors still stop decoding. Passing a nil fn restores the default behavior.
+// Returns the object for chaining.
+func (object *Object[V]) SkipInvalid(fn func(key string, raw jsontext.Value, err error)) *Object[V] {
+	object.extend().onInvalid = fn
+	return object
+}
+
+// keyError wraps an error decoding the value of key, which starts at offset, in a
+// *PathError locating the failure below key.
+func keyError(key string, offset int64, err error) *PathError {
+	var pathErr *PathError
+	if errors.As(withPath(err, 0), &pathErr) {
+		return &PathError{Path: key + "." + pathErr.Path, Offset: offset + pathErr.Offset, Err: pathErr.Err}
+	}
+	return &PathError{Path: key, Offset: offset, Err: err}
+}
@@ -0,0 +1,125 @@
+package orderedobject
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Reports every failing key", func(t *testing.T) {
+		obj := NewObject[int]().CollectErrors(true)
+		err := obj.UnmarshalJSON([]byte(`{"a": 1, "b": "x", "c": 3, "d": {"e": 1}}`))
+		require.Error(t, err)
+
+		var joined interface{ Unwrap() []error }
+		require.ErrorAs(t, err, &joined)
+		errs := joined.Unwrap()
+		require.Len(t, errs, 2)
+
+		var first, second *PathError
+		require.ErrorAs(t, errs[0], &first)
+		require.ErrorAs(t, errs[1], &second)
+		assert.Equal(t, "b", first.Path)
+		assert.Equal(t, int64(14), first.Offset)
+		assert.Equal(t, "d", second.Path)
+
+		assert.Equal(t, []string{"a", "c"}, obj.Keys())
+	})
+
+	t.Run("Nested paths", func(t *testing.T) {
+		obj := NewObject[pathErrorSettings]().CollectErrors(true)
+		err := obj.UnmarshalJSON([]byte(`{"ok": {"ssl": {"cert": 1}}, "bad": {"ssl": {"cert": "x"}}}`))
+
+		var pathErr *PathError
+		require.ErrorAs(t, err, &pathErr)
+		assert.Equal(t, "bad.ssl.cert", pathErr.Path)
+		assert.Equal(t, int64(53), pathErr.Offset)
+		assert.Equal(t, []string{"ok"}, obj.Keys())
+	})
+
+	t.Run("Hook failures are collected", func(t *testing.T) {
+		fail := errors.New("rejected")
+		obj := NewObject[any]().CollectErrors(true).RegisterValueHook(ValueHook{
+			Decode: func(key string, value any) (any, error) {
+				if key == "bad" {
+					return nil, fail
+				}
+				return value, nil
+			},
+		})
+		err := obj.UnmarshalJSON([]byte(`{"bad": 1, "good": 2}`))
+
+		require.ErrorIs(t, err, fail)
+		assert.Equal(t, []string{"good"}, obj.Keys())
+	})
+
+	t.Run("Syntax errors stop decoding", func(t *testing.T) {
+		obj := NewObject[int]().CollectErrors(true)
+		err := obj.UnmarshalJSON([]byte(`{"a": "x", "b": tru}`))
+
+		var joined interface{ Unwrap() []error }
+		assert.False(t, errors.As(err, &joined))
+		require.Error(t, err)
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		obj := NewObject[int]()
+		err := obj.UnmarshalJSON([]byte(`{"a": "x", "b": "y"}`))
+
+		var pathErr *PathError
+		require.ErrorAs(t, err, &pathErr)
+		assert.Equal(t, "a", pathErr.Path)
+	})
+}
+
+func TestSkipInvalid(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Skips and reports invalid entries", func(t *testing.T) {
+		type skipped struct {
+			key, raw, path string
+		}
+		var got []skipped
+		obj := NewObject[int]().SkipInvalid(func(key string, raw jsontext.Value, err error) {
+			var pathErr *PathError
+			require.ErrorAs(t, err, &pathErr)
+			got = append(got, skipped{key, string(raw), pathErr.Path})
+		})
+
+		require.NoError(t, obj.UnmarshalJSON([]byte(`{"a": 1, "b": "x", "c": 3, "d": [1]}`)))
+		assert.Equal(t, []string{"a", "c"}, obj.Keys())
+		assert.Equal(t, []skipped{{"b", `"x"`, "b"}, {"d", `[1]`, "d"}}, got)
+	})
+
+	t.Run("Raw values outlive decoding", func(t *testing.T) {
+		raws := map[string]jsontext.Value{}
+		obj := NewObject[int]().SkipInvalid(func(key string, raw jsontext.Value, _ error) {
+			raws[key] = raw
+		})
+		data := []byte(`{"a": "x"}`)
+		require.NoError(t, obj.UnmarshalJSON(data))
+		copy(data, "          ")
+
+		assert.Equal(t, `"x"`, string(raws["a"]))
+	})
+
+	t.Run("Takes precedence over CollectErrors", func(t *testing.T) {
+		count := 0
+		obj := NewObject[int]().CollectErrors(true).
+			SkipInvalid(func(string, jsontext.Value, error) { count++ })
+
+		require.NoError(t, obj.UnmarshalJSON([]byte(`{"a": "x"}`)))
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Syntax errors stop decoding", func(t *testing.T) {
+		obj := NewObject[int]().SkipInvalid(func(string, jsontext.Value, error) {})
+		assert.Error(t, obj.UnmarshalJSON([]byte(`{"a": tru}`)))
+	})
+}
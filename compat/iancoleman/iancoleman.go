@@ -0,0 +1,63 @@
+// Package iancoleman converts between ordered objects and
+// github.com/iancoleman/orderedmap ordered maps.
+package iancoleman
+
+import (
+	"github.com/iancoleman/orderedmap"
+	"github.com/kaptinlin/orderedobject"
+)
+
+// FromOrderedMap converts an iancoleman ordered map to an ordered object.
+// Nested ordered maps, including those inside arrays, are converted recursively.
+func FromOrderedMap(m *orderedmap.OrderedMap) *orderedobject.Object[any] {
+	keys := m.Keys()
+	obj := orderedobject.NewObject[any](len(keys))
+	for _, key := range keys {
+		value, _ := m.Get(key)
+		obj.Set(key, fromValue(value))
+	}
+	return obj
+}
+
+// ToOrderedMap converts an ordered object to an iancoleman ordered map.
+// Nested objects, including those inside arrays, are converted recursively to
+// orderedmap.OrderedMap values, matching what the orderedmap decoder produces.
+func ToOrderedMap(obj *orderedobject.Object[any]) *orderedmap.OrderedMap {
+	m := orderedmap.New()
+	obj.ForEach(func(key string, value any) {
+		m.Set(key, toValue(value))
+	})
+	return m
+}
+
+// fromValue converts nested ordered maps within value to ordered objects.
+func fromValue(value any) any {
+	switch v := value.(type) {
+	case orderedmap.OrderedMap:
+		return FromOrderedMap(&v)
+	case *orderedmap.OrderedMap:
+		return FromOrderedMap(v)
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = fromValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+// toValue converts nested ordered objects within value to ordered maps.
+func toValue(value any) any {
+	switch v := value.(type) {
+	case *orderedobject.Object[any]:
+		return *ToOrderedMap(v)
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = toValue(item)
+		}
+		return result
+	}
+	return value
+}
@@ -0,0 +1,44 @@
+package iancoleman
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/kaptinlin/orderedobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromOrderedMap(t *testing.T) {
+	t.Parallel()
+
+	m := orderedmap.New()
+	require.NoError(t, json.Unmarshal([]byte(`{"z":1,"a":{"y":true,"b":null},"list":[{"k":"v"}]}`), m))
+
+	obj := FromOrderedMap(m)
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"z":1,"a":{"y":true,"b":null},"list":[{"k":"v"}]}`, string(data))
+
+	nested, _ := obj.Get("a")
+	assert.Equal(t, []string{"y", "b"}, nested.(*orderedobject.Object[any]).Keys())
+}
+
+func TestToOrderedMap(t *testing.T) {
+	t.Parallel()
+
+	obj := orderedobject.NewObject[any]().
+		Set("name", "app").
+		Set("server", orderedobject.NewObject[any]().
+			Set("port", 8080).
+			Set("host", "localhost")).
+		Set("tags", []any{orderedobject.NewObject[any]().Set("b", 1).Set("a", 2)})
+
+	m := ToOrderedMap(obj)
+	assert.Equal(t, []string{"name", "server", "tags"}, m.Keys())
+
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"app","server":{"port":8080,"host":"localhost"},"tags":[{"b":1,"a":2}]}`, string(data))
+}
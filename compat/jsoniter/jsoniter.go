@@ -0,0 +1,103 @@
+// Package jsoniter integrates ordered objects with github.com/json-iterator/go.
+package jsoniter
+
+import (
+	"reflect"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/kaptinlin/orderedobject"
+)
+
+// Register registers an encoder and decoder for orderedobject.Object[any] with
+// json-iterator, so objects are written and read entry by entry in key order instead of
+// through their MarshalJSON and UnmarshalJSON methods. Nested JSON objects are decoded
+// as *orderedobject.Object[any]. Per-object marshaling settings such as OmitEmpty,
+// Redact and value hooks are not applied by the registered encoder.
+// Register should be called once during program initialization.
+func Register() {
+	typ := reflect.TypeFor[orderedobject.Object[any]]().String()
+	jsoniter.RegisterTypeEncoderFunc(typ, encode, isEmpty)
+	jsoniter.RegisterTypeDecoderFunc(typ, decode)
+}
+
+// encode writes an ordered object to a json-iterator stream.
+func encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	obj := (*orderedobject.Object[any])(ptr)
+	stream.WriteObjectStart()
+	first := true
+	for key, value := range obj.All() {
+		if !first {
+			stream.WriteMore()
+		}
+		first = false
+		stream.WriteObjectField(key)
+		stream.WriteVal(value)
+	}
+	stream.WriteObjectEnd()
+}
+
+// isEmpty reports whether an ordered object has no entries.
+func isEmpty(ptr unsafe.Pointer) bool {
+	return (*orderedobject.Object[any])(ptr).IsEmpty()
+}
+
+// decode reads a JSON object from a json-iterator iterator into an ordered object,
+// replacing its entries.
+func decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	obj := (*orderedobject.Object[any])(ptr)
+	obj.DeleteFunc(func(string, any) bool { return true })
+	if iter.WhatIsNext() != jsoniter.ObjectValue {
+		iter.ReportError("decode orderedobject.Object", "expected object")
+		return
+	}
+	readObject(iter, obj)
+}
+
+// readObject reads the entries of a JSON object into obj.
+func readObject(iter *jsoniter.Iterator, obj *orderedobject.Object[any]) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, key string) bool {
+		obj.Set(key, readValue(iter))
+		return iter.Error == nil
+	})
+}
+
+// readValue reads the next JSON value, decoding objects as *orderedobject.Object[any].
+func readValue(iter *jsoniter.Iterator) any {
+	switch iter.WhatIsNext() {
+	case jsoniter.ObjectValue:
+		obj := orderedobject.NewObject[any]()
+		readObject(iter, obj)
+		return obj
+	case jsoniter.ArrayValue:
+		values := []any{}
+		iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+			values = append(values, readValue(iter))
+			return iter.Error == nil
+		})
+		return values
+	default:
+		return iter.Read()
+	}
+}
+
+// Codec returns an orderedobject.Codec backed by a json-iterator configuration, for use
+// with orderedobject.RegisterCodec.
+func Codec(api jsoniter.API) orderedobject.Codec {
+	return codec{api: api}
+}
+
+// codec adapts a json-iterator configuration to orderedobject.Codec.
+type codec struct {
+	api jsoniter.API
+}
+
+// Marshal returns the JSON encoding of v.
+func (c codec) Marshal(v any) ([]byte, error) {
+	return c.api.Marshal(v)
+}
+
+// Unmarshal decodes the JSON value in data into v.
+func (c codec) Unmarshal(data []byte, v any) error {
+	return c.api.Unmarshal(data, v)
+}
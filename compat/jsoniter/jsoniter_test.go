@@ -0,0 +1,66 @@
+package jsoniter
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/kaptinlin/orderedobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	Register()
+}
+
+func TestRegisterMarshal(t *testing.T) {
+	t.Parallel()
+
+	obj := orderedobject.NewObject[any]().
+		Set("zeta", 1).
+		Set("alpha", orderedobject.NewObject[any]().Set("y", true).Set("b", nil)).
+		Set("list", []any{"x", orderedobject.NewObject[any]().Set("k", "v")})
+
+	data, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(obj)
+	require.NoError(t, err)
+	assert.Equal(t, `{"zeta":1,"alpha":{"y":true,"b":null},"list":["x",{"k":"v"}]}`, string(data))
+
+	type wrapper struct {
+		Config *orderedobject.Object[any] `json:"config,omitempty"`
+		Empty  orderedobject.Object[any]  `json:"empty,omitempty"`
+	}
+	data, err = jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(wrapper{Config: obj})
+	require.NoError(t, err)
+	assert.Equal(t, `{"config":{"zeta":1,"alpha":{"y":true,"b":null},"list":["x",{"k":"v"}]}}`, string(data))
+}
+
+func TestRegisterUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	obj := orderedobject.NewObject[any]().Set("stale", 1)
+	err := jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal(
+		[]byte(`{"zeta":1,"alpha":{"y":true,"b":[{"k":"v"}]}}`), obj)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zeta", "alpha"}, obj.Keys())
+
+	alpha, _ := obj.Get("alpha")
+	nested, ok := alpha.(*orderedobject.Object[any])
+	require.True(t, ok)
+	assert.Equal(t, []string{"y", "b"}, nested.Keys())
+
+	err = jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal([]byte(`[1]`), obj)
+	require.Error(t, err)
+}
+
+func TestCodec(t *testing.T) {
+	t.Parallel()
+
+	codec := Codec(jsoniter.ConfigCompatibleWithStandardLibrary)
+	data, err := codec.Marshal(map[string]any{"b": 1, "a": 2})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":2,"b":1}`, string(data))
+
+	var v map[string]any
+	require.NoError(t, codec.Unmarshal(data, &v))
+	assert.Equal(t, map[string]any{"a": float64(2), "b": float64(1)}, v)
+}
@@ -0,0 +1,63 @@
+// Package structpb converts between ordered objects and
+// google.golang.org/protobuf/types/known/structpb values.
+package structpb
+
+import (
+	"github.com/kaptinlin/orderedobject"
+	pb "google.golang.org/protobuf/types/known/structpb"
+)
+
+// ToStructPB converts an ordered object to a protobuf Struct.
+// Nested objects, maps and slices are converted recursively; other values follow
+// structpb.NewValue. Struct fields are unordered, so the key order is not kept.
+func ToStructPB(obj *orderedobject.Object[any]) (*pb.Struct, error) {
+	s := &pb.Struct{Fields: make(map[string]*pb.Value, obj.Length())}
+	for _, entry := range obj.Entries() {
+		value, err := toValue(entry.Value)
+		if err != nil {
+			return nil, err
+		}
+		s.Fields[entry.Key] = value
+	}
+	return s, nil
+}
+
+// FromStructPB converts a protobuf Struct to an ordered object with keys sorted at
+// every level, so its JSON encoding is deterministic. Nested structs become
+// *orderedobject.Object[any] values.
+func FromStructPB(s *pb.Struct) *orderedobject.Object[any] {
+	return orderedobject.FromMapDeep(s.AsMap(), true)
+}
+
+// toValue converts a value to a protobuf Value.
+func toValue(value any) (*pb.Value, error) {
+	switch v := value.(type) {
+	case *orderedobject.Object[any]:
+		s, err := ToStructPB(v)
+		if err != nil {
+			return nil, err
+		}
+		return pb.NewStructValue(s), nil
+	case map[string]any:
+		s := &pb.Struct{Fields: make(map[string]*pb.Value, len(v))}
+		for key, item := range v {
+			field, err := toValue(item)
+			if err != nil {
+				return nil, err
+			}
+			s.Fields[key] = field
+		}
+		return pb.NewStructValue(s), nil
+	case []any:
+		list := &pb.ListValue{Values: make([]*pb.Value, len(v))}
+		for i, item := range v {
+			element, err := toValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list.Values[i] = element
+		}
+		return pb.NewListValue(list), nil
+	}
+	return pb.NewValue(value)
+}
@@ -0,0 +1,47 @@
+package structpb
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/orderedobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestToStructPB(t *testing.T) {
+	t.Parallel()
+
+	obj := orderedobject.NewObject[any]().
+		Set("name", "app").
+		Set("port", 8080).
+		Set("server", orderedobject.NewObject[any]().Set("tls", true)).
+		Set("tags", []any{"a", orderedobject.NewObject[any]().Set("k", nil)})
+
+	s, err := ToStructPB(obj)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":   "app",
+		"port":   float64(8080),
+		"server": map[string]any{"tls": true},
+		"tags":   []any{"a", map[string]any{"k": nil}},
+	}, s.AsMap())
+
+	_, err = ToStructPB(orderedobject.NewObject[any]().Set("ch", make(chan int)))
+	require.Error(t, err)
+}
+
+func TestFromStructPB(t *testing.T) {
+	t.Parallel()
+
+	s, err := pb.NewStruct(map[string]any{
+		"zeta":  1,
+		"alpha": map[string]any{"y": "b", "x": "a"},
+	})
+	require.NoError(t, err)
+
+	obj := FromStructPB(s)
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"alpha":{"x":"a","y":"b"},"zeta":1}`, string(data))
+}
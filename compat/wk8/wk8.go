@@ -0,0 +1,26 @@
+// Package wk8 converts between ordered objects and
+// github.com/wk8/go-ordered-map ordered maps.
+package wk8
+
+import (
+	"github.com/kaptinlin/orderedobject"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+// FromOrderedMap converts a wk8 ordered map with string keys to an ordered object.
+func FromOrderedMap[V any](m *orderedmap.OrderedMap[string, V]) *orderedobject.Object[V] {
+	obj := orderedobject.NewObject[V](m.Len())
+	for pair := m.Oldest(); pair != nil; pair = pair.Next() {
+		obj.Set(pair.Key, pair.Value)
+	}
+	return obj
+}
+
+// ToOrderedMap converts an ordered object to a wk8 ordered map.
+func ToOrderedMap[V any](obj *orderedobject.Object[V]) *orderedmap.OrderedMap[string, V] {
+	m := orderedmap.New[string, V](obj.Length())
+	obj.ForEach(func(key string, value V) {
+		m.Set(key, value)
+	})
+	return m
+}
@@ -0,0 +1,36 @@
+package wk8
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/orderedobject"
+	"github.com/stretchr/testify/assert"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+func TestFromOrderedMap(t *testing.T) {
+	t.Parallel()
+
+	m := orderedmap.New[string, int]()
+	m.Set("z", 1)
+	m.Set("a", 2)
+	m.Set("m", 3)
+
+	obj := FromOrderedMap(m)
+	assert.Equal(t, []string{"z", "a", "m"}, obj.Keys())
+	assert.Equal(t, []int{1, 2, 3}, obj.Values())
+}
+
+func TestToOrderedMap(t *testing.T) {
+	t.Parallel()
+
+	obj := orderedobject.NewObject[string]().
+		Set("b", "x").
+		Set("a", "y")
+
+	m := ToOrderedMap(obj)
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, "b", m.Oldest().Key)
+	assert.Equal(t, "a", m.Newest().Key)
+	assert.Equal(t, "y", m.Value("a"))
+}
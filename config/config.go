@@ -0,0 +1,274 @@
+// Package config loads layered configuration into ordered objects and writes it
+// back without disturbing the key order of the original file.
+//
+// Layers are applied in increasing order of precedence: defaults, the configuration
+// file, environment variables and explicit overrides.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kaptinlin/orderedobject"
+)
+
+// ErrInvalidEnvValue is returned when an environment variable cannot be converted to
+// the type of the value it overrides
+var ErrInvalidEnvValue = errors.New("invalid environment value")
+
+// Config holds the configuration layers.
+type Config struct {
+	defaults  *orderedobject.Object[any]
+	file      *orderedobject.Object[any]
+	env       *orderedobject.Object[any]
+	overrides *orderedobject.Object[any]
+
+	// merged caches the merged layers until a layer changes.
+	merged *orderedobject.Object[any]
+}
+
+// WriteOptions selects the layers WriteFile writes on top of the file layer.
+// Environment variables and overrides often hold secrets, so they are only
+// written when asked for.
+type WriteOptions struct {
+	// Defaults fills in the defaults for keys missing from the file.
+	Defaults bool
+	// Env applies the values read from environment variables.
+	Env bool
+	// Overrides applies the values set with Set.
+	Overrides bool
+}
+
+// New returns a configuration with empty layers.
+func New() *Config {
+	return &Config{
+		defaults:  orderedobject.NewObject[any](),
+		file:      orderedobject.NewObject[any](),
+		env:       orderedobject.NewObject[any](),
+		overrides: orderedobject.NewObject[any](),
+	}
+}
+
+// SetDefaults sets the defaults layer. Nested maps are converted to ordered objects
+// with sorted keys.
+// Returns the configuration for chaining.
+func (c *Config) SetDefaults(defaults *orderedobject.Object[any]) *Config {
+	if obj, ok := orderedobject.Normalize(defaults, true).(*orderedobject.Object[any]); ok && obj != nil {
+		c.defaults = obj
+		c.merged = nil
+	}
+	return c
+}
+
+// LoadFile reads the JSON configuration file at path into the file layer, keeping its
+// key order at every level.
+func (c *Config) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	obj, err := orderedobject.FromJSONDeep(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	c.file = obj
+	c.merged = nil
+	return nil
+}
+
+// LoadEnv reads the environment layer from variables named after the known keys of the
+// defaults and file layers: with prefix "APP_", "server.max_conns" is read from
+// APP_SERVER_MAX_CONNS. Values are converted to the type of the value they override.
+func (c *Config) LoadEnv(prefix string) error {
+	env := orderedobject.NewObject[any]()
+	err := layered(c.defaults, c.file).Walk(func(path []string, key string, value any) error {
+		switch value.(type) {
+		case *orderedobject.Object[any]:
+			return nil
+		case []any, map[string]any:
+			return orderedobject.ErrSkipChildren
+		}
+		keys := append(slices.Clone(path), key)
+		name := prefix + strings.ToUpper(strings.Join(keys, "_"))
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			return nil
+		}
+		parsed, err := parseEnv(raw, value)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidEnvValue, name, err)
+		}
+		return env.SetPath(strings.Join(keys, "."), parsed)
+	})
+	if err != nil {
+		return err
+	}
+	c.env = env
+	c.merged = nil
+	return nil
+}
+
+// Set sets an explicit override at a dotted path, taking precedence over every other layer.
+func (c *Config) Set(path string, value any) error {
+	c.merged = nil
+	return c.overrides.SetPath(path, value)
+}
+
+// Merged returns the merged configuration as a new object. Keys keep the order of the
+// configuration file, followed by keys that only exist in other layers.
+func (c *Config) Merged() *orderedobject.Object[any] {
+	return c.view().DeepClone()
+}
+
+// view returns the merged configuration, merging the layers again only after one
+// of them changed. The result is shared and must not be modified.
+func (c *Config) view() *orderedobject.Object[any] {
+	if c.merged == nil {
+		c.merged = c.merge(WriteOptions{Defaults: true, Env: true, Overrides: true})
+	}
+	return c.merged
+}
+
+// merge merges the file layer with the selected layers. Without any layer selected
+// it returns the file layer itself.
+func (c *Config) merge(opts WriteOptions) *orderedobject.Object[any] {
+	merged := c.file
+	if opts.Defaults {
+		merged = layered(c.defaults, merged)
+	}
+	if opts.Env {
+		merged = orderedobject.DeepMerge(merged, c.env)
+	}
+	if opts.Overrides {
+		merged = orderedobject.DeepMerge(merged, c.overrides)
+	}
+	return merged
+}
+
+// Get returns a copy of the merged value at a dotted path.
+func (c *Config) Get(path string) (any, bool) {
+	value, ok := c.view().GetPath(path)
+	if !ok {
+		return nil, false
+	}
+	// Copy nested objects, maps and slices so callers cannot modify the cache
+	copied, _ := orderedobject.NewObject[any](1).Set("", value).DeepClone().Get("")
+	return copied, true
+}
+
+// GetString returns the merged value at a dotted path as a string.
+func (c *Config) GetString(path string) (string, error) {
+	parent, key, err := c.parent(path)
+	if err != nil {
+		return "", err
+	}
+	return parent.GetString(key)
+}
+
+// GetInt returns the merged value at a dotted path as an int.
+func (c *Config) GetInt(path string) (int, error) {
+	parent, key, err := c.parent(path)
+	if err != nil {
+		return 0, err
+	}
+	return parent.GetInt(key)
+}
+
+// GetFloat64 returns the merged value at a dotted path as a float64.
+func (c *Config) GetFloat64(path string) (float64, error) {
+	parent, key, err := c.parent(path)
+	if err != nil {
+		return 0, err
+	}
+	return parent.GetFloat64(key)
+}
+
+// GetBool returns the merged value at a dotted path as a bool.
+func (c *Config) GetBool(path string) (bool, error) {
+	parent, key, err := c.parent(path)
+	if err != nil {
+		return false, err
+	}
+	return parent.GetBool(key)
+}
+
+// GetDuration returns the merged value at a dotted path as a duration.
+func (c *Config) GetDuration(path string) (time.Duration, error) {
+	parent, key, err := c.parent(path)
+	if err != nil {
+		return 0, err
+	}
+	return parent.GetDuration(key)
+}
+
+// WriteFile atomically writes the file layer to path as indented JSON, keeping its key
+// order. By default only the file layer is written, so values from the environment and
+// explicit overrides, which often hold secrets, stay out of the file; opts selects
+// further layers to write, with keys only found in them following the file's keys.
+func (c *Config) WriteFile(path string, opts ...WriteOptions) error {
+	var o WriteOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return c.merge(o).SaveFile(path, orderedobject.SaveOptions{
+		MarshalOptions: orderedobject.MarshalOptions{Indent: "  "},
+		Perm:           0o600,
+	})
+}
+
+// parent returns the object holding the last key of a dotted path in the merged
+// configuration, together with that key.
+func (c *Config) parent(path string) (*orderedobject.Object[any], string, error) {
+	merged := c.view()
+	idx := strings.LastIndexByte(path, '.')
+	if idx < 0 {
+		return merged, path, nil
+	}
+	value, ok := merged.GetPath(path[:idx])
+	parent, isObject := value.(*orderedobject.Object[any])
+	if !ok || !isObject {
+		return nil, "", fmt.Errorf("%w: %q", orderedobject.ErrKeyNotFound, path)
+	}
+	return parent, path[idx+1:], nil
+}
+
+// layered returns obj with the keys it is missing filled in from defaults at every
+// level. Keys keep the order of obj, followed by the keys only found in defaults.
+func layered(defaults, obj *orderedobject.Object[any]) *orderedobject.Object[any] {
+	result := obj.Clone()
+	for _, entry := range defaults.Entries() {
+		current, ok := result.Get(entry.Key)
+		if !ok {
+			result.Set(entry.Key, entry.Value)
+			continue
+		}
+		currentObj, ok := current.(*orderedobject.Object[any])
+		if !ok {
+			continue
+		}
+		if defaultObj, ok := entry.Value.(*orderedobject.Object[any]); ok {
+			result.Set(entry.Key, layered(defaultObj, currentObj))
+		}
+	}
+	return result
+}
+
+// parseEnv converts a raw environment value to the type of current.
+func parseEnv(raw string, current any) (any, error) {
+	switch current.(type) {
+	case bool:
+		return strconv.ParseBool(raw)
+	case int:
+		return strconv.Atoi(raw)
+	case int64:
+		return strconv.ParseInt(raw, 10, 64)
+	case float64:
+		return strconv.ParseFloat(raw, 64)
+	}
+	return raw, nil
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/orderedobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFile = `{
+  "name": "app",
+  "server": {
+    "port": 8080,
+    "host": "localhost"
+  },
+  "debug": false
+}
+`
+
+func writeTestFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(testFile), 0o600))
+	return path
+}
+
+func TestConfigLayers(t *testing.T) {
+	t.Setenv("APP_SERVER_PORT", "9090")
+	t.Setenv("APP_DEBUG", "true")
+
+	c := New().SetDefaults(orderedobject.NewObject[any]().
+		Set("timeout", "30s").
+		Set("server", map[string]any{"port": 80, "tls": false}))
+	require.NoError(t, c.LoadFile(writeTestFile(t)))
+	require.NoError(t, c.LoadEnv("APP_"))
+	require.NoError(t, c.Set("server.host", "0.0.0.0"))
+
+	port, err := c.GetInt("server.port")
+	require.NoError(t, err)
+	assert.Equal(t, 9090, port)
+
+	host, err := c.GetString("server.host")
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0", host)
+
+	debug, err := c.GetBool("debug")
+	require.NoError(t, err)
+	assert.True(t, debug)
+
+	timeout, err := c.GetDuration("timeout")
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, timeout)
+
+	tls, ok := c.Get("server.tls")
+	assert.True(t, ok)
+	assert.Equal(t, false, tls)
+
+	_, err = c.GetFloat64("missing.key")
+	require.ErrorIs(t, err, orderedobject.ErrKeyNotFound)
+}
+
+func TestConfigLoadEnvInvalid(t *testing.T) {
+	t.Setenv("APP_SERVER_PORT", "not-a-number")
+
+	c := New()
+	require.NoError(t, c.LoadFile(writeTestFile(t)))
+	require.ErrorIs(t, c.LoadEnv("APP_"), ErrInvalidEnvValue)
+}
+
+func TestConfigWriteFile(t *testing.T) {
+	t.Parallel()
+
+	c := New().SetDefaults(orderedobject.NewObject[any]().Set("timeout", "30s"))
+	path := writeTestFile(t)
+	require.NoError(t, c.LoadFile(path))
+	require.NoError(t, c.Set("server.port", 9090))
+	require.NoError(t, c.WriteFile(path, WriteOptions{Defaults: true, Overrides: true}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "name": "app",
+  "server": {
+    "port": 9090,
+    "host": "localhost"
+  },
+  "debug": false,
+  "timeout": "30s"
+}
+`, string(data))
+}
+
+func TestConfigWriteFileLayers(t *testing.T) {
+	t.Setenv("APP_NAME", "secret")
+
+	c := New().SetDefaults(orderedobject.NewObject[any]().Set("timeout", "30s"))
+	path := writeTestFile(t)
+	require.NoError(t, c.LoadFile(path))
+	require.NoError(t, c.LoadEnv("APP_"))
+	require.NoError(t, c.Set("debug", true))
+	require.NoError(t, c.WriteFile(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, testFile, string(data))
+
+	require.NoError(t, c.WriteFile(path, WriteOptions{Env: true}))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"name": "secret"`)
+	assert.Contains(t, string(data), `"debug": false`)
+}
+
+func TestConfigCache(t *testing.T) {
+	t.Parallel()
+
+	c := New()
+	require.NoError(t, c.LoadFile(writeTestFile(t)))
+	port, err := c.GetInt("server.port")
+	require.NoError(t, err)
+	assert.Equal(t, 8080, port)
+	assert.Same(t, c.view(), c.view())
+
+	// Changing a layer refreshes the merged view
+	require.NoError(t, c.Set("server.port", 9090))
+	port, err = c.GetInt("server.port")
+	require.NoError(t, err)
+	assert.Equal(t, 9090, port)
+
+	// Returned values are copies
+	server, _ := c.Get("server")
+	server.(*orderedobject.Object[any]).Set("port", 1)
+	c.Merged().Set("name", "changed")
+	port, _ = c.GetInt("server.port")
+	assert.Equal(t, 9090, port)
+	name, _ := c.GetString("name")
+	assert.Equal(t, "app", name)
+}
+
+func TestConfigLoadFileErrors(t *testing.T) {
+	t.Parallel()
+
+	c := New()
+	require.ErrorIs(t, c.LoadFile(filepath.Join(t.TempDir(), "missing.json")), os.ErrNotExist)
+
+	path := filepath.Join(t.TempDir(), "bad.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[1]`), 0o600))
+	require.ErrorIs(t, c.LoadFile(path), orderedobject.ErrExpectedObjectStart)
+}
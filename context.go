@@ -0,0 +1,37 @@
+package orderedobject
+
+import (
+	"context"
+	"io"
+)
+
+// DecodeContext decodes a single JSON object from r like FromJSONDeep, checking ctx
+// before every read from r so that decoding a large body can be aborted when ctx is
+// canceled or times out. It returns the context's error in that case.
+func DecodeContext(ctx context.Context, r io.Reader) (*Object[any], error) {
+	obj, err := NewDecoderContext(ctx, r).Next()
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return obj, nil
+}
+
+// NewDecoderContext returns a Decoder reading JSON objects from r that checks ctx
+// before every read from r, so long streaming decodes stop once ctx is done.
+func NewDecoderContext(ctx context.Context, r io.Reader) *Decoder {
+	return NewDecoder(contextReader{ctx: ctx, r: r})
+}
+
+// contextReader is an io.Reader that fails with the context's error once it is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read reads from the underlying reader unless the context is done.
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
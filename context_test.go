@@ -0,0 +1,75 @@
+package orderedobject
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cancelingReader cancels a context after a number of reads.
+type cancelingReader struct {
+	r      io.Reader
+	reads  int
+	after  int
+	cancel context.CancelFunc
+}
+
+func (r *cancelingReader) Read(p []byte) (int, error) {
+	r.reads++
+	if r.reads == r.after {
+		r.cancel()
+	}
+	return r.r.Read(p)
+}
+
+func TestDecodeContext(t *testing.T) {
+	t.Parallel()
+
+	obj, err := DecodeContext(context.Background(), strings.NewReader(`{"b":1,"a":{"y":2,"x":3}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, obj.Keys())
+	nested, _ := obj.Get("a")
+	assert.Equal(t, []string{"y", "x"}, nested.(*Object[any]).Keys())
+
+	_, err = DecodeContext(context.Background(), strings.NewReader(``))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestDecodeContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := DecodeContext(ctx, strings.NewReader(`{"a":1}`))
+	require.ErrorIs(t, err, context.Canceled)
+
+	// Cancel midway through a body delivered one byte per read
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	body := `{"items":[` + strings.Repeat(`{"k":"v"},`, 1000) + `{}]}`
+	r := &cancelingReader{r: iotest.OneByteReader(strings.NewReader(body)), after: 100, cancel: cancel}
+	_, err = DecodeContext(ctx, r)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, r.reads, len(body))
+}
+
+func TestNewDecoderContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dec := NewDecoderContext(ctx, iotest.OneByteReader(strings.NewReader(`{"a":1} {"b":2}`)))
+
+	obj, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, obj.Keys())
+
+	cancel()
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,72 @@
+package orderedobject
+
+// Cloner copies a single value for DeepClone. It returns the copy and true, or
+// false to let DeepClone copy the value itself.
+type Cloner func(value any) (any, bool)
+
+// DeepClone returns a copy of the ordered object in which nested *Object[any] values,
+// map[string]any values and []any values are copied recursively, so the clone shares
+// no mutable containers with the original. Other values are copied by assignment
+// unless cloner handles them; cloner is consulted for every value, including nested ones.
+func (object *Object[V]) DeepClone(cloner ...Cloner) *Object[V] {
+	var fn Cloner
+	if len(cloner) > 0 {
+		fn = cloner[0]
+	}
+	clone := object.Clone()
+	for i, entry := range clone.entries {
+		clone.entries[i].Value = deepCloneAs(entry.Value, fn)
+	}
+	if clone.ext == nil {
+		return clone
+	}
+	clone.reindex()
+	for i, d := range clone.ext.deleted {
+		clone.ext.deleted[i].entry.Value = deepCloneAs(d.entry.Value, fn)
+	}
+	return clone
+}
+
+// deepCloneAs deep copies value and converts the copy back to V.
+// If the copy is not a V, the original value is returned.
+func deepCloneAs[V any](value V, fn Cloner) V {
+	if copied, ok := deepCloneValue(any(value), fn).(V); ok {
+		return copied
+	}
+	return value
+}
+
+// deepCloneValue recursively copies objects, maps and slices within value.
+func deepCloneValue(value any, fn Cloner) any {
+	if fn != nil {
+		if copied, ok := fn(value); ok {
+			return copied
+		}
+	}
+	switch v := value.(type) {
+	case *Object[any]:
+		if v == nil {
+			return v
+		}
+		return v.DeepClone(fn)
+	case map[string]any:
+		if v == nil {
+			return v
+		}
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[k] = deepCloneValue(item, fn)
+		}
+		return m
+	case []any:
+		if v == nil {
+			return v
+		}
+		s := make([]any, len(v))
+		for i, item := range v {
+			s[i] = deepCloneValue(item, fn)
+		}
+		return s
+	}
+	return value
+}
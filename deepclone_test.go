@@ -0,0 +1,66 @@
+package orderedobject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeepClone(t *testing.T) {
+	t.Parallel()
+
+	server := NewObject[any]().Set("port", 8080)
+	tags := []any{"a", map[string]any{"k": "v"}}
+	original := NewObject[any]().
+		Set("server", server).
+		Set("tags", tags).
+		Set("name", "app")
+
+	clone := original.DeepClone()
+	assert.True(t, original.Equal(clone))
+
+	clonedServer, _ := clone.Get("server")
+	clonedServer.(*Object[any]).Set("port", 9090)
+	clonedTags, _ := clone.Get("tags")
+	clonedTags.([]any)[1].(map[string]any)["k"] = "changed"
+
+	port, _ := server.Get("port")
+	assert.Equal(t, 8080, port)
+	assert.Equal(t, "v", tags[1].(map[string]any)["k"])
+}
+
+func TestDeepCloneCloner(t *testing.T) {
+	t.Parallel()
+
+	type timestamps struct{ Created *time.Time }
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	original := NewObject[any]().
+		Set("meta", NewObject[any]().Set("ts", &timestamps{Created: &created}))
+
+	clone := original.DeepClone(func(value any) (any, bool) {
+		if ts, ok := value.(*timestamps); ok {
+			c := *ts.Created
+			return &timestamps{Created: &c}, true
+		}
+		return nil, false
+	})
+
+	meta, _ := clone.Get("meta")
+	ts, _ := meta.(*Object[any]).Get("ts")
+	*ts.(*timestamps).Created = created.Add(time.Hour)
+	assert.Equal(t, 2024, created.Year())
+	assert.Equal(t, 0, created.Hour())
+}
+
+func TestDeepCloneTyped(t *testing.T) {
+	t.Parallel()
+
+	original := NewObject[[]any]().Set("list", []any{1, 2})
+	clone := original.DeepClone()
+
+	list, _ := clone.Get("list")
+	list[0] = 100
+	value, _ := original.Get("list")
+	assert.Equal(t, []any{1, 2}, value)
+}
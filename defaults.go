@@ -0,0 +1,56 @@
+package orderedobject
+
+// GetOrDefault returns the value for key, or fallback if the key does not exist.
+func (object *Object[V]) GetOrDefault(key string, fallback V) V {
+	if value, ok := object.Get(key); ok {
+		return value
+	}
+	return fallback
+}
+
+// SetDefaults fills in the keys missing from the object with the entries of defaults,
+// in order. Existing keys keep their value and position, and missing keys are appended
+// in the order of defaults. Unlike SetDefault, the values are runtime fallbacks only and
+// are not registered as the documented defaults reported by Default and ExportDocumented.
+// Returns the object for chaining.
+func (object *Object[V]) SetDefaults(defaults *Object[V]) *Object[V] {
+	if defaults == nil {
+		return object
+	}
+	for _, entry := range defaults.entries {
+		object.setIfAbsent(entry.Key, entry.Value)
+	}
+	return object
+}
+
+// setIfAbsent sets value for key if the key does not exist yet.
+func (object *Object[V]) setIfAbsent(key string, value V) {
+	if !object.Has(key) {
+		object.Set(key, value)
+	}
+}
+
+// GetOrCompute returns the value for key if it exists. Otherwise it calls compute,
+// appends the result under key and returns it. compute is not called for existing keys.
+// The lookup is a Get, so it is traced and reports deprecated keys. The object is not
+// safe for concurrent use: callers sharing it must hold a lock across the call for the
+// lookup and the insert to be atomic.
+func (object *Object[V]) GetOrCompute(key string, compute func() V) V {
+	if value, ok := object.Get(key); ok {
+		return value
+	}
+	value := compute()
+	object.Set(key, value)
+	return value
+}
+
+// LoadOrStore returns the existing value for key and true if the key exists.
+// Otherwise it appends value under key and returns it with false. Like GetOrCompute,
+// it looks the key up with Get and requires callers sharing the object to hold a lock.
+func (object *Object[V]) LoadOrStore(key string, value V) (V, bool) {
+	if existing, ok := object.Get(key); ok {
+		return existing, true
+	}
+	object.Set(key, value)
+	return value, false
+}
@@ -0,0 +1,98 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrDefault(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("port", 9090)
+
+	assert.Equal(t, 9090, obj.GetOrDefault("port", 8080))
+	assert.Equal(t, 30, obj.GetOrDefault("timeout", 30))
+	assert.False(t, obj.Has("timeout"))
+}
+
+func TestSetDefaults(t *testing.T) {
+	t.Parallel()
+
+	defaults := NewObject[any]().
+		Set("host", "0.0.0.0").
+		Set("port", 8080).
+		Set("debug", false)
+
+	config := NewObject[any]().
+		Set("port", 9090).
+		Set("name", "app").
+		SetDefaults(defaults).
+		SetDefaults(nil)
+
+	assert.Equal(t, []string{"port", "name", "host", "debug"}, config.Keys())
+	assert.Equal(t, []any{9090, "app", "0.0.0.0", false}, config.Values())
+
+	// Runtime fallbacks are not documented defaults
+	_, ok := config.Default("port")
+	assert.False(t, ok)
+	assert.Nil(t, config.ext)
+
+	config.SetDefault("port", 80)
+	def, ok := config.Default("port")
+	assert.True(t, ok)
+	assert.Equal(t, 80, def)
+	doc, err := config.ExportDocumented(DocYAML)
+	require.NoError(t, err)
+	assert.Equal(t, "# Default: 80\nport: 9090\nname: \"app\"\nhost: \"0.0.0.0\"\ndebug: false\n", string(doc))
+}
+
+func TestGetOrCompute(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	compute := func() []string {
+		calls++
+		return []string{"computed"}
+	}
+
+	cache := NewObject[[]string]().Set("a", []string{"cached"})
+
+	assert.Equal(t, []string{"cached"}, cache.GetOrCompute("a", compute))
+	assert.Equal(t, 0, calls)
+
+	assert.Equal(t, []string{"computed"}, cache.GetOrCompute("b", compute))
+	assert.Equal(t, []string{"computed"}, cache.GetOrCompute("b", compute))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []string{"a", "b"}, cache.Keys())
+}
+
+func TestLoadOrStore(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("a", 1)
+
+	value, loaded := obj.LoadOrStore("a", 10)
+	assert.True(t, loaded)
+	assert.Equal(t, 1, value)
+
+	value, loaded = obj.LoadOrStore("b", 2)
+	assert.False(t, loaded)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, []string{"a", "b"}, obj.Keys())
+
+	// Lookups are traced and report deprecated keys like Get
+	var events []LookupEvent
+	var warnings []string
+	obj.Trace("cache", func(event LookupEvent) { events = append(events, event) }).
+		MarkDeprecated("a", "use b").
+		OnDeprecated(func(warning DeprecationWarning) { warnings = append(warnings, warning.Key) })
+	obj.LoadOrStore("a", 0)
+	obj.GetOrCompute("c", func() int { return 3 })
+	assert.Equal(t, []LookupEvent{
+		{Key: "a", Found: true, Label: "cache"},
+		{Key: "c", Found: false, Label: "cache"},
+	}, events)
+	assert.Equal(t, []string{"a"}, warnings)
+}
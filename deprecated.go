@@ -0,0 +1,68 @@
+package orderedobject
+
+// DeprecationSource identifies how a deprecated key was accessed.
+type DeprecationSource int
+
+const (
+	// DeprecationRead reports a deprecated key that was read with Get.
+	DeprecationRead DeprecationSource = iota
+	// DeprecationDecode reports a deprecated key that was found in decoded input.
+	DeprecationDecode
+)
+
+// String returns the name of the deprecation source.
+func (source DeprecationSource) String() string {
+	switch source {
+	case DeprecationRead:
+		return "read"
+	case DeprecationDecode:
+		return "decode"
+	}
+	return "unknown"
+}
+
+// DeprecationWarning describes an access to a key marked as deprecated.
+type DeprecationWarning struct {
+	Key     string
+	Message string
+	Source  DeprecationSource
+}
+
+// MarkDeprecated marks a key as deprecated with a message explaining what to use instead.
+// The key may also be an alias registered with Alias.
+// Returns the object for chaining.
+func (object *Object[V]) MarkDeprecated(key, message string) *Object[V] {
+	ext := object.extend()
+	if ext.deprecated == nil {
+		ext.deprecated = make(map[string]string)
+	}
+	ext.deprecated[key] = message
+	return object
+}
+
+// OnDeprecated sets the hook invoked when a deprecated key is read or decoded from input.
+// Returns the object for chaining.
+func (object *Object[V]) OnDeprecated(fn func(warning DeprecationWarning)) *Object[V] {
+	object.extend().onDeprecated = fn
+	return object
+}
+
+// IsDeprecated returns whether the key has been marked as deprecated.
+func (object *Object[V]) IsDeprecated(key string) bool {
+	if object.ext == nil {
+		return false
+	}
+	_, ok := object.ext.deprecated[key]
+	return ok
+}
+
+// warnDeprecated invokes the deprecation hook if key is deprecated.
+func (object *Object[V]) warnDeprecated(key string, source DeprecationSource) {
+	ext := object.ext
+	if ext == nil || ext.onDeprecated == nil {
+		return
+	}
+	if message, ok := ext.deprecated[key]; ok {
+		ext.onDeprecated(DeprecationWarning{Key: key, Message: message, Source: source})
+	}
+}
@@ -0,0 +1,55 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkDeprecated(t *testing.T) {
+	t.Parallel()
+
+	var warnings []DeprecationWarning
+	obj := NewObject[any]().
+		Set("max_conn", 10).
+		Set("timeout", 5).
+		MarkDeprecated("max_conn", "use max_connections").
+		OnDeprecated(func(warning DeprecationWarning) {
+			warnings = append(warnings, warning)
+		})
+
+	assert.True(t, obj.IsDeprecated("max_conn"))
+	assert.False(t, obj.IsDeprecated("timeout"))
+
+	obj.Get("timeout")
+	assert.Empty(t, warnings)
+
+	value, found := obj.Get("max_conn")
+	assert.True(t, found)
+	assert.Equal(t, 10, value)
+	assert.Equal(t, []DeprecationWarning{
+		{Key: "max_conn", Message: "use max_connections", Source: DeprecationRead},
+	}, warnings)
+}
+
+func TestDeprecatedDecode(t *testing.T) {
+	t.Parallel()
+
+	var warnings []DeprecationWarning
+	obj := NewObject[any]().
+		Alias("max_conn", "max_connections").
+		MarkDeprecated("max_conn", "renamed to max_connections").
+		OnDeprecated(func(warning DeprecationWarning) {
+			warnings = append(warnings, warning)
+		})
+
+	err := obj.UnmarshalJSON([]byte(`{"max_conn":10,"timeout":5}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"max_connections", "timeout"}, obj.Keys())
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "max_conn", warnings[0].Key)
+	assert.Equal(t, DeprecationDecode, warnings[0].Source)
+	assert.Equal(t, "decode", warnings[0].Source.String())
+}
@@ -0,0 +1,175 @@
+package orderedobject
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ChangeType identifies the kind of a Change reported by Diff.
+type ChangeType int
+
+const (
+	// ChangeAdded reports a key that only exists in the second object.
+	ChangeAdded ChangeType = iota
+	// ChangeRemoved reports a key that only exists in the first object.
+	ChangeRemoved
+	// ChangeModified reports a key whose value differs between the objects.
+	ChangeModified
+	// ChangeMoved reports a key whose position relative to the other keys changed.
+	ChangeMoved
+)
+
+// String returns the name of the change type.
+func (changeType ChangeType) String() string {
+	switch changeType {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	case ChangeMoved:
+		return "moved"
+	}
+	return "unknown"
+}
+
+// Change describes a single difference between two objects.
+type Change struct {
+	Type ChangeType
+	// Path is the key path from the root object to the changed key.
+	Path []string
+	// From is the value in the first object; nil for added keys.
+	From any
+	// To is the value in the second object; nil for removed keys.
+	To any
+	// FromIndex is the position of the key in the first object, or -1 if added.
+	FromIndex int
+	// ToIndex is the position of the key in the second object, or -1 if removed.
+	ToIndex int
+}
+
+// Diff compares a with b and returns the changes that turn a into b.
+// Removed keys are reported first in the order of a, followed by added, moved and
+// modified keys in the order of b. Nested *Object[any] values present on both sides
+// are compared recursively, so their changes are reported with a longer path.
+// A key is reported as moved when it is not part of the longest sequence of keys
+// that both objects share in the same relative order.
+// Keys are compared exactly, ignoring aliases, case fallback and KeyEqual.
+func Diff(a, b *Object[any]) []Change {
+	return diffObjects(nil, a, b, nil)
+}
+
+// diffObjects appends the changes between a and b to changes.
+func diffObjects(path []string, a, b *Object[any], changes []Change) []Change {
+	a.expire()
+	b.expire()
+	inA, inB := keyPositions(a), keyPositions(b)
+	for i, entry := range a.entries {
+		if _, ok := inB[entry.Key]; !ok {
+			changes = append(changes, Change{
+				Type:      ChangeRemoved,
+				Path:      appendPath(path, entry.Key),
+				From:      entry.Value,
+				FromIndex: i,
+				ToIndex:   -1,
+			})
+		}
+	}
+
+	stable := stableKeys(a, inB)
+	for j, entry := range b.entries {
+		i, ok := inA[entry.Key]
+		if !ok {
+			changes = append(changes, Change{
+				Type:      ChangeAdded,
+				Path:      appendPath(path, entry.Key),
+				To:        entry.Value,
+				FromIndex: -1,
+				ToIndex:   j,
+			})
+			continue
+		}
+
+		from := a.entries[i].Value
+		if _, ok := stable[entry.Key]; !ok {
+			changes = append(changes, Change{
+				Type:      ChangeMoved,
+				Path:      appendPath(path, entry.Key),
+				From:      from,
+				To:        entry.Value,
+				FromIndex: i,
+				ToIndex:   j,
+			})
+		}
+		if equalValues(from, entry.Value, false) {
+			continue
+		}
+		if fromObject, ok := from.(*Object[any]); ok && fromObject != nil {
+			if toObject, ok := entry.Value.(*Object[any]); ok && toObject != nil {
+				changes = diffObjects(appendPath(path, entry.Key), fromObject, toObject, changes)
+				continue
+			}
+		}
+		changes = append(changes, Change{
+			Type:      ChangeModified,
+			Path:      appendPath(path, entry.Key),
+			From:      from,
+			To:        entry.Value,
+			FromIndex: i,
+			ToIndex:   j,
+		})
+	}
+	return changes
+}
+
+// keyPositions maps the keys of object to their positions.
+func keyPositions(object *Object[any]) map[string]int {
+	positions := make(map[string]int, len(object.entries))
+	for i, entry := range object.entries {
+		positions[entry.Key] = i
+	}
+	return positions
+}
+
+// stableKeys returns the longest common subsequence of the keys of a and of the
+// object whose key positions are given, which are the keys that kept their relative
+// order. Keys are unique, so this is the longest increasing run of the positions of
+// the keys of a, found in O(n log n) time.
+func stableKeys(a *Object[any], positions map[string]int) map[string]struct{} {
+	var keys []string
+	var seq []int
+	for _, entry := range a.entries {
+		if j, ok := positions[entry.Key]; ok {
+			keys = append(keys, entry.Key)
+			seq = append(seq, j)
+		}
+	}
+
+	// tails[k] is the index in seq of the smallest tail of an increasing run of
+	// length k+1, and prev links each element to its predecessor in the run.
+	var tails []int
+	prev := make([]int, len(seq))
+	for i, j := range seq {
+		k, _ := slices.BinarySearchFunc(tails, j, func(t, target int) int {
+			return cmp.Compare(seq[t], target)
+		})
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	stable := make(map[string]struct{}, len(tails))
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			stable[keys[i]] = struct{}{}
+		}
+	}
+	return stable
+}
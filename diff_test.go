@@ -0,0 +1,66 @@
+package orderedobject
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	a := NewObject[any]().
+		Set("name", "app").
+		Set("version", "1.0.0").
+		Set("server", NewObject[any]().
+			Set("host", "localhost").
+			Set("port", 8080)).
+		Set("debug", true)
+
+	b := NewObject[any]().
+		Set("version", "1.1.0").
+		Set("server", NewObject[any]().
+			Set("port", 8080).
+			Set("host", "0.0.0.0")).
+		Set("name", "app").
+		Set("license", "MIT")
+
+	assert.Equal(t, []Change{
+		{Type: ChangeRemoved, Path: []string{"debug"}, From: true, FromIndex: 3, ToIndex: -1},
+		{Type: ChangeModified, Path: []string{"version"}, From: "1.0.0", To: "1.1.0", FromIndex: 1, ToIndex: 0},
+		{Type: ChangeMoved, Path: []string{"server", "host"}, From: "localhost", To: "0.0.0.0", FromIndex: 0, ToIndex: 1},
+		{Type: ChangeModified, Path: []string{"server", "host"}, From: "localhost", To: "0.0.0.0", FromIndex: 0, ToIndex: 1},
+		{Type: ChangeMoved, Path: []string{"name"}, From: "app", To: "app", FromIndex: 0, ToIndex: 2},
+		{Type: ChangeAdded, Path: []string{"license"}, To: "MIT", FromIndex: -1, ToIndex: 3},
+	}, Diff(a, b))
+}
+
+func TestDiffEqual(t *testing.T) {
+	t.Parallel()
+
+	a := NewObject[any]().Set("a", 1).Set("b", []any{1, 2})
+	assert.Empty(t, Diff(a, a.Clone()))
+	assert.Equal(t, "moved", ChangeMoved.String())
+}
+
+func TestDiffMoves(t *testing.T) {
+	t.Parallel()
+
+	// Only "e" and "a" left the longest run b, c, d
+	a := NewObject[any]().Set("a", 1).Set("b", 2).Set("c", 3).Set("d", 4).Set("e", 5)
+	b := NewObject[any]().Set("e", 5).Set("b", 2).Set("c", 3).Set("a", 1).Set("d", 4)
+	assert.Equal(t, []Change{
+		{Type: ChangeMoved, Path: []string{"e"}, From: 5, To: 5, FromIndex: 4, ToIndex: 0},
+		{Type: ChangeMoved, Path: []string{"a"}, From: 1, To: 1, FromIndex: 0, ToIndex: 3},
+	}, Diff(a, b))
+
+	// Large objects are compared without a quadratic table
+	large, reversed := NewObject[any](50000), NewObject[any](50000)
+	for i := range 50000 {
+		large.entries = append(large.entries, Entry[any]{Key: fmt.Sprintf("k%d", i), Value: i})
+		reversed.entries = append(reversed.entries, Entry[any]{Key: fmt.Sprintf("k%d", 49999-i), Value: 49999 - i})
+	}
+	assert.Len(t, Diff(large, reversed), 49999)
+	assert.Empty(t, Diff(large, large.Clone()))
+}
@@ -0,0 +1,113 @@
+package orderedobject
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// DirtyKey is a key reported by ChangedSince.
+type DirtyKey struct {
+	Key string
+	// Type is ChangeAdded, ChangeModified or ChangeRemoved.
+	Type ChangeType
+}
+
+// keyChange records when a key was last changed, added and removed. previous is
+// when the key was added before its last removal, or zero if it existed before
+// recording started.
+type keyChange struct {
+	changed  uint64
+	added    uint64
+	removed  uint64
+	previous uint64
+}
+
+// Checkpoint returns a marker for the current state of the object to pass to
+// ChangedSince. The first call starts recording changes; from then on every set or
+// deleted key is remembered. Changes made by decoding, NormalizeKeys or
+// RestoreSnapshot, and in place inside nested values, are not recorded.
+// Every changed key stays recorded until ForgetChanges drops it, so long-lived
+// objects with changing keys should call ForgetChanges once older checkpoints are
+// no longer needed.
+func (object *Object[V]) Checkpoint() uint64 {
+	ext := object.extend()
+	if ext.changes == nil {
+		ext.changes = make(map[string]keyChange)
+	}
+	return ext.version
+}
+
+// ChangedSince returns the keys added, modified or removed since checkpoint was taken.
+// As with Diff, removed keys come first in the order they were removed, followed by
+// added and modified keys in the current key order. A key added and removed again
+// since the checkpoint is not reported; a key removed and added again is reported
+// as modified.
+func (object *Object[V]) ChangedSince(checkpoint uint64) []DirtyKey {
+	if object.ext == nil || len(object.ext.changes) == 0 {
+		return nil
+	}
+	changes := object.ext.changes
+	present := make(map[string]bool, len(object.entries))
+	for _, entry := range object.entries {
+		present[entry.Key] = true
+	}
+
+	var removed []string
+	for key, change := range changes {
+		if change.removed > checkpoint && change.added <= checkpoint && !present[key] {
+			removed = append(removed, key)
+		}
+	}
+	slices.SortFunc(removed, func(a, b string) int {
+		return cmp.Compare(changes[a].changed, changes[b].changed)
+	})
+
+	var dirty []DirtyKey
+	for _, key := range removed {
+		dirty = append(dirty, DirtyKey{Key: key, Type: ChangeRemoved})
+	}
+	for _, entry := range object.entries {
+		change := changes[entry.Key]
+		switch {
+		case change.added > checkpoint && (change.removed <= checkpoint || change.previous > checkpoint):
+			dirty = append(dirty, DirtyKey{Key: entry.Key, Type: ChangeAdded})
+		case change.changed > checkpoint:
+			dirty = append(dirty, DirtyKey{Key: entry.Key, Type: ChangeModified})
+		}
+	}
+	return dirty
+}
+
+// ForgetChanges drops the changes recorded up to checkpoint, which keeps the memory
+// used by change recording bounded. ChangedSince stays exact for checkpoint and later
+// markers; earlier markers may miss changes.
+func (object *Object[V]) ForgetChanges(checkpoint uint64) {
+	if object.ext == nil {
+		return
+	}
+	maps.DeleteFunc(object.ext.changes, func(_ string, change keyChange) bool {
+		return change.changed <= checkpoint
+	})
+}
+
+// recordChange remembers a change of key once Checkpoint has been called.
+func (object *Object[V]) recordChange(key string, typ ChangeType) {
+	ext := object.ext
+	if ext == nil || ext.changes == nil {
+		return
+	}
+	ext.version++
+	change := ext.changes[key]
+	change.changed = ext.version
+	switch typ {
+	case ChangeAdded:
+		if change.removed > 0 {
+			change.previous = change.added
+		}
+		change.added = ext.version
+	case ChangeRemoved:
+		change.removed = ext.version
+	}
+	ext.changes[key] = change
+}
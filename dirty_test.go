@@ -0,0 +1,103 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedSince(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Reports added, modified and removed keys", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1).Set("b", 2).Set("c", 3)
+		cp := obj.Checkpoint()
+
+		obj.Set("d", 4).Set("b", 20).Delete("c").Delete("a")
+
+		assert.Equal(t, []DirtyKey{
+			{Key: "c", Type: ChangeRemoved},
+			{Key: "a", Type: ChangeRemoved},
+			{Key: "b", Type: ChangeModified},
+			{Key: "d", Type: ChangeAdded},
+		}, obj.ChangedSince(cp))
+	})
+
+	t.Run("Only changes after the checkpoint", func(t *testing.T) {
+		obj := NewObject[int]()
+		first := obj.Checkpoint()
+		obj.Set("a", 1)
+		second := obj.Checkpoint()
+		obj.Set("b", 2)
+
+		assert.Equal(t, []DirtyKey{{Key: "a", Type: ChangeAdded}, {Key: "b", Type: ChangeAdded}}, obj.ChangedSince(first))
+		assert.Equal(t, []DirtyKey{{Key: "b", Type: ChangeAdded}}, obj.ChangedSince(second))
+		assert.Empty(t, obj.ChangedSince(obj.Checkpoint()))
+	})
+
+	t.Run("Added then removed key is not reported", func(t *testing.T) {
+		obj := NewObject[int]()
+		cp := obj.Checkpoint()
+		obj.Set("tmp", 1).Delete("tmp")
+
+		assert.Empty(t, obj.ChangedSince(cp))
+	})
+
+	t.Run("Not recording before the first checkpoint", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1)
+		assert.Nil(t, obj.ChangedSince(0))
+	})
+
+	t.Run("Bulk removals and transactions", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1).Set("b", 2).Set("c", 3)
+		cp := obj.Checkpoint()
+
+		obj.DeleteFunc(func(key string, _ int) bool { return key == "a" })
+		require.NoError(t, obj.Begin().Set("c", 30).Commit())
+
+		assert.Equal(t, []DirtyKey{
+			{Key: "a", Type: ChangeRemoved},
+			{Key: "c", Type: ChangeModified},
+		}, obj.ChangedSince(cp))
+	})
+	t.Run("Removed then added key is modified", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1)
+		tracked := obj.Checkpoint()
+		obj.Set("b", 2)
+		cp := obj.Checkpoint()
+		obj.Delete("a").Set("a", 10).Delete("b").Set("b", 20).Set("c", 3).Delete("c").Set("c", 30)
+
+		assert.Equal(t, []DirtyKey{
+			{Key: "a", Type: ChangeModified},
+			{Key: "b", Type: ChangeModified},
+			{Key: "c", Type: ChangeAdded},
+		}, obj.ChangedSince(cp))
+		assert.Equal(t, []DirtyKey{
+			{Key: "a", Type: ChangeModified},
+			{Key: "b", Type: ChangeAdded},
+			{Key: "c", Type: ChangeAdded},
+		}, obj.ChangedSince(tracked))
+	})
+
+	t.Run("Forgotten changes", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1)
+		obj.Checkpoint()
+		obj.Set("a", 10).Set("b", 2).Delete("a")
+		cp := obj.Checkpoint()
+		obj.Set("c", 3).Set("a", 1)
+
+		obj.ForgetChanges(cp)
+		assert.Len(t, obj.ext.changes, 2)
+		assert.Equal(t, []DirtyKey{
+			{Key: "c", Type: ChangeAdded},
+			{Key: "a", Type: ChangeAdded},
+		}, obj.ChangedSince(cp))
+
+		obj.ForgetChanges(obj.Checkpoint())
+		assert.Empty(t, obj.ext.changes)
+		obj.Delete("b")
+		assert.Equal(t, []DirtyKey{{Key: "b", Type: ChangeRemoved}}, obj.ChangedSince(cp))
+		NewObject[int]().ForgetChanges(0)
+	})
+}
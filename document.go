@@ -0,0 +1,236 @@
+package orderedobject
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+)
+
+// ErrUnknownDocFormat is returned when ExportDocumented is called with an unsupported format
+var ErrUnknownDocFormat = errors.New("unknown documented export format")
+
+// DocFormat selects the output format of ExportDocumented.
+type DocFormat int
+
+const (
+	// DocJSONC writes JSON with // comments.
+	DocJSONC DocFormat = iota
+	// DocYAML writes YAML with # comments.
+	DocYAML
+)
+
+// SetDefault registers value as the default for key and sets it if the key does not
+// exist yet. Existing keys keep their value and position.
+// Returns the object for chaining.
+func (object *Object[V]) SetDefault(key string, value V) *Object[V] {
+	key = object.resolveKey(key)
+	ext := object.extend()
+	if ext.defaults == nil {
+		ext.defaults = make(map[string]V)
+	}
+	ext.defaults[key] = value
+	object.setIfAbsent(key, value)
+	return object
+}
+
+// Default returns the default registered for key with SetDefault.
+func (object *Object[V]) Default(key string) (V, bool) {
+	if object.ext == nil {
+		var zero V
+		return zero, false
+	}
+	value, ok := object.ext.defaults[object.resolveKey(key)]
+	return value, ok
+}
+
+// Describe attaches a description to key, which ExportDocumented emits as a comment.
+// Returns the object for chaining.
+func (object *Object[V]) Describe(key, description string) *Object[V] {
+	key = object.resolveKey(key)
+	ext := object.extend()
+	if ext.descriptions == nil {
+		ext.descriptions = make(map[string]string)
+	}
+	ext.descriptions[key] = description
+	return object
+}
+
+// Description returns the description attached to key with Describe.
+func (object *Object[V]) Description(key string) string {
+	if object.ext == nil {
+		return ""
+	}
+	return object.ext.descriptions[object.resolveKey(key)]
+}
+
+// ExportDocumented writes the object as a commented configuration template in key order.
+// Descriptions are emitted as comments above their keys, followed by the default when
+// the current value differs from it. Descriptions of nested *Object[any] values are
+// emitted as well.
+func (object *Object[V]) ExportDocumented(format DocFormat) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case DocJSONC:
+		err = writeDocumentedJSONC(&buf, object, "")
+		buf.WriteByte('\n')
+	case DocYAML:
+		err = writeDocumentedYAML(&buf, object, "")
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownDocFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// docComments returns the comment lines documenting key.
+func (object *Object[V]) docComments(key string, value V) ([]string, error) {
+	ext := object.ext
+	if ext == nil {
+		return nil, nil
+	}
+	var lines []string
+	if description := ext.descriptions[key]; description != "" {
+		lines = strings.Split(description, "\n")
+	}
+	if def, ok := ext.defaults[key]; ok && !equalValues(any(def), any(value), false) {
+		data, err := json.Marshal(def, json.Deterministic(true))
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, "Default: "+string(data))
+	}
+	return lines, nil
+}
+
+// writeDocumentedJSONC writes object as indented JSON with // comments.
+func writeDocumentedJSONC[V any](buf *bytes.Buffer, object *Object[V], indent string) error {
+	if len(object.entries) == 0 {
+		buf.WriteString("{}")
+		return nil
+	}
+	inner := indent + "  "
+	buf.WriteString("{\n")
+	for i, entry := range object.entries {
+		comments, err := object.docComments(entry.Key, entry.Value)
+		if err != nil {
+			return err
+		}
+		for _, line := range comments {
+			buf.WriteString(inner + "// " + line + "\n")
+		}
+		key, err := json.Marshal(entry.Key)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(inner)
+		buf.Write(key)
+		buf.WriteString(": ")
+		if nested, ok := any(entry.Value).(*Object[any]); ok && nested != nil {
+			if err := writeDocumentedJSONC(buf, nested, inner); err != nil {
+				return err
+			}
+		} else {
+			data, err := json.Marshal(entry.Value, json.Deterministic(true))
+			if err != nil {
+				return err
+			}
+			buf.Write(data)
+		}
+		if i < len(object.entries)-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(indent + "}")
+	return nil
+}
+
+// plainYAMLKey matches keys that can be written in YAML without quotes.
+var plainYAMLKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// writeDocumentedYAML writes object as a YAML block mapping with # comments.
+func writeDocumentedYAML[V any](buf *bytes.Buffer, object *Object[V], indent string) error {
+	for _, entry := range object.entries {
+		comments, err := object.docComments(entry.Key, entry.Value)
+		if err != nil {
+			return err
+		}
+		for _, line := range comments {
+			buf.WriteString(indent + "# " + line + "\n")
+		}
+		if err := writeYAMLEntry(buf, entry.Key, any(entry.Value), indent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeYAMLEntry writes a single key and its value as YAML.
+func writeYAMLEntry(buf *bytes.Buffer, key string, value any, indent string) error {
+	if plainYAMLKey.MatchString(key) {
+		buf.WriteString(indent + key + ":")
+	} else {
+		data, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(indent + string(data) + ":")
+	}
+	return writeYAMLValue(buf, value, indent)
+}
+
+// writeYAMLValue writes a value following a mapping key or sequence dash.
+// Objects, maps and slices are written as nested blocks; scalars are written as
+// JSON, which YAML accepts as flow scalars.
+func writeYAMLValue(buf *bytes.Buffer, value any, indent string) error {
+	inner := indent + "  "
+	switch v := value.(type) {
+	case *Object[any]:
+		if v != nil && v.Length() > 0 {
+			buf.WriteByte('\n')
+			return writeDocumentedYAML(buf, v, inner)
+		}
+		buf.WriteString(" {}\n")
+		return nil
+	case map[string]any:
+		if len(v) > 0 {
+			buf.WriteByte('\n')
+			for _, k := range slices.Sorted(maps.Keys(v)) {
+				if err := writeYAMLEntry(buf, k, v[k], inner); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		buf.WriteString(" {}\n")
+		return nil
+	case []any:
+		if len(v) > 0 {
+			buf.WriteByte('\n')
+			for _, item := range v {
+				buf.WriteString(inner + "-")
+				if err := writeYAMLValue(buf, item, inner); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		buf.WriteString(" []\n")
+		return nil
+	}
+	data, err := json.Marshal(value, json.Deterministic(true))
+	if err != nil {
+		return err
+	}
+	buf.WriteString(" " + string(data) + "\n")
+	return nil
+}
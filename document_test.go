@@ -0,0 +1,94 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDocumentedConfig() *Object[any] {
+	server := NewObject[any]().
+		SetDefault("host", "0.0.0.0").
+		Describe("host", "Address to listen on.").
+		SetDefault("port", 8080).
+		Describe("port", "TCP port.\nMust be above 1024.")
+
+	return NewObject[any]().
+		Set("name", "app").
+		Describe("name", "Service name.").
+		SetDefault("server", server).
+		Set("tags", []any{"web", map[string]any{"tier": 1}})
+}
+
+func TestSetDefault(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().
+		Set("b", 1).
+		SetDefault("a", 10).
+		SetDefault("b", 20)
+
+	assert.Equal(t, []string{"b", "a"}, obj.Keys())
+	assert.Equal(t, []int{1, 10}, obj.Values())
+
+	def, ok := obj.Default("b")
+	assert.True(t, ok)
+	assert.Equal(t, 20, def)
+
+	obj.Describe("a", "The a value.")
+	assert.Equal(t, "The a value.", obj.Description("a"))
+	assert.Empty(t, obj.Description("b"))
+}
+
+func TestExportDocumentedJSONC(t *testing.T) {
+	t.Parallel()
+
+	obj := newDocumentedConfig()
+	server, _ := obj.Get("server")
+	server.(*Object[any]).Set("port", 9090)
+
+	data, err := obj.ExportDocumented(DocJSONC)
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  // Service name.
+  "name": "app",
+  "server": {
+    // Address to listen on.
+    "host": "0.0.0.0",
+    // TCP port.
+    // Must be above 1024.
+    // Default: 8080
+    "port": 9090
+  },
+  "tags": ["web",{"tier":1}]
+}
+`, string(data))
+}
+
+func TestExportDocumentedYAML(t *testing.T) {
+	t.Parallel()
+
+	obj := newDocumentedConfig().Set("empty", NewObject[any]()).Set("my key", nil)
+
+	data, err := obj.ExportDocumented(DocYAML)
+	require.NoError(t, err)
+	assert.Equal(t, `# Service name.
+name: "app"
+server:
+  # Address to listen on.
+  host: "0.0.0.0"
+  # TCP port.
+  # Must be above 1024.
+  port: 8080
+tags:
+  - "web"
+  -
+    tier: 1
+empty: {}
+"my key": null
+`, string(data))
+
+	_, err = obj.ExportDocumented(DocFormat(99))
+	assert.ErrorIs(t, err, ErrUnknownDocFormat)
+}
@@ -0,0 +1,57 @@
+package orderedobject
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DumpOptions configures the tree rendered by Dump.
+type DumpOptions struct {
+	// Indent is repeated once per nesting level. Defaults to two spaces.
+	Indent string
+	// HideTypes omits the type annotation after each key.
+	HideTypes bool
+}
+
+// Dump writes an indented tree of the object to w for debugging, one key per line,
+// descending into nested *Object[any] values, maps and slices. Each key is annotated
+// with the type of its value unless opts.HideTypes is set.
+func (object *Object[V]) Dump(w io.Writer, opts DumpOptions) error {
+	indent := opts.Indent
+	if indent == "" {
+		indent = "  "
+	}
+	return object.Walk(func(path []string, key string, value any) error {
+		var sb strings.Builder
+		sb.WriteString(strings.Repeat(indent, len(path)))
+		sb.WriteString(key)
+		if !opts.HideTypes {
+			fmt.Fprintf(&sb, " (%s)", dumpType(value))
+		}
+		sb.WriteByte(':')
+		switch value.(type) {
+		case *Object[any], map[string]any, []any:
+		case nil:
+			sb.WriteString(" null")
+		default:
+			fmt.Fprintf(&sb, " %v", value)
+		}
+		sb.WriteByte('\n')
+		_, err := io.WriteString(w, sb.String())
+		return err
+	})
+}
+
+// dumpType returns the type annotation of a value.
+func dumpType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case *Object[any], map[string]any:
+		return "object"
+	case []any:
+		return fmt.Sprintf("array, %d", len(v))
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,35 @@
+package orderedobject
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDump(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("name", "app").
+		Set("server", NewObject[any]().
+			Set("port", 8080).
+			Set("tls", nil)).
+		Set("tags", []any{"a", true})
+
+	var buf bytes.Buffer
+	require.NoError(t, obj.Dump(&buf, DumpOptions{}))
+	assert.Equal(t, `name (string): app
+server (object):
+  port (int): 8080
+  tls (null): null
+tags (array, 2):
+  0 (string): a
+  1 (bool): true
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, obj.Dump(&buf, DumpOptions{Indent: "\t", HideTypes: true}))
+	assert.Equal(t, "name: app\nserver:\n\tport: 8080\n\ttls: null\ntags:\n\t0: a\n\t1: true\n", buf.String())
+}
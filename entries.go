@@ -0,0 +1,88 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ErrInvalidPairs is returned when SetPairs receives malformed key-value pairs
+var ErrInvalidPairs = errors.New("invalid key-value pairs")
+
+// NewObjectFromEntries creates an ordered object from entries in a single allocation.
+// If a key appears more than once, the last value wins and the first position is kept.
+func NewObjectFromEntries[V any](entries ...Entry[V]) *Object[V] {
+	return NewObject[V](len(entries)).SetMany(entries...)
+}
+
+// SetMany sets each entry in order as if by Set, growing the object once up front.
+// Returns the object for chaining.
+func (object *Object[V]) SetMany(entries ...Entry[V]) *Object[V] {
+	object.entries = slices.Grow(object.entries, len(entries))
+	for _, entry := range entries {
+		object.Set(entry.Key, entry.Value)
+	}
+	return object
+}
+
+// Concat appends the entries of others to the object in order, stitching document
+// fragments together. The policy selects what happens to a key the object already
+// has, including keys appended from an earlier object: CollisionKeepFirst skips it,
+// CollisionKeepLast overwrites the value in place, and CollisionError makes Concat
+// return ErrDuplicateKey without appending anything.
+func (object *Object[V]) Concat(policy CollisionPolicy, others ...*Object[V]) error {
+	if policy == CollisionError {
+		seen := make(map[string]bool)
+		for _, other := range others {
+			for _, entry := range other.Entries() {
+				key := object.resolveKey(entry.Key)
+				if seen[key] || object.findKeyIndex(key) >= 0 {
+					return fmt.Errorf("%w: %q", ErrDuplicateKey, entry.Key)
+				}
+				seen[key] = true
+			}
+		}
+	}
+	for _, other := range others {
+		for _, entry := range other.Entries() {
+			if policy == CollisionKeepFirst && object.findKeyIndex(entry.Key) >= 0 {
+				continue
+			}
+			object.Set(entry.Key, entry.Value)
+		}
+	}
+	return nil
+}
+
+// SetPairs sets alternating keys and values, such as SetPairs("a", 1, "b", 2).
+// Every key must be a string and every value a V, or nil if V is an interface, pointer,
+// map, slice, channel or function type; nothing is set if any pair is invalid.
+func (object *Object[V]) SetPairs(kv ...any) error {
+	if len(kv)%2 != 0 {
+		return fmt.Errorf("%w: odd number of arguments %d", ErrInvalidPairs, len(kv))
+	}
+	entries := make([]Entry[V], 0, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			return fmt.Errorf("%w: key at position %d is %T, not string", ErrInvalidPairs, i, kv[i])
+		}
+		value, ok := kv[i+1].(V)
+		if !ok && (kv[i+1] != nil || !nilable[V]()) {
+			return fmt.Errorf("%w: value for %q has type %T", ErrInvalidPairs, key, kv[i+1])
+		}
+		entries = append(entries, Entry[V]{Key: key, Value: value})
+	}
+	object.SetMany(entries...)
+	return nil
+}
+
+// nilable reports whether nil is a valid value of V.
+func nilable[V any]() bool {
+	switch reflect.TypeFor[V]().Kind() {
+	case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewObjectFromEntries(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObjectFromEntries(
+		Entry[int]{Key: "b", Value: 1},
+		Entry[int]{Key: "a", Value: 2},
+		Entry[int]{Key: "b", Value: 3},
+	)
+	assert.Equal(t, []string{"b", "a"}, obj.Keys())
+	assert.Equal(t, []int{3, 2}, obj.Values())
+	assert.Equal(t, 3, cap(obj.entries))
+}
+
+func TestSetMany(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[string]().Set("x", "1")
+	obj.SetMany(Entry[string]{Key: "y", Value: "2"}, Entry[string]{Key: "x", Value: "3"})
+
+	assert.Equal(t, []string{"x", "y"}, obj.Keys())
+	assert.Equal(t, []string{"3", "2"}, obj.Values())
+}
+
+func TestSetPairs(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]()
+	require.NoError(t, obj.SetPairs("name", "app", "port", 8080, "tls", nil))
+	assert.Equal(t, []string{"name", "port", "tls"}, obj.Keys())
+
+	typed := NewObject[int]()
+	require.ErrorIs(t, typed.SetPairs("a", 1, "b"), ErrInvalidPairs)
+	require.ErrorIs(t, typed.SetPairs(1, 1), ErrInvalidPairs)
+	require.ErrorIs(t, typed.SetPairs("a", 1, "b", "two"), ErrInvalidPairs)
+	require.ErrorIs(t, typed.SetPairs("a", 1, "b", nil), ErrInvalidPairs)
+	assert.True(t, typed.IsEmpty())
+
+	// nil is only accepted where V can hold it
+	pointers := NewObject[*int]()
+	require.NoError(t, pointers.SetPairs("a", nil))
+	assert.True(t, pointers.Has("a"))
+	require.NoError(t, NewObject[[]string]().SetPairs("a", nil))
+	require.NoError(t, NewObject[func()]().SetPairs("a", nil))
+	require.ErrorIs(t, NewObject[string]().SetPairs("a", nil), ErrInvalidPairs)
+}
+
+func TestConcat(t *testing.T) {
+	t.Parallel()
+
+	fragments := func() (*Object[int], *Object[int], *Object[int]) {
+		return NewObject[int]().Set("a", 1).Set("b", 2),
+			NewObject[int]().Set("c", 3).Set("a", 10),
+			NewObject[int]().Set("d", 4).Set("c", 30)
+	}
+
+	t.Run("keep first", func(t *testing.T) {
+		t.Parallel()
+		obj, x, y := fragments()
+		require.NoError(t, obj.Concat(CollisionKeepFirst, x, y))
+		assert.Equal(t, []string{"a", "b", "c", "d"}, obj.Keys())
+		assert.Equal(t, []int{1, 2, 3, 4}, obj.Values())
+	})
+
+	t.Run("keep last", func(t *testing.T) {
+		t.Parallel()
+		obj, x, y := fragments()
+		require.NoError(t, obj.Concat(CollisionKeepLast, x, y))
+		assert.Equal(t, []string{"a", "b", "c", "d"}, obj.Keys())
+		assert.Equal(t, []int{10, 2, 30, 4}, obj.Values())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		obj, x, y := fragments()
+		err := obj.Concat(CollisionError, x, y)
+		require.ErrorIs(t, err, ErrDuplicateKey)
+		assert.Equal(t, []string{"a", "b"}, obj.Keys(), "nothing is appended")
+
+		// Keys repeated between the appended objects collide as well
+		obj = NewObject[int]()
+		require.ErrorIs(t, obj.Concat(CollisionError, y, NewObject[int]().Set("d", 5)), ErrDuplicateKey)
+		assert.True(t, obj.IsEmpty())
+
+		require.NoError(t, obj.Concat(CollisionError, y, NewObject[int]().Set("e", 5)))
+		assert.Equal(t, []string{"d", "c", "e"}, obj.Keys())
+	})
+
+	t.Run("no others", func(t *testing.T) {
+		t.Parallel()
+		obj, _, _ := fragments()
+		require.NoError(t, obj.Concat(CollisionError))
+		assert.Equal(t, []string{"a", "b"}, obj.Keys())
+	})
+}
@@ -0,0 +1,174 @@
+package orderedobject
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+)
+
+// ErrInvalidEnvLine is returned when a line of .env content is not a KEY=value assignment
+var ErrInvalidEnvLine = errors.New("invalid env line")
+
+// ErrInvalidEnvName is returned when ToEnv cannot derive a valid, unique variable name
+var ErrInvalidEnvName = errors.New("invalid env name")
+
+// ToEnv flattens the object into .env content with one KEY=value line per value in
+// order. Keys are prefixed, upper-cased and joined with "_" across nested objects and
+// maps, so "server.port" becomes PREFIX_SERVER_PORT. Characters other than ASCII
+// letters, digits and "_" are replaced with "_", so "max-conns" and "max conns" both
+// become MAX_CONNS; names that start with a digit or that two keys share are
+// rejected with ErrInvalidEnvName. Strings are written as is, arrays and other values
+// as JSON, and values containing spaces, quotes, "#" or newlines are double-quoted.
+//
+// FromEnv does not restore keys that contain "_", upper-case letters or replaced
+// characters, and reads every value back as a string.
+func (object *Object[V]) ToEnv(prefix string) ([]byte, error) {
+	var buf bytes.Buffer
+	seen := make(map[string]bool)
+	for _, entry := range object.entries {
+		if err := writeEnv(&buf, seen, envName(prefix, entry.Key), any(entry.Value)); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// envName appends key to the variable name prefix as upper-case letters, digits and "_".
+func envName(prefix, key string) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	for _, r := range strings.ToUpper(key) {
+		if r == '_' || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteByte('_')
+		}
+	}
+	return sb.String()
+}
+
+// writeEnv writes the .env lines for a value under name, recording the names written.
+func writeEnv(buf *bytes.Buffer, seen map[string]bool, name string, value any) error {
+	switch v := value.(type) {
+	case *Object[any]:
+		if v != nil {
+			for _, entry := range v.entries {
+				if err := writeEnv(buf, seen, envName(name+"_", entry.Key), entry.Value); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			if err := writeEnv(buf, seen, envName(name+"_", k), v[k]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !validEnvName(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidEnvName, name)
+	}
+	if seen[name] {
+		return fmt.Errorf("%w: duplicate %q", ErrInvalidEnvName, name)
+	}
+	seen[name] = true
+
+	var s string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		s = v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		s = string(data)
+	}
+	if strings.ContainsAny(s, " \t\r\n\"'#\\$") {
+		s = strconv.Quote(s)
+	}
+	fmt.Fprintf(buf, "%s=%s\n", name, s)
+	return nil
+}
+
+// validEnvName reports whether name is a portable variable name: ASCII letters,
+// digits and "_", not starting with a digit.
+func validEnvName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for i := range len(name) {
+		c := name[i]
+		if c != '_' && (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// FromEnv parses .env content into an ordered object, keeping the variables whose names
+// start with prefix. The prefix is removed and names are lower-cased and split on "_"
+// into nested objects, so PREFIX_SERVER_PORT becomes "server.port"; keys that contain
+// "_" therefore do not round-trip through ToEnv. Values are kept as strings.
+// Blank lines, "#" comments and an "export " prefix are ignored, and values may be
+// double-quoted with Go escapes or single-quoted literally.
+func FromEnv(data []byte, prefix string) (*Object[any], error) {
+	obj := NewObject[any]()
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		text = strings.TrimPrefix(text, "export ")
+		name, value, ok := strings.Cut(text, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: line %d", ErrInvalidEnvLine, line)
+		}
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		value, err := parseEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidEnvLine, line, err)
+		}
+		path := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(name, prefix), "_", "."))
+		if err := obj.SetPath(path, value); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// parseEnvValue unquotes a .env value.
+func parseEnvValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		return strconv.Unquote(value)
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", strconv.ErrSyntax
+		}
+		return value[1 : len(value)-1], nil
+	}
+	// Unquoted values end at an inline comment
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = strings.TrimSpace(value[:idx])
+	}
+	return value, nil
+}
@@ -0,0 +1,94 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToEnv(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("name", "my app").
+		Set("server", NewObject[any]().
+			Set("port", 8080).
+			Set("tls", map[string]any{"key": "k", "cert": "c"})).
+		Set("debug", true).
+		Set("tags", []any{"a", "b"}).
+		Set("empty", nil)
+
+	data, err := obj.ToEnv("APP_")
+	require.NoError(t, err)
+	assert.Equal(t, `APP_NAME="my app"
+APP_SERVER_PORT=8080
+APP_SERVER_TLS_CERT=c
+APP_SERVER_TLS_KEY=k
+APP_DEBUG=true
+APP_TAGS="[\"a\",\"b\"]"
+APP_EMPTY=
+`, string(data))
+}
+
+func TestToEnvNames(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("max-conns", 1).
+		Set("log.level", "info").
+		Set("has space", 2).
+		Set("x=y", "z").
+		Set("café", NewObject[any]().Set("menu", 3))
+	data, err := obj.ToEnv("APP_")
+	require.NoError(t, err)
+	assert.Equal(t, `APP_MAX_CONNS=1
+APP_LOG_LEVEL=info
+APP_HAS_SPACE=2
+APP_X_Y=z
+APP_CAF__MENU=3
+`, string(data))
+
+	_, err = NewObject[any]().Set("a-b", 1).Set("a_b", 2).ToEnv("APP_")
+	require.ErrorIs(t, err, ErrInvalidEnvName)
+	_, err = NewObject[any]().Set("1st", 1).ToEnv("")
+	require.ErrorIs(t, err, ErrInvalidEnvName)
+	_, err = NewObject[any]().Set("a", 1).ToEnv("APP-")
+	require.ErrorIs(t, err, ErrInvalidEnvName)
+
+	// Keys with "_", upper-case letters or replaced characters, and non-string
+	// values, do not round-trip
+	data, err = NewObject[any]().Set("max_conns", 1).Set("Name", "app").Set("a-b", true).ToEnv("APP_")
+	require.NoError(t, err)
+	back, err := FromEnv(data, "APP_")
+	require.NoError(t, err)
+	out, err := back.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"max":{"conns":"1"},"name":"app","a":{"b":"true"}}`, string(out))
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`# settings
+APP_NAME="my app"
+export APP_SERVER_PORT=8080
+APP_SERVER_HOST='0.0.0.0' 
+APP_DEBUG=true # inline comment
+
+OTHER=ignored
+`)
+	obj, err := FromEnv(data, "APP_")
+	require.NoError(t, err)
+
+	out, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"my app","server":{"port":"8080","host":"0.0.0.0"},"debug":"true"}`, string(out))
+
+	_, err = FromEnv([]byte("APP_NAME\n"), "APP_")
+	require.ErrorIs(t, err, ErrInvalidEnvLine)
+	_, err = FromEnv([]byte(`APP_NAME="unterminated`), "APP_")
+	require.ErrorIs(t, err, ErrInvalidEnvLine)
+	_, err = FromEnv([]byte("APP_A=1\nAPP_A_B=2\n"), "APP_")
+	require.ErrorIs(t, err, ErrPathConflict)
+}
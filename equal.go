@@ -0,0 +1,90 @@
+package orderedobject
+
+import "reflect"
+
+// Equal reports whether both objects have the same keys with equal values in the
+// same order. Nested *Object[any] values, maps and slices are compared recursively,
+// and nested objects must also match in order.
+func (object *Object[V]) Equal(other *Object[V]) bool {
+	return object.EqualFunc(other, func(a, b V) bool {
+		return equalValues(a, b, false)
+	})
+}
+
+// EqualFunc reports whether both objects have the same keys in the same order,
+// using eq to compare the values.
+func (object *Object[V]) EqualFunc(other *Object[V], eq func(a, b V) bool) bool {
+	if object == nil || other == nil {
+		return object == other
+	}
+	if len(object.entries) != len(other.entries) {
+		return false
+	}
+	for i, entry := range object.entries {
+		if entry.Key != other.entries[i].Key || !eq(entry.Value, other.entries[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualUnordered reports whether both objects have the same keys with equal values,
+// regardless of key order. Nested *Object[any] values are compared regardless of
+// key order as well, while slices must still match element by element. Keys are
+// matched exactly, like Equal, ignoring aliases, case fallback and KeyEqual.
+func (object *Object[V]) EqualUnordered(other *Object[V]) bool {
+	if object == nil || other == nil {
+		return object == other
+	}
+	if len(object.entries) != len(other.entries) {
+		return false
+	}
+	for _, entry := range object.entries {
+		idx := indexOfKey(other.entries, entry.Key)
+		if idx < 0 || !equalValues(entry.Value, other.entries[idx].Value, true) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalValues reports whether two values are deeply equal, descending into
+// *Object[any] values, maps and slices. When ignoreOrder is true, nested objects
+// are compared regardless of key order.
+func equalValues(a, b any, ignoreOrder bool) bool {
+	switch x := a.(type) {
+	case *Object[any]:
+		y, ok := b.(*Object[any])
+		if !ok {
+			return false
+		}
+		if ignoreOrder {
+			return x.EqualUnordered(y)
+		}
+		return x.Equal(y)
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) || (x == nil) != (y == nil) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equalValues(v, w, ignoreOrder) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) || (x == nil) != (y == nil) {
+			return false
+		}
+		for i := range x {
+			if !equalValues(x[i], y[i], ignoreOrder) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,51 @@
+package orderedobject
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := NewObject[any]().
+		Set("name", "app").
+		Set("server", NewObject[any]().Set("host", "localhost").Set("port", 8080)).
+		Set("tags", []any{"a", map[string]any{"k": NewObject[any]().Set("x", 1)}})
+
+	assert.True(t, a.Equal(a.Clone()))
+	assert.True(t, a.EqualUnordered(a.Clone()))
+
+	reordered := NewObject[any]().
+		Set("tags", []any{"a", map[string]any{"k": NewObject[any]().Set("x", 1)}}).
+		Set("server", NewObject[any]().Set("port", 8080).Set("host", "localhost")).
+		Set("name", "app")
+	assert.False(t, a.Equal(reordered))
+	assert.True(t, a.EqualUnordered(reordered))
+
+	changed := a.Clone().Set("tags", []any{"a", map[string]any{"k": NewObject[any]().Set("x", 2)}})
+	assert.False(t, a.Equal(changed))
+	assert.False(t, a.EqualUnordered(changed))
+
+	assert.False(t, a.Equal(NewObject[any]()))
+	assert.False(t, a.Equal(nil))
+
+	// Keys match exactly, whatever lookups the other object allows
+	upper := NewObject[any]().Set("NAME", "app").CaseFallback(true)
+	lower := NewObject[any]().Set("name", "app").KeyEqual(strings.EqualFold)
+	assert.False(t, lower.EqualUnordered(upper))
+	assert.False(t, upper.EqualUnordered(lower))
+	assert.False(t, a.EqualUnordered(a.Clone().Delete("name").Set("app_name", "app").Alias("name", "app_name")))
+}
+
+func TestEqualFunc(t *testing.T) {
+	t.Parallel()
+
+	a := NewObject[string]().Set("a", "Hello").Set("b", "World")
+	b := NewObject[string]().Set("a", "hello").Set("b", "world")
+
+	assert.False(t, a.Equal(b))
+	assert.True(t, a.EqualFunc(b, strings.EqualFold))
+}
@@ -0,0 +1,78 @@
+package orderedobject
+
+import (
+	"errors"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+)
+
+// ErrorWithDetails wraps an error together with ordered details describing it.
+// It marshals to a stable JSON error body of the form
+// {"error":"message","details":{...}}.
+type ErrorWithDetails struct {
+	Err     error
+	Details *Object[any]
+}
+
+// WithDetails wraps err with the given details.
+// If details is nil, an empty object is used so details can be added later.
+func WithDetails(err error, details *Object[any]) *ErrorWithDetails {
+	if details == nil {
+		details = NewObject[any]()
+	}
+	return &ErrorWithDetails{Err: err, Details: details}
+}
+
+// With adds a detail to the error.
+// Returns the error for chaining.
+func (e *ErrorWithDetails) With(key string, value any) *ErrorWithDetails {
+	if e.Details == nil {
+		e.Details = NewObject[any]()
+	}
+	e.Details.Set(key, value)
+	return e
+}
+
+// Error returns the message of the wrapped error.
+func (e *ErrorWithDetails) Error() string {
+	if e.Err == nil {
+		return ""
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *ErrorWithDetails) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON encodes the error as a JSON error body. The details include those of
+// every ErrorWithDetails found in the wrapped error chain, as returned by ErrorDetails.
+func (e *ErrorWithDetails) MarshalJSON() ([]byte, error) {
+	body := NewObject[any](2).Set("error", e.Error())
+	if details := ErrorDetails(e); details.Length() > 0 {
+		body.Set("details", details)
+	}
+	return json.Marshal(body)
+}
+
+// ErrorDetails collects the details of every ErrorWithDetails in the chain of err.
+// Details of inner errors come first; outer errors override values for the same key
+// and append new keys. It returns an empty object if err carries no details.
+func ErrorDetails(err error) *Object[any] {
+	var layers []*Object[any]
+	for err != nil {
+		if e, ok := err.(*ErrorWithDetails); ok && e.Details != nil {
+			layers = append(layers, e.Details)
+		}
+		err = errors.Unwrap(err)
+	}
+
+	details := NewObject[any]()
+	for i := len(layers) - 1; i >= 0; i-- {
+		layers[i].ForEach(func(key string, value any) {
+			details.Set(key, value)
+		})
+	}
+	return details
+}
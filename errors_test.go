@@ -0,0 +1,51 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorWithDetails(t *testing.T) {
+	t.Parallel()
+
+	errNotFound := errors.New("not found")
+	inner := WithDetails(errNotFound, NewObject[any]().
+		Set("resource", "user").
+		Set("id", 42))
+	wrapped := fmt.Errorf("load profile: %w", inner)
+	outer := WithDetails(wrapped, nil).
+		With("request_id", "abc").
+		With("id", 43)
+
+	require.ErrorIs(t, outer, errNotFound)
+	assert.Equal(t, "load profile: not found", outer.Error())
+
+	var target *ErrorWithDetails
+	require.ErrorAs(t, wrapped, &target)
+	assert.Same(t, inner, target)
+
+	details := ErrorDetails(outer)
+	assert.Equal(t, []string{"resource", "id", "request_id"}, details.Keys())
+	assert.Equal(t, []any{"user", 43, "abc"}, details.Values())
+
+	data, err := json.Marshal(outer)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"error":"load profile: not found","details":{"resource":"user","id":43,"request_id":"abc"}}`,
+		string(data))
+}
+
+func TestErrorWithDetailsEmpty(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(WithDetails(errors.New("boom"), nil))
+	require.NoError(t, err)
+	assert.Equal(t, `{"error":"boom"}`, string(data))
+
+	assert.Equal(t, 0, ErrorDetails(errors.New("plain")).Length())
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/kaptinlin/orderedobject"
 )
@@ -30,32 +31,18 @@ func main() {
 				Set("password", "secret")))
 
 	// Access nested values
-	if app, found := config.Get("app"); found {
-		if appObj, ok := app.(*orderedobject.Object[any]); ok {
-			if name, found := appObj.Get("name"); found {
-				fmt.Printf("\nApp name: %v\n", name)
-			}
-		}
+	if name, found := config.GetPath("app.name"); found {
+		fmt.Printf("\nApp name: %v\n", name)
+	}
+
+	// Update nested values, creating intermediate objects as needed
+	if err := config.SetPath("server.ssl.key", "/path/to/key.pem"); err != nil {
+		fmt.Printf("Set error: %v\n", err)
 	}
 
 	// Print nested structure
 	fmt.Println("\nFull configuration:")
-	printNestedObject(config, 0)
-}
-
-func printNestedObject(obj *orderedobject.Object[any], indent int) {
-	indentStr := ""
-	for i := 0; i < indent; i++ {
-		indentStr += "  "
+	if err := config.Dump(os.Stdout, orderedobject.DumpOptions{}); err != nil {
+		fmt.Printf("Dump error: %v\n", err)
 	}
-
-	obj.ForEach(func(key string, value any) {
-		fmt.Printf("%s%s: ", indentStr, key)
-		if nested, ok := value.(*orderedobject.Object[any]); ok {
-			fmt.Println()
-			printNestedObject(nested, indent+1)
-		} else {
-			fmt.Printf("%v\n", value)
-		}
-	})
 }
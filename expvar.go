@@ -0,0 +1,28 @@
+package orderedobject
+
+import "expvar"
+
+// ExpVar returns an expvar.Var reporting the current JSON encoding of the object, so a
+// live configuration or metrics object can be published at /debug/vars with its keys
+// in order:
+//
+//	expvar.Publish("config", config.ExpVar())
+//
+// The object must not be modified while the variable is being read.
+func (object *Object[V]) ExpVar() expvar.Var {
+	return expVar[V]{object: object}
+}
+
+// expVar adapts an ordered object to expvar.Var.
+type expVar[V any] struct {
+	object *Object[V]
+}
+
+// String returns the JSON encoding of the object, or null if it cannot be encoded.
+func (v expVar[V]) String() string {
+	data, err := v.object.ToJSON()
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
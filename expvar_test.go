@@ -0,0 +1,24 @@
+package orderedobject
+
+import (
+	"expvar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpVar(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().Set("zeta", 1).Set("alpha", 2)
+	v := obj.ExpVar()
+	expvar.Publish("orderedobject_test", v)
+
+	assert.Equal(t, `{"zeta":1,"alpha":2}`, expvar.Get("orderedobject_test").String())
+
+	obj.Set("beta", 3)
+	assert.Equal(t, `{"zeta":1,"alpha":2,"beta":3}`, v.String())
+
+	bad := NewObject[any]().Set("ch", make(chan int))
+	assert.Equal(t, "null", bad.ExpVar().String())
+}
@@ -0,0 +1,237 @@
+package orderedobject
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// ErrNoExtraField is returned when a struct does not contain an Extra field
+var ErrNoExtraField = errors.New("struct has no orderedobject.Extra field")
+
+// Extra captures the keys of a JSON object that do not map to a struct field.
+// Embed it in a struct (or add it as a field) and decode and encode the struct with
+// UnmarshalWithExtra and MarshalWithExtra to round-trip unknown keys in their original order.
+type Extra struct {
+	// Unknown holds the unrecognized keys in input order. Nested objects are
+	// decoded as *Object[any] so their order is preserved too.
+	Unknown *Object[any] `json:"-"`
+}
+
+// UnmarshalWithExtra decodes data into the struct pointed to by v and stores keys that
+// do not match any of its fields in the struct's Extra field.
+func UnmarshalWithExtra(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w, got %T", ErrNotStruct, v)
+	}
+	extra, ok := findExtraField(rv.Elem())
+	if !ok {
+		return ErrNoExtraField
+	}
+	raw := NewObject[jsontext.Value]()
+	if err := raw.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	known, extraNames := extraKnownFields(rv.Elem().Type())
+
+	// Keys named like an Extra field are unknown keys, not values of the field
+	fields := data
+	if slices.ContainsFunc(extraNames, raw.Has) {
+		stripped := raw.Clone()
+		for _, name := range extraNames {
+			stripped.Delete(name)
+		}
+		var err error
+		if fields, err = json.Marshal(stripped); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal(fields, v); err != nil {
+		return err
+	}
+
+	unknown := NewObject[any]()
+	for _, entry := range raw.entries {
+		if known[entry.Key] {
+			continue
+		}
+		value, err := decodeOrdered(entry.Value)
+		if err != nil {
+			return err
+		}
+		unknown.Set(entry.Key, value)
+	}
+	extra.Unknown = nil
+	if unknown.Length() > 0 {
+		extra.Unknown = unknown
+	}
+	return nil
+}
+
+// MarshalWithExtra encodes the struct v and appends the keys held in its Extra field
+// after the struct fields. Struct fields take precedence over unknown keys with the same name.
+func MarshalWithExtra(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w, got %T", ErrNotStruct, v)
+	}
+	extra, ok := findExtraField(rv)
+	if !ok {
+		return nil, ErrNoExtraField
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	_, extraNames := extraKnownFields(rv.Type())
+	if len(extraNames) == 0 && (extra.Unknown == nil || extra.Unknown.Length() == 0) {
+		return data, nil
+	}
+
+	obj := NewObject[jsontext.Value]()
+	if err := obj.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	// A named Extra field is encoded as an empty object; its keys replace it
+	for _, name := range extraNames {
+		obj.Delete(name)
+	}
+	if extra.Unknown == nil {
+		return json.Marshal(obj)
+	}
+	for _, entry := range extra.Unknown.entries {
+		if obj.Has(entry.Key) {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := marshalValue(jsontext.NewEncoder(&buf), entry.Value); err != nil {
+			return nil, err
+		}
+		obj.Set(entry.Key, jsontext.Value(bytes.TrimSpace(buf.Bytes())))
+	}
+	return json.Marshal(obj)
+}
+
+// extraType is the reflected type of Extra.
+var extraType = reflect.TypeFor[Extra]()
+
+// extraKnownFields returns the JSON names of the fields of a struct type other than
+// Extra fields, and the JSON names of Extra fields added as named fields.
+func extraKnownFields(t reflect.Type) (map[string]bool, []string) {
+	known := make(map[string]bool)
+	var extraNames []string
+	for _, field := range structFields(t) {
+		if t.FieldByIndex(field.index).Type == extraType {
+			extraNames = append(extraNames, field.name)
+		} else {
+			known[field.name] = true
+		}
+	}
+	return known, extraNames
+}
+
+// findExtraField returns the first Extra field of a struct value, searching
+// embedded structs breadth first.
+func findExtraField(rv reflect.Value) (*Extra, bool) {
+	queue := []reflect.Value{rv}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for i := range v.NumField() {
+			sf := v.Type().Field(i)
+			fv := v.Field(i)
+			if sf.Type == extraType {
+				if !fv.CanAddr() {
+					var extra Extra
+					reflect.ValueOf(&extra).Elem().Set(fv)
+					return &extra, true
+				}
+				return fv.Addr().Interface().(*Extra), true
+			}
+			if sf.Anonymous {
+				if fv.Kind() == reflect.Pointer && !fv.IsNil() {
+					fv = fv.Elem()
+				}
+				if fv.Kind() == reflect.Struct {
+					queue = append(queue, fv)
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// decodeOrdered decodes a JSON value, decoding objects as *Object[any] at every level.
+func decodeOrdered(data []byte) (any, error) {
+	return decodeOrderedFrom(jsontext.NewDecoder(bytes.NewReader(data)))
+}
+
+// decodeOrderedFrom decodes the next JSON value from dec, decoding objects as
+// *Object[any] at every level.
+func decodeOrderedFrom(dec *jsontext.Decoder) (any, error) {
+	switch dec.PeekKind() {
+	case '{':
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		// Index the keys: a duplicate name, if the decoder allows it, replaces the
+		// earlier value in place
+		obj := NewObject[any]()
+		seen := make(map[string]int)
+		for dec.PeekKind() != '}' {
+			tok, err := dec.ReadToken()
+			if err != nil {
+				return nil, err
+			}
+			if tok.Kind() != '"' {
+				return nil, fmt.Errorf("%w, got %v", ErrExpectedStringKey, tok.Kind())
+			}
+			key := tok.String()
+			value, err := decodeOrderedFrom(dec)
+			if err != nil {
+				return nil, err
+			}
+			if i, ok := seen[key]; ok {
+				obj.entries[i].Value = value
+				continue
+			}
+			seen[key] = len(obj.entries)
+			obj.entries = append(obj.entries, Entry[any]{Key: key, Value: value})
+		}
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case '[':
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		values := []any{}
+		for dec.PeekKind() != ']' {
+			value, err := decodeOrderedFrom(dec)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+	var value any
+	if err := json.UnmarshalDecode(dec, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
@@ -0,0 +1,86 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type extraConfig struct {
+	Name string `json:"name"`
+	Port int    `json:"port"`
+	Extra
+}
+
+func TestUnmarshalWithExtra(t *testing.T) {
+	t.Parallel()
+
+	input := `{"zeta":1,"name":"app","plugins":{"b":true,"a":false},"port":8080,"alpha":[{"y":1,"x":2}]}`
+
+	var cfg extraConfig
+	require.NoError(t, UnmarshalWithExtra([]byte(input), &cfg))
+	assert.Equal(t, "app", cfg.Name)
+	assert.Equal(t, 8080, cfg.Port)
+	require.NotNil(t, cfg.Unknown)
+	assert.Equal(t, []string{"zeta", "plugins", "alpha"}, cfg.Unknown.Keys())
+
+	plugins, _ := cfg.Unknown.Get("plugins")
+	assert.Equal(t, []string{"b", "a"}, plugins.(*Object[any]).Keys())
+
+	data, err := MarshalWithExtra(cfg)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"name":"app","port":8080,"zeta":1,"plugins":{"b":true,"a":false},"alpha":[{"y":1,"x":2}]}`,
+		string(data))
+}
+
+func TestMarshalWithExtraPrecedence(t *testing.T) {
+	t.Parallel()
+
+	cfg := extraConfig{Name: "app", Extra: Extra{Unknown: NewObject[any]().Set("name", "other").Set("x", 1)}}
+
+	data, err := MarshalWithExtra(&cfg)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"app","port":0,"x":1}`, string(data))
+
+	cfg.Unknown = nil
+	data, err = MarshalWithExtra(&cfg)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"app","port":0}`, string(data))
+}
+
+func TestExtraErrors(t *testing.T) {
+	t.Parallel()
+
+	var plain struct{ Name string }
+	require.ErrorIs(t, UnmarshalWithExtra([]byte(`{}`), &plain), ErrNoExtraField)
+	require.ErrorIs(t, UnmarshalWithExtra([]byte(`{}`), plain), ErrNotStruct)
+
+	_, err := MarshalWithExtra(plain)
+	require.ErrorIs(t, err, ErrNoExtraField)
+}
+
+func TestExtraNamedField(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Name string `json:"name"`
+		More Extra
+	}
+
+	var cfg config
+	require.NoError(t, UnmarshalWithExtra([]byte(`{"b":1,"name":"app","More":2,"a":true}`), &cfg))
+	assert.Equal(t, "app", cfg.Name)
+	require.NotNil(t, cfg.More.Unknown)
+	assert.Equal(t, []string{"b", "More", "a"}, cfg.More.Unknown.Keys())
+
+	data, err := MarshalWithExtra(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"app","b":1,"More":2,"a":true}`, string(data))
+
+	// Without unknown keys the field is not encoded either
+	data, err = MarshalWithExtra(config{Name: "app"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"app"}`, string(data))
+}
@@ -0,0 +1,92 @@
+package orderedobject
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SaveOptions configures SaveFile.
+type SaveOptions struct {
+	// MarshalOptions controls the encoding; the zero value writes compact JSON.
+	MarshalOptions
+	// Perm is the permission of the written file; zero means 0o644.
+	Perm os.FileMode
+	// Sync flushes the file and its directory to stable storage before returning.
+	Sync bool
+}
+
+// SaveFile writes the ordered object as JSON to path atomically: the data is written
+// to a temporary file in the same directory, which is then renamed over path, so
+// readers see either the old or the new content and never a partial write.
+func (object *Object[V]) SaveFile(path string, opts SaveOptions) (err error) {
+	data, err := object.MarshalWith(opts.MarshalOptions)
+	if err != nil {
+		return err
+	}
+	if opts.Multiline || opts.Indent != "" || opts.IndentPrefix != "" {
+		data = append(data, '\n')
+	}
+	perm := opts.Perm
+	if perm == 0 {
+		perm = 0o644
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if opts.Sync {
+		if err = tmp.Sync(); err != nil {
+			return err
+		}
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	if opts.Sync {
+		return syncDir(dir)
+	}
+	return nil
+}
+
+// LoadFile reads the JSON object in the file at path, decoding nested objects as
+// ordered objects so key order is kept at every level.
+func LoadFile(path string) (*Object[any], error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := FromJSONDeep(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return obj, nil
+}
+
+// syncDir flushes a directory so a rename within it is durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
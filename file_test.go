@@ -0,0 +1,93 @@
+package orderedobject
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Writes compact JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		obj := NewObject[any]().Set("z", 1).Set("a", 2)
+
+		require.NoError(t, obj.SaveFile(path, SaveOptions{}))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `{"z":1,"a":2}`, string(data))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+	})
+
+	t.Run("Replaces existing file with indent, perm and sync", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"old":true}`), 0o600))
+
+		obj := NewObject[any]().Set("b", 1).Set("a", 2)
+		require.NoError(t, obj.SaveFile(path, SaveOptions{
+			MarshalOptions: MarshalOptions{Indent: "  "},
+			Perm:           0o600,
+			Sync:           true,
+		}))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "{\n  \"b\": 1,\n  \"a\": 2\n}\n", string(data))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "no temporary file is left behind")
+	})
+
+	t.Run("Encoding error leaves file untouched", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"old":true}`), 0o600))
+
+		obj := NewObject[any]().Set("a", 1)
+		err := obj.SaveFile(path, SaveOptions{MarshalOptions: MarshalOptions{Indent: "x"}})
+		require.ErrorIs(t, err, ErrInvalidIndent)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `{"old":true}`, string(data))
+	})
+
+	t.Run("Missing directory", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing", "config.json")
+		assert.Error(t, NewObject[any]().SaveFile(path, SaveOptions{}))
+	})
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"z":{"y":1,"x":2},"a":3}`), 0o600))
+
+	obj, err := LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"z", "a"}, obj.Keys())
+	nested, ok := GetAs[*Object[any]](obj, "z")
+	require.True(t, ok)
+	assert.Equal(t, []string{"y", "x"}, nested.Keys())
+
+	require.NoError(t, os.WriteFile(path, []byte(`[1]`), 0o600))
+	_, err = LoadFile(path)
+	assert.Error(t, err)
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,244 @@
+package orderedobject
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+)
+
+// ErrNotStruct is returned when FromStruct is given a value that is not a struct
+var ErrNotStruct = errors.New("expected struct or pointer to struct")
+
+// FromStruct creates an ordered object from a struct whose keys follow the field
+// declaration order. It honors json tags, including "-", renamed fields, omitempty
+// and omitzero, and promotes the fields of embedded structs like encoding/json does.
+// Nested structs and maps become nested *Object[any] values, with map keys sorted,
+// and slices become []any. Values implementing a JSON or text marshaler are kept as is.
+func FromStruct(v any) (*Object[any], error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("%w, got nil %v", ErrNotStruct, rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w, got %v", ErrNotStruct, reflect.TypeOf(v))
+	}
+	return structToObject(rv), nil
+}
+
+// structField is an exported field reachable from a struct, including promoted fields.
+type structField struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+	omitZero  bool
+}
+
+// structToObject converts a struct value to an ordered object.
+func structToObject(rv reflect.Value) *Object[any] {
+	fields := structFields(rv.Type())
+	obj := NewObject[any](len(fields))
+	for _, field := range fields {
+		fv, ok := fieldByIndex(rv, field.index)
+		if !ok {
+			continue
+		}
+		if (field.omitEmpty && isEmptyValue(fv)) || (field.omitZero && fv.IsZero()) {
+			continue
+		}
+		obj.Set(field.name, structValue(fv))
+	}
+	return obj
+}
+
+// structFields returns the JSON fields of a struct type in declaration order,
+// resolving name conflicts between promoted fields like encoding/json.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	collectStructFields(t, nil, map[reflect.Type]bool{}, &fields)
+
+	// Keep the shallowest field for each name, preferring tagged fields;
+	// ambiguous names are dropped.
+	byName := make(map[string][]structField)
+	for _, field := range fields {
+		byName[field.name] = append(byName[field.name], field)
+	}
+	var result []structField
+	for _, field := range fields {
+		if dominant, ok := dominantField(byName[field.name]); ok && slices.Equal(dominant.index, field.index) {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// collectStructFields appends the fields of t, descending into embedded structs.
+func collectStructFields(t reflect.Type, index []int, visited map[reflect.Type]bool, fields *[]structField) {
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		idx := append(slices.Clone(index), i)
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collectStructFields(ft, idx, visited, fields)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		field := structField{name: sf.Name, index: idx, tagged: name != ""}
+		if name != "" {
+			field.name = name
+		}
+		for opt := range strings.SplitSeq(opts, ",") {
+			switch opt {
+			case "omitempty":
+				field.omitEmpty = true
+			case "omitzero":
+				field.omitZero = true
+			}
+		}
+		*fields = append(*fields, field)
+	}
+}
+
+// dominantField returns the field that wins among fields sharing a name.
+func dominantField(fields []structField) (structField, bool) {
+	if len(fields) == 1 {
+		return fields[0], true
+	}
+	depth := slices.MinFunc(fields, func(a, b structField) int {
+		return len(a.index) - len(b.index)
+	})
+	var candidates []structField
+	for _, field := range fields {
+		if len(field.index) == len(depth.index) {
+			candidates = append(candidates, field)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+	var tagged []structField
+	for _, field := range candidates {
+		if field.tagged {
+			tagged = append(tagged, field)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return structField{}, false
+}
+
+// fieldByIndex returns the field at index, reporting false if a nil embedded
+// pointer is in the way.
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// isEmptyValue reports whether v is empty in the sense of the omitempty option.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// marshalerTypes are the interfaces whose implementations FromStruct keeps as is.
+var marshalerTypes = []reflect.Type{
+	reflect.TypeFor[json.Marshaler](),
+	reflect.TypeFor[json.MarshalerTo](),
+	reflect.TypeFor[encoding.TextMarshaler](),
+}
+
+// structValue converts a field value, turning structs and maps into ordered objects.
+func structValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	for _, t := range marshalerTypes {
+		if v.Type().Implements(t) {
+			return v.Interface()
+		}
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return structValue(v.Elem())
+	case reflect.Struct:
+		return structToObject(v)
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		m := make(map[string]reflect.Value, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			m[iter.Key().String()] = iter.Value()
+		}
+		obj := NewObject[any](len(m))
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			obj.Set(k, structValue(m[k]))
+		}
+		return obj
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		fallthrough
+	case reflect.Array:
+		s := make([]any, v.Len())
+		for i := range s {
+			s[i] = structValue(v.Index(i))
+		}
+		return s
+	}
+	return v.Interface()
+}
@@ -0,0 +1,96 @@
+package orderedobject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type structBase struct {
+	ID      int    `json:"id"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type StructMeta struct {
+	Labels map[string]string `json:"labels"`
+}
+
+type structConfig struct {
+	Name string `json:"name"`
+	structBase
+	*StructMeta
+	Server struct {
+		Host string
+		Port int `json:"port"`
+	} `json:"server"`
+	Tags     []string  `json:"tags,omitempty"`
+	Created  time.Time `json:"created"`
+	Timeout  int       `json:"timeout,omitzero"`
+	Secret   string    `json:"-"`
+	internal bool
+}
+
+func TestFromStruct(t *testing.T) {
+	t.Parallel()
+
+	cfg := structConfig{Name: "app", Secret: "hidden", internal: true}
+	cfg.ID = 7
+	cfg.Server.Host = "localhost"
+	cfg.Server.Port = 8080
+	cfg.Created = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	obj, err := FromStruct(&cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "id", "server", "created"}, obj.Keys())
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"name":"app","id":7,"server":{"Host":"localhost","port":8080},"created":"2024-01-02T03:04:05Z"}`,
+		string(data))
+
+	cfg.StructMeta = &StructMeta{Labels: map[string]string{"b": "2", "a": "1"}}
+	cfg.Tags = []string{"x"}
+	cfg.Timeout = 30
+
+	obj, err = FromStruct(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "id", "labels", "server", "tags", "created", "timeout"}, obj.Keys())
+	labels, _ := obj.Get("labels")
+	assert.Equal(t, []string{"a", "b"}, labels.(*Object[any]).Keys())
+	tags, _ := obj.Get("tags")
+	assert.Equal(t, []any{"x"}, tags)
+}
+
+func TestFromStructConflicts(t *testing.T) {
+	t.Parallel()
+
+	type A struct{ Name, Both string }
+	type B struct {
+		Name string `json:"Name"`
+		Both string
+	}
+	type outer struct {
+		A
+		B
+	}
+
+	obj, err := FromStruct(outer{A: A{Name: "a", Both: "a"}, B: B{Name: "b", Both: "b"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Name"}, obj.Keys())
+	name, _ := obj.Get("Name")
+	assert.Equal(t, "b", name)
+}
+
+func TestFromStructInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := FromStruct(42)
+	require.ErrorIs(t, err, ErrNotStruct)
+
+	var nilConfig *structConfig
+	_, err = FromStruct(nilConfig)
+	require.ErrorIs(t, err, ErrNotStruct)
+}
@@ -0,0 +1,29 @@
+package orderedobject
+
+// Prepend sets the value for a key, inserting a new key at position zero instead of
+// appending it. An existing key keeps its position, as with Set.
+// Returns the object for chaining.
+func (object *Object[V]) Prepend(key string, value V) *Object[V] {
+	key = object.resolveKey(key)
+	if object.findKeyIndex(key) >= 0 {
+		return object.Set(key, value)
+	}
+	object.forgetDeleted(key)
+	object.insertAt(0, Entry[V]{Key: key, Value: value})
+	return object
+}
+
+// SetFront sets the value for a key and moves it to position zero, whether or not it
+// already exists, for formats in which a discriminator such as "type" or "$schema"
+// must be the first key. The other keys keep their relative order.
+// Returns the object for chaining.
+func (object *Object[V]) SetFront(key string, value V) *Object[V] {
+	object.Prepend(key, value)
+	if idx := object.findKeyIndex(key); idx > 0 {
+		entry := object.entries[idx]
+		copy(object.entries[1:idx+1], object.entries[:idx])
+		object.entries[0] = entry
+		object.notify(Event{Kind: EventReorder})
+	}
+	return object
+}
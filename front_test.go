@@ -0,0 +1,56 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepend(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().Set("name", "app").Set("port", 8080)
+	obj.Prepend("$schema", "config.json").Prepend("port", 9090)
+	assert.Equal(t, []string{"$schema", "name", "port"}, obj.Keys())
+	assert.Equal(t, []any{"config.json", "app", 9090}, obj.Values())
+
+	empty := NewObject[int]().Prepend("a", 1)
+	assert.Equal(t, []string{"a"}, empty.Keys())
+
+	aliased := NewObject[int]().Alias("kind", "type").Set("a", 1).Prepend("kind", 2)
+	assert.Equal(t, []string{"type", "a"}, aliased.Keys())
+}
+
+func TestSetFront(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().Set("name", "app").Set("port", 8080).Set("type", "service")
+
+	var events []Event
+	obj.Subscribe(func(event Event) {
+		events = append(events, event)
+	})
+
+	obj.SetFront("type", "server")
+	assert.Equal(t, []string{"type", "name", "port"}, obj.Keys())
+	assert.Equal(t, []any{"server", "app", 8080}, obj.Values())
+	require.Len(t, events, 2)
+	assert.Equal(t, EventSet, events[0].Kind)
+	assert.True(t, events[0].Replaced)
+	assert.Equal(t, EventReorder, events[1].Kind)
+
+	// A key already at the front is updated in place
+	events = nil
+	obj.SetFront("type", "worker")
+	assert.Equal(t, []string{"type", "name", "port"}, obj.Keys())
+	require.Len(t, events, 1)
+	assert.Equal(t, EventSet, events[0].Kind)
+
+	obj.SetFront("$schema", "config.json")
+	assert.Equal(t, []string{"$schema", "type", "name", "port"}, obj.Keys())
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"$schema":"config.json","type":"worker","name":"app","port":8080}`, string(data))
+}
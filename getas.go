@@ -0,0 +1,62 @@
+package orderedobject
+
+import (
+	"reflect"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+)
+
+// GetAs returns the value for key as a T and whether the key exists and could be converted.
+// Values that are already a T are returned directly, numbers are converted between
+// numeric types when no precision is lost, and anything else, such as a map[string]any
+// or *Object[any] decoded into a struct, is converted through a JSON round trip.
+func GetAs[T any](obj *Object[any], key string) (T, bool) {
+	value, ok := obj.Get(key)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return convertTo[T](value)
+}
+
+// convertTo converts value to T.
+func convertTo[T any](value any) (T, bool) {
+	var result T
+	if v, ok := value.(T); ok {
+		return v, true
+	}
+	if value == nil {
+		return result, false
+	}
+
+	rv := reflect.ValueOf(value)
+	target := reflect.TypeFor[T]()
+	if isNumberKind(rv.Kind()) && isNumberKind(target.Kind()) {
+		converted := rv.Convert(target)
+		if !converted.Convert(rv.Type()).Equal(rv) {
+			return result, false
+		}
+		return converted.Interface().(T), true
+	}
+
+	data, err := json.Marshal(value, json.Deterministic(true))
+	if err != nil {
+		return result, false
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		var zero T
+		return zero, false
+	}
+	return result, true
+}
+
+// isNumberKind reports whether kind is an integer or floating point kind.
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
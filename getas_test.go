@@ -0,0 +1,53 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAs(t *testing.T) {
+	t.Parallel()
+
+	type server struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+
+	obj, err := FromJSON[any]([]byte(`{"name":"app","port":8080,"ratio":0.5,"server":{"host":"localhost","port":9090},"tags":["a","b"]}`))
+	require.NoError(t, err)
+	obj.Set("nested", NewObject[any]().Set("host", "example.com").Set("port", 443))
+
+	name, ok := GetAs[string](obj, "name")
+	assert.True(t, ok)
+	assert.Equal(t, "app", name)
+
+	port, ok := GetAs[int](obj, "port")
+	assert.True(t, ok)
+	assert.Equal(t, 8080, port)
+
+	_, ok = GetAs[int](obj, "ratio")
+	assert.False(t, ok)
+
+	_, ok = GetAs[uint8](obj, "port")
+	assert.False(t, ok)
+
+	srv, ok := GetAs[server](obj, "server")
+	assert.True(t, ok)
+	assert.Equal(t, server{Host: "localhost", Port: 9090}, srv)
+
+	nested, ok := GetAs[*server](obj, "nested")
+	assert.True(t, ok)
+	assert.Equal(t, &server{Host: "example.com", Port: 443}, nested)
+
+	tags, ok := GetAs[[]string](obj, "tags")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "b"}, tags)
+
+	_, ok = GetAs[int](obj, "name")
+	assert.False(t, ok)
+
+	_, ok = GetAs[string](obj, "missing")
+	assert.False(t, ok)
+}
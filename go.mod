@@ -4,9 +4,11 @@ go 1.25
 
 require github.com/go-json-experiment/json v0.0.0-20250910080747-cc2cfa0554c3
 
+require golang.org/x/text v0.33.0
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/testify v1.11.1
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1
 )
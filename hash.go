@@ -0,0 +1,75 @@
+package orderedobject
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// Hash returns the hex-encoded SHA-256 digest of the object's canonical JSON encoding.
+// The digest depends on key order, so reordering keys changes the hash.
+// Nested maps are encoded with sorted keys, which keeps the hash stable.
+// The canonical encoding holds the entries as stored at every level: options that only
+// change how objects are written, such as Redact, OmitEmpty, encode hooks, emitted
+// aliases and the registered codec, do not affect the hash.
+func (object *Object[V]) Hash() (string, error) {
+	data, err := object.canonicalJSON()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Sum64 returns the 64-bit FNV-1a hash of the object's canonical JSON encoding.
+// It is cheaper than Hash and suitable for in-memory caches and change detection.
+func (object *Object[V]) Sum64() (uint64, error) {
+	data, err := object.canonicalJSON()
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return h.Sum64(), nil
+}
+
+// canonicalObject is an ordered object of any value type.
+type canonicalObject interface {
+	encodeCanonical(enc *jsontext.Encoder) error
+}
+
+// canonicalMarshalers encode the ordered objects nested in values canonically.
+var canonicalMarshalers = json.MarshalToFunc(func(enc *jsontext.Encoder, object canonicalObject) error {
+	return object.encodeCanonical(enc)
+})
+
+// canonicalJSON returns the canonical JSON encoding hashed by Hash and Sum64.
+func (object *Object[V]) canonicalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := jsontext.NewEncoder(&buf, json.Deterministic(true), json.WithMarshalers(canonicalMarshalers))
+	if err := object.encodeCanonical(enc); err != nil {
+		return nil, withPath(err, -1)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// encodeCanonical writes the entries of the object to enc with the standard encoding.
+func (object *Object[V]) encodeCanonical(enc *jsontext.Encoder) error {
+	object.expire()
+	if err := enc.WriteToken(jsontext.BeginObject); err != nil {
+		return err
+	}
+	for _, entry := range object.entries {
+		if err := enc.WriteToken(jsontext.String(entry.Key)); err != nil {
+			return err
+		}
+		if err := json.MarshalEncode(enc, entry.Value); err != nil {
+			return err
+		}
+	}
+	return enc.WriteToken(jsontext.EndObject)
+}
@@ -0,0 +1,87 @@
+package orderedobject
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	a := NewObject[any]().
+		Set("name", "app").
+		Set("meta", map[string]any{"b": 2, "a": 1})
+
+	hash, err := a.Hash()
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	again, err := a.Clone().Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, again)
+
+	reordered, err := NewObject[any]().
+		Set("meta", map[string]any{"a": 1, "b": 2}).
+		Set("name", "app").
+		Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, reordered)
+}
+
+func TestSum64(t *testing.T) {
+	t.Parallel()
+
+	a := NewObject[int]().Set("a", 1).Set("b", 2)
+
+	sum, err := a.Sum64()
+	require.NoError(t, err)
+	again, err := a.Clone().Sum64()
+	require.NoError(t, err)
+	assert.Equal(t, sum, again)
+
+	changed, err := a.Clone().Set("b", 3).Sum64()
+	require.NoError(t, err)
+	assert.NotEqual(t, sum, changed)
+
+	_, err = NewObject[any]().Set("fn", func() {}).Sum64()
+	assert.Error(t, err)
+}
+
+func TestHashCanonical(t *testing.T) {
+	obj := NewObject[any]().
+		Set("user", "alice").
+		Set("password", "secret").
+		Set("empty", "").
+		Set("nested", NewObject[any]().Set("token", "t").Set("list", []any{NewObject[any]().Set("k", "<v>")}))
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	hash, err := obj.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), hash)
+	plain, err := obj.Sum64()
+	require.NoError(t, err)
+
+	// Output options apply to ToJSON but not to the hash
+	obj.Redact(true).OmitEmpty(true).Alias("login", "user").EmitAliases(true)
+	nested, _ := GetAs[*Object[any]](obj, "nested")
+	nested.RegisterValueHook(ValueHook{Encode: func(_ string, value any) (any, error) { return "hooked", nil }})
+	redacted, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.NotEqual(t, string(data), string(redacted))
+
+	RegisterCodec("hash-v1", &v1Codec{})
+	require.NoError(t, UseCodec("hash-v1"))
+	defer func() { require.NoError(t, UseCodec(DefaultCodec)) }()
+
+	again, err := obj.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, again)
+	sum64, err := obj.Sum64()
+	require.NoError(t, err)
+	assert.Equal(t, plain, sum64)
+}
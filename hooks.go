@@ -0,0 +1,105 @@
+package orderedobject
+
+import "slices"
+
+// ValueHook transforms entry values as they are encoded or decoded, for cross-cutting
+// processing such as encryption or timestamp normalization. Either function may be nil.
+// Hooks receive the key of the entry and are applied recursively to the entries of
+// nested objects and maps, including those inside arrays.
+type ValueHook struct {
+	Encode func(key string, value any) (any, error)
+	Decode func(key string, value any) (any, error)
+}
+
+// RegisterValueHook adds a hook that runs for every entry during marshaling and
+// unmarshaling. Hooks run in the order they were registered.
+// Returns the object for chaining.
+func (object *Object[V]) RegisterValueHook(hook ValueHook) *Object[V] {
+	ext := object.extend()
+	ext.hooks = append(ext.hooks, hook)
+	return object
+}
+
+// encodeHooks returns the encode functions of the registered hooks.
+func (object *Object[V]) encodeHooks() []func(key string, value any) (any, error) {
+	if object.ext == nil {
+		return nil
+	}
+	var fns []func(key string, value any) (any, error)
+	for _, hook := range object.ext.hooks {
+		if hook.Encode != nil {
+			fns = append(fns, hook.Encode)
+		}
+	}
+	return fns
+}
+
+// decodeHooks returns the decode functions of the registered hooks.
+func (object *Object[V]) decodeHooks() []func(key string, value any) (any, error) {
+	if object.ext == nil {
+		return nil
+	}
+	var fns []func(key string, value any) (any, error)
+	for _, hook := range object.ext.hooks {
+		if hook.Decode != nil {
+			fns = append(fns, hook.Decode)
+		}
+	}
+	return fns
+}
+
+// applyHooks runs fns on the value of key and then on the entries nested in the result.
+// Containers are copied rather than modified.
+func applyHooks(fns []func(key string, value any) (any, error), key string, value any) (any, error) {
+	for _, fn := range fns {
+		var err error
+		if value, err = fn(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return applyNestedHooks(fns, value)
+}
+
+// applyNestedHooks runs fns on the entries of nested objects, maps and arrays in value.
+func applyNestedHooks(fns []func(key string, value any) (any, error), value any) (any, error) {
+	switch v := value.(type) {
+	case *Object[any]:
+		if v == nil {
+			return v, nil
+		}
+		obj := v.Clone()
+		for i, entry := range obj.entries {
+			item, err := applyHooks(fns, entry.Key, entry.Value)
+			if err != nil {
+				return nil, err
+			}
+			obj.entries[i].Value = item
+		}
+		obj.reindex()
+		return obj, nil
+	case map[string]any:
+		if v == nil {
+			return v, nil
+		}
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			item, err := applyHooks(fns, k, item)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = item
+		}
+		return m, nil
+	case []any:
+		s := slices.Clone(v)
+		for i, item := range s {
+			item, err := applyNestedHooks(fns, item)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = item
+		}
+		return s, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,67 @@
+package orderedobject
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterValueHook(t *testing.T) {
+	t.Parallel()
+
+	upper := ValueHook{
+		Encode: func(key string, value any) (any, error) {
+			if s, ok := value.(string); ok && key == "name" {
+				return strings.ToUpper(s), nil
+			}
+			return value, nil
+		},
+		Decode: func(key string, value any) (any, error) {
+			if s, ok := value.(string); ok && key == "name" {
+				return strings.ToLower(s), nil
+			}
+			return value, nil
+		},
+	}
+
+	obj := NewObject[any]().
+		Set("name", "root").
+		Set("child", NewObject[any]().Set("name", "nested")).
+		Set("items", []any{map[string]any{"name": "item"}}).
+		RegisterValueHook(upper)
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"ROOT","child":{"name":"NESTED"},"items":[{"name":"ITEM"}]}`, string(data))
+
+	// Stored values are unchanged
+	name, _ := obj.Get("name")
+	assert.Equal(t, "root", name)
+
+	decoded := NewObject[any]().RegisterValueHook(upper)
+	require.NoError(t, decoded.UnmarshalJSON(data))
+	name, _ = decoded.Get("name")
+	assert.Equal(t, "root", name)
+	child, _ := decoded.Get("child")
+	assert.Equal(t, map[string]any{"name": "nested"}, child)
+}
+
+func TestRegisterValueHookErrors(t *testing.T) {
+	t.Parallel()
+
+	errHook := errors.New("hook failed")
+	failing := ValueHook{
+		Encode: func(string, any) (any, error) { return nil, errHook },
+	}
+	_, err := NewObject[int]().Set("a", 1).RegisterValueHook(failing).ToJSON()
+	require.ErrorIs(t, err, errHook)
+
+	wrongType := ValueHook{
+		Decode: func(string, any) (any, error) { return "text", nil },
+	}
+	err = NewObject[int]().RegisterValueHook(wrongType).UnmarshalJSON([]byte(`{"a":1}`))
+	require.ErrorIs(t, err, ErrTypeMismatch)
+}
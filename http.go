@@ -0,0 +1,98 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+var (
+	// ErrUnsupportedContentType is returned when a request body is not JSON
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+	// ErrRequestTooLarge is returned when a request body exceeds the size limit.
+	// It wraps ErrSizeLimitExceeded.
+	ErrRequestTooLarge = errors.New("request body too large")
+)
+
+// Default limits applied by DecodeRequest.
+const (
+	DefaultMaxRequestBytes = 1 << 20
+	DefaultMaxRequestDepth = 32
+)
+
+// DecodeRequest decodes the JSON body of an HTTP request into an ordered object, keeping
+// the key order at every level so payloads can be inspected as sent, for example to
+// verify signatures. It requires an application/json or +json Content-Type and enforces
+// the limits while reading the body. A zero MaxBytes or MaxDepth uses
+// DefaultMaxRequestBytes or DefaultMaxRequestDepth, and a zero MaxKeys is unlimited.
+func DecodeRequest(r *http.Request, limits ...DecodeLimits) (*Object[any], error) {
+	var l DecodeLimits
+	if len(limits) > 0 {
+		l = limits[0]
+	}
+	if l.MaxBytes <= 0 {
+		l.MaxBytes = DefaultMaxRequestBytes
+	}
+	if l.MaxDepth <= 0 {
+		l.MaxDepth = DefaultMaxRequestDepth
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedContentType, r.Header.Get("Content-Type"))
+	}
+
+	// The body is cut one byte past the limit, so a token running over it ends the read
+	body := &io.LimitedReader{R: r.Body, N: l.MaxBytes + 1}
+	value, err := l.read(jsontext.NewDecoder(body))
+	if err != nil && body.N == 0 && !errors.Is(err, ErrSizeLimitExceeded) {
+		err = fmt.Errorf("%w: limit is %d bytes", ErrSizeLimitExceeded, l.MaxBytes)
+	}
+	if errors.Is(err, ErrSizeLimitExceeded) {
+		return nil, fmt.Errorf("%w: %w", ErrRequestTooLarge, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", withPath(err, -1))
+	}
+	return FromJSONDeep(value)
+}
+
+// jsonContentType is the Content-Type written by WriteJSON.
+const jsonContentType = "application/json; charset=utf-8"
+
+// WriteJSON sets the JSON Content-Type header, writes the status code and streams the
+// object to the response in key order.
+func (object *Object[V]) WriteJSON(w http.ResponseWriter, status int) error {
+	w.Header().Set("Content-Type", jsonContentType)
+	w.WriteHeader(status)
+	return object.MarshalJSONTo(jsontext.NewEncoder(w))
+}
+
+// Renderer returns a renderer streaming the object as JSON. It implements the render
+// interface used by Gin, so ordered bodies can be written with c.Render(status, obj.Renderer()).
+func (object *Object[V]) Renderer() Renderer[V] {
+	return Renderer[V]{Object: object}
+}
+
+// Renderer streams an ordered object as a JSON response body.
+type Renderer[V any] struct {
+	Object *Object[V]
+}
+
+// Render writes the JSON Content-Type header if none is set and streams the object to w.
+func (r Renderer[V]) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return r.Object.MarshalJSONTo(jsontext.NewEncoder(w))
+}
+
+// WriteContentType writes the JSON Content-Type header if none is set.
+func (r Renderer[V]) WriteContentType(w http.ResponseWriter) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", jsonContentType)
+	}
+}
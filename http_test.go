@@ -0,0 +1,105 @@
+package orderedobject
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRequest(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"z":1,"a":{"y":2,"b":3}}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	obj, err := DecodeRequest(r)
+	require.NoError(t, err)
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"z":1,"a":{"y":2,"b":3}}`, string(data))
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"a":1}`))
+	r.Header.Set("Content-Type", "application/merge-patch+json")
+	_, err = DecodeRequest(r)
+	require.NoError(t, err)
+}
+
+func TestDecodeRequestLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		limits      DecodeLimits
+		want        error
+	}{
+		{"content type", "text/plain", `{}`, DecodeLimits{}, ErrUnsupportedContentType},
+		{"missing content type", "", `{}`, DecodeLimits{}, ErrUnsupportedContentType},
+		{"size", "application/json", `{"a":"0123456789"}`, DecodeLimits{MaxBytes: 10}, ErrRequestTooLarge},
+		{"depth", "application/json", `{"a":[{"b":1}]}`, DecodeLimits{MaxDepth: 2}, ErrDepthLimitExceeded},
+		{"not an object", "application/json", `[1]`, DecodeLimits{}, ErrExpectedObjectStart},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			_, err := DecodeRequest(r, tt.limits)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"a":[{"b":1}]}`))
+	r.Header.Set("Content-Type", "application/json")
+	_, err := DecodeRequest(r, DecodeLimits{MaxDepth: 3})
+	require.NoError(t, err)
+
+	// Size errors are decode limit errors too, whether a token ends past the limit or not
+	for _, body := range []string{`{"a":1,"b":2,"c":3}`, `{"a":"0123456789"}`} {
+		r = httptest.NewRequest("POST", "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		_, err = DecodeRequest(r, DecodeLimits{MaxBytes: 12})
+		require.ErrorIs(t, err, ErrRequestTooLarge)
+		require.ErrorIs(t, err, ErrSizeLimitExceeded)
+	}
+
+	// Only the value counts against the limit
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"a":1}`+strings.Repeat(" ", 20)))
+	r.Header.Set("Content-Type", "application/json")
+	_, err = DecodeRequest(r, DecodeLimits{MaxBytes: 12, MaxKeys: 1})
+	require.NoError(t, err)
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	obj := NewObject[any]().Set("z", 1).Set("a", 2)
+	require.NoError(t, obj.WriteJSON(rec, 201))
+
+	assert.Equal(t, 201, rec.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"z\":1,\"a\":2}\n", rec.Body.String())
+}
+
+func TestRenderer(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().Set("z", 1).Set("a", 2)
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, obj.Renderer().Render(rec))
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"z\":1,\"a\":2}\n", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/problem+json")
+	require.NoError(t, obj.Renderer().Render(rec))
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+}
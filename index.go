@@ -0,0 +1,124 @@
+package orderedobject
+
+import "slices"
+
+// valueIndex maps index keys extracted from values to the entries holding them.
+type valueIndex[V any] struct {
+	extract func(value V) string
+	entries map[string][]Entry[V]
+}
+
+// BuildIndex builds a secondary index named name over the values of the object,
+// keyed by the string extractor returns for each value, such as the email field of a
+// record. The index is kept up to date as entries are set and deleted, so GetByIndex
+// finds a value without scanning the object. Building an index under an existing name
+// replaces it. Values changed in place, such as through a pointer, are not noticed;
+// set them again or rebuild the index.
+// Returns the object for chaining.
+func (object *Object[V]) BuildIndex(name string, extractor func(value V) string) *Object[V] {
+	ext := object.extend()
+	if ext.indexes == nil {
+		ext.indexes = make(map[string]*valueIndex[V])
+	}
+	index := &valueIndex[V]{extract: extractor}
+	index.rebuild(object.entries)
+	ext.indexes[name] = index
+	return object
+}
+
+// GetByIndex returns the value whose index key in the index named name is indexKey,
+// and whether there is one. If several entries share the index key, the one indexed
+// first is returned.
+func (object *Object[V]) GetByIndex(name, indexKey string) (V, bool) {
+	object.expire()
+	if object.ext == nil {
+		var zero V
+		return zero, false
+	}
+	if index, ok := object.ext.indexes[name]; ok {
+		if entries := index.entries[indexKey]; len(entries) > 0 {
+			return entries[0].Value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// DropIndex removes the index named name and reports whether it existed.
+func (object *Object[V]) DropIndex(name string) bool {
+	if object.ext == nil {
+		return false
+	}
+	if _, ok := object.ext.indexes[name]; !ok {
+		return false
+	}
+	delete(object.ext.indexes, name)
+	if len(object.ext.indexes) == 0 {
+		object.ext.indexes = nil
+	}
+	return true
+}
+
+// indexSet updates the indexes for a set of key.
+func (object *Object[V]) indexSet(key string, value, old V, replaced bool) {
+	for _, index := range object.ext.indexes {
+		if replaced {
+			index.remove(key, old)
+		}
+		index.add(key, value)
+	}
+}
+
+// indexDelete updates the indexes for the removal of an entry.
+func (object *Object[V]) indexDelete(entry Entry[V]) {
+	for _, index := range object.ext.indexes {
+		index.remove(entry.Key, entry.Value)
+	}
+}
+
+// reindex rebuilds the indexes after the entries were replaced wholesale.
+func (object *Object[V]) reindex() {
+	if object.ext == nil {
+		return
+	}
+	for _, index := range object.ext.indexes {
+		index.rebuild(object.entries)
+	}
+}
+
+// cloneIndexes builds the indexes of the object on its clone.
+func (object *Object[V]) cloneIndexes(clone *Object[V]) {
+	if object.ext == nil {
+		return
+	}
+	for name, index := range object.ext.indexes {
+		clone.BuildIndex(name, index.extract)
+	}
+}
+
+// rebuild indexes entries from scratch.
+func (index *valueIndex[V]) rebuild(entries []Entry[V]) {
+	index.entries = make(map[string][]Entry[V], len(entries))
+	for _, entry := range entries {
+		index.add(entry.Key, entry.Value)
+	}
+}
+
+// add indexes value under key.
+func (index *valueIndex[V]) add(key string, value V) {
+	indexKey := index.extract(value)
+	index.entries[indexKey] = append(index.entries[indexKey], Entry[V]{Key: key, Value: value})
+}
+
+// remove drops the entry of key indexed for value.
+func (index *valueIndex[V]) remove(key string, value V) {
+	indexKey := index.extract(value)
+	entries := slices.DeleteFunc(index.entries[indexKey], func(entry Entry[V]) bool {
+		return entry.Key == key
+	})
+	if len(entries) == 0 {
+		delete(index.entries, indexKey)
+	} else {
+		index.entries[indexKey] = entries
+	}
+}
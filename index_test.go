@@ -0,0 +1,121 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type indexUser struct {
+	Name  string
+	Email string
+}
+
+func TestIndex(t *testing.T) {
+	t.Parallel()
+
+	users := NewObject[indexUser]().
+		Set("u1", indexUser{Name: "Alice", Email: "alice@example.com"}).
+		Set("u2", indexUser{Name: "Bob", Email: "bob@example.com"})
+	users.BuildIndex("email", func(u indexUser) string { return u.Email })
+
+	user, ok := users.GetByIndex("email", "bob@example.com")
+	require.True(t, ok)
+	assert.Equal(t, "Bob", user.Name)
+
+	_, ok = users.GetByIndex("email", "carol@example.com")
+	assert.False(t, ok)
+	_, ok = users.GetByIndex("name", "Bob")
+	assert.False(t, ok, "unknown index")
+
+	t.Run("set", func(t *testing.T) {
+		t.Parallel()
+		obj := users.Clone()
+		obj.Set("u3", indexUser{Name: "Carol", Email: "carol@example.com"})
+		user, ok := obj.GetByIndex("email", "carol@example.com")
+		require.True(t, ok)
+		assert.Equal(t, "Carol", user.Name)
+
+		// Replacing a value moves it to its new index key
+		obj.Set("u2", indexUser{Name: "Robert", Email: "robert@example.com"})
+		_, ok = obj.GetByIndex("email", "bob@example.com")
+		assert.False(t, ok)
+		user, ok = obj.GetByIndex("email", "robert@example.com")
+		require.True(t, ok)
+		assert.Equal(t, "Robert", user.Name)
+
+		// The original object keeps its own index
+		user, ok = users.GetByIndex("email", "bob@example.com")
+		require.True(t, ok)
+		assert.Equal(t, "Bob", user.Name)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+		obj := users.Clone()
+		obj.Delete("u1")
+		_, ok := obj.GetByIndex("email", "alice@example.com")
+		assert.False(t, ok)
+
+		obj.DeleteFunc(func(string, indexUser) bool { return true })
+		_, ok = obj.GetByIndex("email", "bob@example.com")
+		assert.False(t, ok)
+	})
+
+	t.Run("shared index keys", func(t *testing.T) {
+		t.Parallel()
+		obj := users.Clone().BuildIndex("domain", func(u indexUser) string { return u.Email[len(u.Name)+1:] })
+		user, ok := obj.GetByIndex("domain", "example.com")
+		require.True(t, ok)
+		assert.Equal(t, "Alice", user.Name)
+		obj.Delete("u1")
+		user, ok = obj.GetByIndex("domain", "example.com")
+		require.True(t, ok)
+		assert.Equal(t, "Bob", user.Name)
+	})
+
+	t.Run("wholesale replacement", func(t *testing.T) {
+		t.Parallel()
+		obj := users.Clone()
+		require.NoError(t, obj.UnmarshalJSON([]byte(`{"u9":{"Name":"Dan","Email":"dan@example.com"}}`)))
+		_, ok := obj.GetByIndex("email", "alice@example.com")
+		assert.False(t, ok)
+		user, ok := obj.GetByIndex("email", "dan@example.com")
+		require.True(t, ok)
+		assert.Equal(t, "Dan", user.Name)
+
+		txn := obj.Begin()
+		txn.Set("u10", indexUser{Name: "Eve", Email: "eve@example.com"})
+		txn.Delete("u9")
+		require.NoError(t, txn.Commit())
+		_, ok = obj.GetByIndex("email", "dan@example.com")
+		assert.False(t, ok)
+		_, ok = obj.GetByIndex("email", "eve@example.com")
+		assert.True(t, ok)
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		t.Parallel()
+		obj := users.Clone()
+		assert.True(t, obj.DropIndex("email"))
+		assert.False(t, obj.DropIndex("email"))
+		_, ok := obj.GetByIndex("email", "bob@example.com")
+		assert.False(t, ok)
+	})
+}
+
+func BenchmarkGetByIndex(b *testing.B) {
+	obj := NewObject[indexUser]()
+	for i := range 1000 {
+		key := string(rune('a'+i%26)) + string(rune('a'+i/26))
+		obj.Set(key, indexUser{Name: key, Email: key + "@example.com"})
+	}
+	obj.BuildIndex("email", func(u indexUser) string { return u.Email })
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		obj.GetByIndex("email", "zz@example.com")
+	}
+}
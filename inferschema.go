@@ -0,0 +1,202 @@
+package orderedobject
+
+import "slices"
+
+// schemaDialect is the $schema of inferred schemas.
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// InferSchema returns a JSON Schema (draft 2020-12) describing the object, with the
+// keys of "properties" and "required" in the order of the entries, so the schema reads
+// like the document it was inferred from. Nested *Object[any] values, maps and slices
+// are described recursively. The items of an array are described by one schema merging
+// all of them: objects are merged into one listing every key seen, requiring only keys
+// present in all of them, and values of different types are combined into a list of
+// types or an anyOf. Whole numbers are inferred as integers, and values of other Go
+// types are described by an empty schema.
+func (object *Object[V]) InferSchema() *Object[any] {
+	inferred := inferSchema(object.schemaInstance())
+	schema := NewObject[any](len(inferred.entries)+1).Set("$schema", schemaDialect)
+	for _, entry := range inferred.entries {
+		schema.Set(entry.Key, entry.Value)
+	}
+	return schema
+}
+
+// inferSchema returns a schema describing value.
+func inferSchema(value any) *Object[any] {
+	if members, ok := schemaMembers(value); ok {
+		schema := NewObject[any](3).Set("type", "object")
+		if len(members) == 0 {
+			return schema
+		}
+		properties := NewObject[any](len(members))
+		required := make([]any, len(members))
+		for i, member := range members {
+			properties.Set(member.Key, inferSchema(member.Value))
+			required[i] = member.Key
+		}
+		return schema.Set("properties", properties).Set("required", required)
+	}
+	if items, ok := schemaItems(value); ok {
+		schema := NewObject[any](2).Set("type", "array")
+		if len(items) == 0 {
+			return schema
+		}
+		merged := inferSchema(items[0])
+		for _, item := range items[1:] {
+			merged = mergeSchemas(merged, inferSchema(item))
+		}
+		return schema.Set("items", merged)
+	}
+	switch t := schemaType(value); t {
+	case "null", "boolean", "string", "integer", "number":
+		return NewObject[any](1).Set("type", t)
+	}
+	return NewObject[any]()
+}
+
+// mergeSchemas returns a schema describing the values described by a or b.
+func mergeSchemas(a, b *Object[any]) *Object[any] {
+	if merged, ok := mergeAlternative(a, b); ok {
+		return merged
+	}
+	alternatives := schemaAlternatives(a)
+	for _, alt := range schemaAlternatives(b) {
+		i := slices.IndexFunc(alternatives, func(existing *Object[any]) bool {
+			_, ok := mergeAlternative(existing, alt)
+			return ok
+		})
+		if i < 0 {
+			alternatives = append(alternatives, alt)
+			continue
+		}
+		alternatives[i], _ = mergeAlternative(alternatives[i], alt)
+	}
+	if len(alternatives) == 1 {
+		return alternatives[0]
+	}
+	anyOf := make([]any, len(alternatives))
+	for i, alt := range alternatives {
+		anyOf[i] = alt
+	}
+	return NewObject[any](1).Set("anyOf", anyOf)
+}
+
+// mergeAlternative merges two schemas that can be described by a single schema
+// without anyOf: equal schemas, objects, arrays, and schemas holding only a type.
+func mergeAlternative(a, b *Object[any]) (*Object[any], bool) {
+	if a.Equal(b) {
+		return a, true
+	}
+	ta, tb := schemaTypeOf(a), schemaTypeOf(b)
+	switch {
+	case ta == "object" && tb == "object":
+		return mergeObjectSchemas(a, b), true
+	case ta == "array" && tb == "array":
+		itemsA, okA := a.Get("items")
+		itemsB, okB := b.Get("items")
+		switch {
+		case !okA:
+			return b, true
+		case !okB:
+			return a, true
+		}
+		return NewObject[any](2).Set("type", "array").Set("items", mergeSchemas(itemsA.(*Object[any]), itemsB.(*Object[any]))), true
+	}
+
+	typesA, okA := schemaTypeList(a)
+	typesB, okB := schemaTypeList(b)
+	if !okA || !okB {
+		return nil, false
+	}
+	// Integers are numbers, so "number" takes the place of "integer" once both occur
+	widen := slices.Contains(typesA, "number") || slices.Contains(typesB, "number")
+	var types []any
+	for _, t := range slices.Concat(typesA, typesB) {
+		if widen && t == "integer" {
+			t = "number"
+		}
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	if len(types) == 1 {
+		return NewObject[any](1).Set("type", types[0]), true
+	}
+	return NewObject[any](1).Set("type", types), true
+}
+
+// mergeObjectSchemas merges two object schemas, listing the properties of a and then
+// the properties only b has, and requiring the keys both require.
+func mergeObjectSchemas(a, b *Object[any]) *Object[any] {
+	propsA, _ := a.Get("properties")
+	propsB, _ := b.Get("properties")
+	properties := NewObject[any]()
+	for _, props := range []any{propsA, propsB} {
+		obj, _ := props.(*Object[any])
+		if obj == nil {
+			continue
+		}
+		for _, entry := range obj.entries {
+			if existing, ok := properties.Get(entry.Key); ok {
+				properties.Set(entry.Key, mergeSchemas(existing.(*Object[any]), entry.Value.(*Object[any])))
+			} else {
+				properties.Set(entry.Key, entry.Value)
+			}
+		}
+	}
+
+	reqA, _ := a.Get("required")
+	reqB, _ := b.Get("required")
+	requiredB, _ := reqB.([]any)
+	var required []any
+	if requiredA, ok := reqA.([]any); ok {
+		for _, key := range requiredA {
+			if slices.Contains(requiredB, key) {
+				required = append(required, key)
+			}
+		}
+	}
+
+	schema := NewObject[any](3).Set("type", "object")
+	if properties.Length() > 0 {
+		schema.Set("properties", properties)
+	}
+	if len(required) > 0 {
+		schema.Set("required", required)
+	}
+	return schema
+}
+
+// schemaAlternatives returns the schemas listed by anyOf, or the schema itself.
+func schemaAlternatives(schema *Object[any]) []*Object[any] {
+	if anyOf, ok := schema.Get("anyOf"); ok {
+		var alternatives []*Object[any]
+		for _, alt := range anyOf.([]any) {
+			alternatives = append(alternatives, alt.(*Object[any]))
+		}
+		return alternatives
+	}
+	return []*Object[any]{schema}
+}
+
+// schemaTypeOf returns the type of a schema with a single type, or "".
+func schemaTypeOf(schema *Object[any]) string {
+	t, _ := schema.Get("type")
+	s, _ := t.(string)
+	return s
+}
+
+// schemaTypeList returns the types of a schema holding only a type.
+func schemaTypeList(schema *Object[any]) ([]any, bool) {
+	if schema.Length() != 1 {
+		return nil, false
+	}
+	switch t, _ := schema.Get("type"); t := t.(type) {
+	case string:
+		return []any{t}, true
+	case []any:
+		return t, true
+	}
+	return nil, false
+}
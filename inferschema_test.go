@@ -0,0 +1,97 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferSchema(t *testing.T) {
+	t.Parallel()
+
+	obj := MustFromJSONDeep([]byte(`{
+		"name": "app",
+		"port": 8080,
+		"ratio": 0.5,
+		"debug": false,
+		"owner": null,
+		"server": {"host": "localhost", "tls": {}},
+		"tags": [],
+		"users": [
+			{"name": "alice", "age": 30},
+			{"name": "bob", "email": "bob@example.com", "age": 41.5}
+		],
+		"mixed": [1, "a", 2.5, null]
+	}`))
+
+	schema := obj.InferSchema()
+	data, err := schema.ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"port": {"type": "integer"},
+			"ratio": {"type": "number"},
+			"debug": {"type": "boolean"},
+			"owner": {"type": "null"},
+			"server": {
+				"type": "object",
+				"properties": {"host": {"type": "string"}, "tls": {"type": "object"}},
+				"required": ["host", "tls"]
+			},
+			"tags": {"type": "array"},
+			"users": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {"name": {"type": "string"}, "age": {"type": "number"}, "email": {"type": "string"}},
+					"required": ["name", "age"]
+				}
+			},
+			"mixed": {"type": "array", "items": {"type": ["number", "string", "null"]}}
+		},
+		"required": ["name", "port", "ratio", "debug", "owner", "server", "tags", "users", "mixed"]
+	}`, string(data))
+
+	// Properties follow the order of the entries
+	properties, ok := GetAs[*Object[any]](schema, "properties")
+	require.True(t, ok)
+	assert.Equal(t, obj.Keys(), properties.Keys())
+	users, ok := schema.GetPath("properties.users.items.properties")
+	require.True(t, ok)
+	assert.Equal(t, []string{"name", "age", "email"}, users.(*Object[any]).Keys())
+
+	// The object satisfies the inferred schema
+	violations, err := obj.Validate(schema)
+	require.NoError(t, err)
+	assert.Nil(t, violations)
+}
+
+func TestInferSchemaAnyOf(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("values", []any{
+			map[string]any{"a": 1},
+			"x",
+			[]any{1},
+			NewObject[any]().Set("b", true),
+			[]any{"y"},
+		})
+
+	items, ok := obj.InferSchema().GetPath("properties.values.items")
+	require.True(t, ok)
+	data, err := items.(*Object[any]).ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"anyOf": [
+		{"type": "object", "properties": {"a": {"type": "integer"}, "b": {"type": "boolean"}}},
+		{"type": "string"},
+		{"type": "array", "items": {"type": ["integer", "string"]}}
+	]}`, string(data))
+
+	typed := NewObject[int]().Set("b", 2).Set("a", 1)
+	assert.Equal(t, []string{"$schema", "type", "properties", "required"}, typed.InferSchema().Keys())
+}
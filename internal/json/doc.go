@@ -0,0 +1,4 @@
+// Package json selects the JSON v2 implementation used by the module: the standard
+// library's encoding/json/v2 when building with GOEXPERIMENT=jsonv2 on Go 1.27 or
+// later, and the github.com/go-json-experiment/json module otherwise.
+package json
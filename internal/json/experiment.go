@@ -0,0 +1,42 @@
+//go:build !goexperiment.jsonv2 || !go1.27
+
+package json
+
+import (
+	json "github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// Interfaces and option types of the selected implementation.
+type (
+	Options         = json.Options
+	Marshalers      = json.Marshalers
+	Marshaler       = json.Marshaler
+	MarshalerTo     = json.MarshalerTo
+	UnmarshalerFrom = json.UnmarshalerFrom
+
+	SemanticError = json.SemanticError
+)
+
+// Functions and options of the selected implementation.
+var (
+	Marshal         = json.Marshal
+	MarshalEncode   = json.MarshalEncode
+	Unmarshal       = json.Unmarshal
+	UnmarshalDecode = json.UnmarshalDecode
+
+	Deterministic        = json.Deterministic
+	WithMarshalers       = json.WithMarshalers
+	FormatNilSliceAsNull = json.FormatNilSliceAsNull
+	StringifyNumbers     = json.StringifyNumbers
+)
+
+// GetOption returns the value of an option in opts, if set.
+func GetOption[T any](opts Options, setter func(T) Options) (T, bool) {
+	return json.GetOption(opts, setter)
+}
+
+// MarshalToFunc returns marshalers encoding values of type T with fn.
+func MarshalToFunc[T any](fn func(*jsontext.Encoder, T) error) *Marshalers {
+	return json.MarshalToFunc(fn)
+}
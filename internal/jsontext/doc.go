@@ -0,0 +1,4 @@
+// Package jsontext selects the jsontext implementation matching the json package:
+// the standard library's encoding/json/jsontext when building with GOEXPERIMENT=jsonv2
+// on Go 1.27 or later, and github.com/go-json-experiment/json/jsontext otherwise.
+package jsontext
@@ -0,0 +1,45 @@
+//go:build !goexperiment.jsonv2 || !go1.27
+
+package jsontext
+
+import "github.com/go-json-experiment/json/jsontext"
+
+// Types of the selected implementation.
+type (
+	Decoder = jsontext.Decoder
+	Encoder = jsontext.Encoder
+	Kind    = jsontext.Kind
+	Options = jsontext.Options
+	Pointer = jsontext.Pointer
+	Token   = jsontext.Token
+	Value   = jsontext.Value
+
+	SyntacticError = jsontext.SyntacticError
+)
+
+// Errors of the selected implementation.
+var ErrDuplicateName = jsontext.ErrDuplicateName
+
+// Tokens of the selected implementation.
+var (
+	Null        = jsontext.Null
+	BeginObject = jsontext.BeginObject
+	EndObject   = jsontext.EndObject
+)
+
+// Functions and options of the selected implementation.
+var (
+	NewDecoder = jsontext.NewDecoder
+	NewEncoder = jsontext.NewEncoder
+	String     = jsontext.String
+	Bool       = jsontext.Bool
+	Int        = jsontext.Int
+	Float      = jsontext.Float
+
+	AllowDuplicateNames = jsontext.AllowDuplicateNames
+	AllowInvalidUTF8    = jsontext.AllowInvalidUTF8
+	EscapeForHTML       = jsontext.EscapeForHTML
+	Multiline           = jsontext.Multiline
+	WithIndent          = jsontext.WithIndent
+	WithIndentPrefix    = jsontext.WithIndentPrefix
+)
@@ -0,0 +1,50 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrValueCollision is returned by Invert when two keys hold the same value and the
+// policy is CollisionError
+var ErrValueCollision = errors.New("value collision")
+
+// CollisionPolicy selects which entry is kept when two entries collide: two keys
+// holding the same value for Invert, or the same key for Concat.
+type CollisionPolicy int
+
+const (
+	// CollisionKeepFirst keeps the first entry, skipping the later ones.
+	CollisionKeepFirst CollisionPolicy = iota
+	// CollisionKeepLast keeps the last entry, at the position of the first.
+	CollisionKeepLast
+	// CollisionError reports the collision as an error.
+	CollisionError
+)
+
+// Invert returns a new object keyed by the values of object, holding the keys that
+// held them, such as a reverse lookup table for an ordered enumeration. Values are
+// converted to keys by their string form. The entries are ordered by the first
+// occurrence of each value, and policy selects the key kept for a value held by
+// several keys. The object is not modified.
+func Invert[V comparable](object *Object[V], policy CollisionPolicy) (*Object[string], error) {
+	object.expire()
+	result := NewObject[string](len(object.entries))
+	index := make(map[string]int, len(object.entries))
+	for _, entry := range object.entries {
+		key := fmt.Sprint(entry.Value)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(result.entries)
+			result.entries = append(result.entries, Entry[string]{Key: key, Value: entry.Key})
+			continue
+		}
+		switch policy {
+		case CollisionKeepLast:
+			result.entries[i].Value = entry.Key
+		case CollisionError:
+			return nil, fmt.Errorf("%w: %q and %q both hold %q", ErrValueCollision, result.entries[i].Value, entry.Key, key)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvert(t *testing.T) {
+	t.Parallel()
+
+	codes := NewObject[string]().
+		Set("ok", "200").
+		Set("created", "201").
+		Set("success", "200").
+		Set("missing", "404")
+
+	t.Run("keep first", func(t *testing.T) {
+		t.Parallel()
+		inverted, err := Invert(codes, CollisionKeepFirst)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"200", "201", "404"}, inverted.Keys())
+		assert.Equal(t, []string{"ok", "created", "missing"}, inverted.Values())
+	})
+
+	t.Run("keep last", func(t *testing.T) {
+		t.Parallel()
+		inverted, err := Invert(codes, CollisionKeepLast)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"200", "201", "404"}, inverted.Keys())
+		assert.Equal(t, []string{"success", "created", "missing"}, inverted.Values())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		_, err := Invert(codes, CollisionError)
+		require.ErrorIs(t, err, ErrValueCollision)
+		assert.Contains(t, err.Error(), `"ok" and "success" both hold "200"`)
+
+		unique, err := Invert(NewObject[string]().Set("a", "x").Set("b", "y"), CollisionError)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"x", "y"}, unique.Keys())
+	})
+
+	t.Run("comparable values", func(t *testing.T) {
+		t.Parallel()
+		levels := NewObject[int]().Set("debug", -4).Set("info", 0).Set("warn", 4)
+		inverted, err := Invert(levels, CollisionError)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"-4", "0", "4"}, inverted.Keys())
+		warn, _ := inverted.Get("4")
+		assert.Equal(t, "warn", warn)
+	})
+
+	assert.Equal(t, []string{"ok", "created", "success", "missing"}, codes.Keys())
+}
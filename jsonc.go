@@ -0,0 +1,414 @@
+package orderedobject
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// ErrUnterminatedComment is returned when a JSONC block comment is not closed
+var ErrUnterminatedComment = errors.New("unterminated comment")
+
+// EntryComments holds the comments attached to an entry in a JSONC document.
+type EntryComments struct {
+	// Leading holds the comment lines written on the lines before the key.
+	Leading []string
+	// Trailing is the comment written after the value on the same line.
+	Trailing string
+}
+
+// Comments returns the comments attached to key and whether there are any.
+func (object *Object[V]) Comments(key string) (EntryComments, bool) {
+	if object.ext == nil {
+		return EntryComments{}, false
+	}
+	comments, ok := object.ext.comments[object.resolveKey(key)]
+	return comments, ok
+}
+
+// SetComments attaches comments to key, replacing existing ones. They are written by
+// ToJSONC while the key exists. Returns the object for chaining.
+func (object *Object[V]) SetComments(key string, comments EntryComments) *Object[V] {
+	ext := object.extend()
+	if ext.comments == nil {
+		ext.comments = make(map[string]EntryComments)
+	}
+	ext.comments[object.resolveKey(key)] = comments
+	return object
+}
+
+// SetEndComments sets the comment lines written after the last entry, before the
+// closing brace. Returns the object for chaining.
+func (object *Object[V]) SetEndComments(lines ...string) *Object[V] {
+	object.extend().endComments = lines
+	return object
+}
+
+// EndComments returns the comment lines written after the last entry.
+func (object *Object[V]) EndComments() []string {
+	if object.ext == nil {
+		return nil
+	}
+	return object.ext.endComments
+}
+
+// FromJSONC creates an ordered object from JSON with comments (JSONC), decoding nested
+// objects as ordered objects. Both // and /* */ comments are accepted, as are trailing
+// commas. Comments on the lines before a key are attached to it as leading comments, a
+// comment after a value on the same line as trailing comment, and comments after the
+// last entry as end comments, so ToJSONC can write them back. Comments before the
+// document are attached to its first key; comments between array elements are dropped.
+func FromJSONC(data []byte) (*Object[any], error) {
+	clean, comments, err := stripComments(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSONC: %w", err)
+	}
+	p := &jsoncParser{dec: jsontext.NewDecoder(bytes.NewReader(clean)), src: clean, comments: comments}
+	if p.dec.PeekKind() != '{' {
+		return nil, fmt.Errorf("failed to unmarshal JSONC: %w", ErrExpectedObjectStart)
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSONC: %w", err)
+	}
+	obj := value.(*Object[any])
+	// Comments after the document are kept with the end comments
+	if rest := p.comments[p.next:]; len(rest) > 0 {
+		lines := obj.EndComments()
+		for _, c := range rest {
+			lines = append(lines, c.lines...)
+		}
+		obj.SetEndComments(lines...)
+	}
+	return obj, nil
+}
+
+// ToJSONC encodes the ordered object as indented JSON, writing the attached comments
+// as // comments. Nested *Object[any] values, including those inside arrays, are
+// written with their own comments. An empty indent indents with a tab.
+func (object *Object[V]) ToJSONC(indent string) ([]byte, error) {
+	if indent == "" {
+		indent = "\t"
+	}
+	if strings.Trim(indent, " \t") != "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidIndent, indent)
+	}
+	var b bytes.Buffer
+	if err := writeJSONCObject(&b, object, indent, ""); err != nil {
+		return nil, err
+	}
+	b.WriteByte('\n')
+	return b.Bytes(), nil
+}
+
+// writeJSONCObject writes an object and its comments, with its lines after the first
+// prefixed by prefix.
+func writeJSONCObject[V any](b *bytes.Buffer, object *Object[V], indent, prefix string) error {
+	var comments map[string]EntryComments
+	var endComments []string
+	if object.ext != nil {
+		comments, endComments = object.ext.comments, object.ext.endComments
+	}
+	if len(object.entries) == 0 && len(endComments) == 0 {
+		b.WriteString("{}")
+		return nil
+	}
+	inner := prefix + indent
+	b.WriteString("{\n")
+	for i, entry := range object.entries {
+		entryComments := comments[entry.Key]
+		writeCommentLines(b, entryComments.Leading, inner)
+		key, err := json.Marshal(entry.Key)
+		if err != nil {
+			return err
+		}
+		b.WriteString(inner)
+		b.Write(key)
+		b.WriteString(": ")
+		if err := writeJSONCValue(b, any(entry.Value), indent, inner); err != nil {
+			return err
+		}
+		if i < len(object.entries)-1 {
+			b.WriteByte(',')
+		}
+		if entryComments.Trailing != "" {
+			// A trailing comment must stay on the line, so its lines are joined
+			b.WriteString(" // ")
+			b.WriteString(strings.Join(commentLines(entryComments.Trailing), " "))
+		}
+		b.WriteByte('\n')
+	}
+	writeCommentLines(b, endComments, inner)
+	b.WriteString(prefix)
+	b.WriteByte('}')
+	return nil
+}
+
+// writeJSONCValue writes a value, descending into arrays so nested objects keep
+// their comments.
+func writeJSONCValue(b *bytes.Buffer, value any, indent, prefix string) error {
+	switch v := value.(type) {
+	case *Object[any]:
+		if v != nil {
+			return writeJSONCObject(b, v, indent, prefix)
+		}
+	case []any:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return nil
+		}
+		inner := prefix + indent
+		b.WriteString("[\n")
+		for i, element := range v {
+			b.WriteString(inner)
+			if err := writeJSONCValue(b, element, indent, inner); err != nil {
+				return err
+			}
+			if i < len(v)-1 {
+				b.WriteByte(',')
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString(prefix)
+		b.WriteByte(']')
+		return nil
+	}
+	data, err := json.Marshal(value, json.Deterministic(true),
+		jsontext.Multiline(true), jsontext.WithIndent(indent), jsontext.WithIndentPrefix(prefix))
+	if err != nil {
+		return err
+	}
+	b.Write(data)
+	return nil
+}
+
+// writeCommentLines writes each line as a // comment on its own line, splitting
+// lines that contain line breaks.
+func writeCommentLines(b *bytes.Buffer, lines []string, prefix string) {
+	for _, text := range lines {
+		for _, line := range commentLines(text) {
+			b.WriteString(prefix)
+			b.WriteString("//")
+			if line != "" {
+				b.WriteByte(' ')
+				b.WriteString(line)
+			}
+			b.WriteByte('\n')
+		}
+	}
+}
+
+// commentLines splits comment text on \n, \r\n and \r line breaks.
+func commentLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.ReplaceAll(text, "\r", "\n"), "\n")
+}
+
+// jsonComment is a comment found in a JSONC document.
+type jsonComment struct {
+	// start is the byte offset of the comment
+	start int
+	// lines holds the comment text without its markers
+	lines []string
+}
+
+// stripComments returns data with comments and trailing commas replaced by spaces,
+// keeping every other byte at its offset, together with the comments it removed.
+func stripComments(data []byte) ([]byte, []jsonComment, error) {
+	clean := slices.Clone(data)
+	var comments []jsonComment
+	inString := false
+	for i := 0; i < len(clean); i++ {
+		c := clean[i]
+		switch {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(clean) && clean[i+1] == '/':
+			end := bytes.IndexByte(clean[i:], '\n')
+			if end < 0 {
+				end = len(clean)
+			} else {
+				end += i
+			}
+			text := strings.TrimSpace(string(clean[i+2 : end]))
+			comments = append(comments, jsonComment{start: i, lines: []string{text}})
+			blank(clean[i:end])
+			i = end - 1
+		case c == '/' && i+1 < len(clean) && clean[i+1] == '*':
+			end := bytes.Index(clean[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, nil, fmt.Errorf("%w at offset %d", ErrUnterminatedComment, i)
+			}
+			end += i + 4
+			comments = append(comments, jsonComment{start: i, lines: blockCommentLines(string(clean[i+2 : end-2]))})
+			blank(clean[i:end])
+			i = end - 1
+		}
+	}
+
+	// Remove trailing commas before closing braces and brackets
+	inString, escaped := false, false
+	for i, c := range clean {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == ',':
+			rest := bytes.TrimLeft(clean[i+1:], " \t\r\n")
+			if len(rest) > 0 && (rest[0] == '}' || rest[0] == ']') {
+				clean[i] = ' '
+			}
+		}
+	}
+	return clean, comments, nil
+}
+
+// blank replaces every byte except newlines with a space.
+func blank(data []byte) {
+	for i, c := range data {
+		if c != '\n' {
+			data[i] = ' '
+		}
+	}
+}
+
+// blockCommentLines splits the text of a block comment into trimmed lines, dropping
+// blank first and last lines and the leading asterisks of doc-style comments.
+func blockCommentLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "* ") || line == "*" {
+			line = strings.TrimSpace(line[1:])
+		}
+		lines[i] = line
+	}
+	if len(lines) > 1 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// jsoncParser decodes a JSONC document whose comments were stripped, attaching the
+// comments to the entries around them.
+type jsoncParser struct {
+	dec      *jsontext.Decoder
+	src      []byte
+	comments []jsonComment
+	// next is the index of the first comment not yet attached
+	next int
+}
+
+// parseValue decodes the next value, decoding objects as *Object[any].
+func (p *jsoncParser) parseValue() (any, error) {
+	switch p.dec.PeekKind() {
+	case '{':
+		return p.parseObject()
+	case '[':
+		if _, err := p.dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		values := []any{}
+		for p.dec.PeekKind() != ']' {
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		if _, err := p.dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		// Comments between array elements are not attached to anything
+		end := int(p.dec.InputOffset())
+		for p.next < len(p.comments) && p.comments[p.next].start < end {
+			p.next++
+		}
+		return values, nil
+	}
+	var value any
+	if err := json.UnmarshalDecode(p.dec, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// parseObject decodes an object and attaches the comments inside it.
+func (p *jsoncParser) parseObject() (*Object[any], error) {
+	if _, err := p.dec.ReadToken(); err != nil {
+		return nil, err
+	}
+	obj := NewObject[any]()
+	lastKey, lastEnd := "", 0
+	for p.dec.PeekKind() != '}' {
+		raw, err := p.dec.ReadValue()
+		if err != nil {
+			return nil, err
+		}
+		keyStart := int(p.dec.InputOffset()) - len(raw)
+		var key string
+		if err := json.Unmarshal(raw, &key); err != nil {
+			return nil, err
+		}
+		leading := p.attach(obj, lastKey, lastEnd, keyStart)
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		obj.Set(key, value)
+		if len(leading) > 0 {
+			obj.SetComments(key, EntryComments{Leading: leading})
+		}
+		lastKey, lastEnd = key, int(p.dec.InputOffset())
+	}
+	if _, err := p.dec.ReadToken(); err != nil {
+		return nil, err
+	}
+	if lines := p.attach(obj, lastKey, lastEnd, int(p.dec.InputOffset())-1); len(lines) > 0 {
+		obj.SetEndComments(lines...)
+	}
+	return obj, nil
+}
+
+// attach consumes the comments before offset end. A comment on the same line as the
+// end of the previous entry becomes its trailing comment; the lines of the others are
+// returned.
+func (p *jsoncParser) attach(obj *Object[any], lastKey string, lastEnd, end int) []string {
+	var lines []string
+	for p.next < len(p.comments) && p.comments[p.next].start < end {
+		c := p.comments[p.next]
+		p.next++
+		if lastKey != "" && bytes.IndexByte(p.src[lastEnd:c.start], '\n') < 0 {
+			comments, _ := obj.Comments(lastKey)
+			if comments.Trailing == "" {
+				comments.Trailing = strings.Join(c.lines, " ")
+				obj.SetComments(lastKey, comments)
+				continue
+			}
+		}
+		lines = append(lines, c.lines...)
+	}
+	return lines
+}
@@ -0,0 +1,154 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsoncInput = `// Application settings
+{
+  // Display name
+  "name": "app", // shown in the title bar
+  /*
+   * Server options
+   */
+  "server": {
+    "port": 8080, // default port
+    "hosts": ["a", /* primary */ "b",],
+    // "debug": true,
+  },
+  "url": "http://example.com/*not a comment*/", // "quoted // text"
+  "list": [{"k": 1 /* one */}],
+}
+`
+
+func TestFromJSONC(t *testing.T) {
+	t.Parallel()
+
+	obj, err := FromJSONC([]byte(jsoncInput))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "server", "url", "list"}, obj.Keys())
+
+	url, _ := obj.Get("url")
+	assert.Equal(t, "http://example.com/*not a comment*/", url)
+
+	comments, ok := obj.Comments("name")
+	require.True(t, ok)
+	assert.Equal(t, EntryComments{
+		Leading:  []string{"Application settings", "Display name"},
+		Trailing: "shown in the title bar",
+	}, comments)
+
+	comments, _ = obj.Comments("server")
+	assert.Equal(t, EntryComments{Leading: []string{"Server options"}}, comments)
+
+	comments, _ = obj.Comments("url")
+	assert.Equal(t, `"quoted // text"`, comments.Trailing)
+
+	server, _ := obj.Get("server")
+	nested := server.(*Object[any])
+	comments, _ = nested.Comments("port")
+	assert.Equal(t, "default port", comments.Trailing)
+	assert.Equal(t, []string{`"debug": true,`}, nested.EndComments())
+
+	hosts, _ := nested.Get("hosts")
+	assert.Equal(t, []any{"a", "b"}, hosts)
+
+	_, ok = obj.Comments("missing")
+	assert.False(t, ok)
+}
+
+func TestToJSONC(t *testing.T) {
+	t.Parallel()
+
+	obj, err := FromJSONC([]byte(jsoncInput))
+	require.NoError(t, err)
+	obj.Set("added", true).SetComments("added", EntryComments{Trailing: "new"})
+
+	data, err := obj.ToJSONC("  ")
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  // Application settings
+  // Display name
+  "name": "app", // shown in the title bar
+  // Server options
+  "server": {
+    "port": 8080, // default port
+    "hosts": [
+      "a",
+      "b"
+    ]
+    // "debug": true,
+  },
+  "url": "http://example.com/*not a comment*/", // "quoted // text"
+  "list": [
+    {
+      "k": 1 // one
+    }
+  ],
+  "added": true // new
+}
+`, string(data))
+
+	// The output parses back to the same document and comments
+	again, err := FromJSONC(data)
+	require.NoError(t, err)
+	assert.True(t, obj.Equal(again))
+	output, err := again.ToJSONC("  ")
+	require.NoError(t, err)
+	assert.Equal(t, string(data), string(output))
+}
+
+func TestToJSONCMultilineComments(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().Set("a", 1).Set("b", 2).
+		SetComments("a", EntryComments{Leading: []string{"first\nsecond", "third\r\nfourth"}, Trailing: "x\ny"})
+	data, err := obj.ToJSONC("  ")
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  // first
+  // second
+  // third
+  // fourth
+  "a": 1, // x y
+  "b": 2
+}
+`, string(data))
+
+	again, err := FromJSONC(data)
+	require.NoError(t, err)
+	comments, _ := again.Comments("a")
+	assert.Equal(t, EntryComments{Leading: []string{"first", "second", "third", "fourth"}, Trailing: "x y"}, comments)
+}
+
+func TestToJSONCValues(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("empty", NewObject[any]()).
+		Set("none", []any{}).
+		Set("map", map[string]any{"b": 1, "a": 2})
+	data, err := obj.ToJSONC("")
+	require.NoError(t, err)
+	assert.Equal(t, "{\n\t\"empty\": {},\n\t\"none\": [],\n\t\"map\": {\n\t\t\"a\": 2,\n\t\t\"b\": 1\n\t}\n}\n", string(data))
+
+	_, err = obj.ToJSONC("--")
+	assert.ErrorIs(t, err, ErrInvalidIndent)
+}
+
+func TestFromJSONCErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := FromJSONC([]byte(`{"a": 1 /* open`))
+	require.ErrorIs(t, err, ErrUnterminatedComment)
+
+	_, err = FromJSONC([]byte(`// list
+[1, 2]`))
+	require.ErrorIs(t, err, ErrExpectedObjectStart)
+
+	_, err = FromJSONC([]byte(`{"a": }`))
+	require.Error(t, err)
+}
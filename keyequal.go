@@ -0,0 +1,10 @@
+package orderedobject
+
+// KeyEqual sets the function used to decide whether a requested key matches a stored
+// key, for domain-specific key semantics such as trimmed or locale-aware comparison.
+// Updates through an equal key keep the stored key. Pass nil to compare keys exactly.
+// Returns the object for chaining.
+func (object *Object[V]) KeyEqual(equal func(a, b string) bool) *Object[V] {
+	object.extend().keyEqual = equal
+	return object
+}
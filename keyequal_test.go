@@ -0,0 +1,33 @@
+package orderedobject
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyEqual(t *testing.T) {
+	t.Parallel()
+
+	trimmed := func(a, b string) bool {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+
+	obj := NewObject[int]().KeyEqual(trimmed)
+	obj.Set("name ", 1)
+	obj.Set(" name", 2)
+	assert.Equal(t, []string{"name "}, obj.Keys())
+
+	value, ok := obj.Get("name")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+
+	require.NoError(t, obj.UnmarshalJSON([]byte(`{"a":1," a ":2,"b":3}`)))
+	assert.Equal(t, []string{"a", "b"}, obj.Keys())
+	assert.Equal(t, []int{2, 3}, obj.Values())
+
+	obj.KeyEqual(nil)
+	assert.False(t, obj.Has(" a"))
+}
@@ -0,0 +1,111 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+var (
+	// ErrDepthLimitExceeded is returned when decoded JSON nests deeper than the depth limit
+	ErrDepthLimitExceeded = errors.New("JSON depth limit exceeded")
+	// ErrKeyLimitExceeded is returned when decoded JSON has more keys than the key limit
+	ErrKeyLimitExceeded = errors.New("JSON key limit exceeded")
+	// ErrSizeLimitExceeded is returned when decoded JSON is larger than the size limit
+	ErrSizeLimitExceeded = errors.New("JSON size limit exceeded")
+)
+
+// DecodeLimits bounds the JSON accepted when decoding into an object, to protect
+// servers parsing untrusted input from pathological documents. Zero fields are unlimited.
+type DecodeLimits struct {
+	// MaxDepth is the maximum nesting depth of objects and arrays; the object itself is at depth 1.
+	MaxDepth int
+	// MaxKeys is the maximum number of keys, counted across all nested objects.
+	MaxKeys int
+	// MaxBytes is the maximum size of the encoded object.
+	MaxBytes int64
+}
+
+// SetDecodeLimits sets the limits enforced by UnmarshalJSON and UnmarshalJSONFrom.
+// The encoded object is checked against the limits before any value is decoded.
+// Returns the object for chaining.
+func (object *Object[V]) SetDecodeLimits(limits DecodeLimits) *Object[V] {
+	object.extend().limits = limits
+	return object
+}
+
+// FromJSONWithLimits creates an ordered object from JSON, rejecting input that exceeds
+// the limits.
+func FromJSONWithLimits[V any](data []byte, limits DecodeLimits) (*Object[V], error) {
+	obj := NewObject[V]().SetDecodeLimits(limits)
+	if err := obj.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return obj, nil
+}
+
+// enabled reports whether any limit is set.
+func (l DecodeLimits) enabled() bool {
+	return l.MaxDepth > 0 || l.MaxKeys > 0 || l.MaxBytes > 0
+}
+
+// read reads the next value from dec token by token, checking it against the limits as
+// it goes: reading stops at the first token beyond a limit, so an oversized document is
+// never buffered whole. The value is returned with its original bytes.
+func (l DecodeLimits) read(dec *jsontext.Decoder) (jsontext.Value, error) {
+	if !l.enabled() {
+		return dec.ReadValue()
+	}
+	var value []byte
+	keys := 0
+	for {
+		kind := dec.PeekKind()
+		if len(value) > 0 {
+			// Keep the whitespace, colon or comma preceding the token
+			unread := dec.UnreadBuffer()
+			value = append(value, unread[:separatorLength(unread)]...)
+		}
+		// An object member at an even position is a key
+		if parent, length := dec.StackIndex(dec.StackDepth()); parent == '{' && length%2 == 0 && kind == '"' {
+			keys++
+			if l.MaxKeys > 0 && keys > l.MaxKeys {
+				return nil, fmt.Errorf("%w: limit is %d", ErrKeyLimitExceeded, l.MaxKeys)
+			}
+		}
+		switch kind {
+		case '{', '[', '}', ']':
+			if _, err := dec.ReadToken(); err != nil {
+				return nil, err
+			}
+			value = append(value, byte(kind))
+		default:
+			raw, err := dec.ReadValue()
+			if err != nil {
+				return nil, err
+			}
+			value = append(value, raw...)
+		}
+		if l.MaxBytes > 0 && int64(len(value)) > l.MaxBytes {
+			return nil, fmt.Errorf("%w: limit is %d bytes", ErrSizeLimitExceeded, l.MaxBytes)
+		}
+		if l.MaxDepth > 0 && dec.StackDepth() > l.MaxDepth {
+			return nil, fmt.Errorf("%w: limit is %d", ErrDepthLimitExceeded, l.MaxDepth)
+		}
+		if dec.StackDepth() == 0 {
+			return value, nil
+		}
+	}
+}
+
+// separatorLength returns the length of the whitespace, colons and commas data starts with.
+func separatorLength(data []byte) int {
+	for i, c := range data {
+		switch c {
+		case ' ', '\t', '\r', '\n', ':', ',':
+		default:
+			return i
+		}
+	}
+	return len(data)
+}
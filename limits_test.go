@@ -0,0 +1,113 @@
+package orderedobject
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromJSONWithLimits(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`{"a":{"b":{"c":[1,{"d":2}]}},"e":"x"}`)
+
+	tests := []struct {
+		name   string
+		limits DecodeLimits
+		want   error
+	}{
+		{name: "unlimited", limits: DecodeLimits{}},
+		{name: "within limits", limits: DecodeLimits{MaxDepth: 5, MaxKeys: 5, MaxBytes: int64(len(input))}},
+		{name: "too deep", limits: DecodeLimits{MaxDepth: 4}, want: ErrDepthLimitExceeded},
+		{name: "too many keys", limits: DecodeLimits{MaxKeys: 4}, want: ErrKeyLimitExceeded},
+		{name: "too large", limits: DecodeLimits{MaxBytes: int64(len(input)) - 1}, want: ErrSizeLimitExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			obj, err := FromJSONWithLimits[any](input, tt.limits)
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a", "e"}, obj.Keys())
+		})
+	}
+}
+
+func TestSetDecodeLimits(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().SetDecodeLimits(DecodeLimits{MaxKeys: 3})
+	require.NoError(t, json.Unmarshal([]byte(`{"a":1,"b":{"c":2}}`), obj))
+	assert.Equal(t, []string{"a", "b"}, obj.Keys())
+
+	err := json.Unmarshal([]byte(`{"a":1,"b":{"c":2,"d":3}}`), obj)
+	assert.ErrorIs(t, err, ErrKeyLimitExceeded)
+
+	// Clones keep the limits
+	err = obj.Clone().UnmarshalJSON([]byte(`{"a":1,"b":2,"c":3,"d":4}`))
+	assert.ErrorIs(t, err, ErrKeyLimitExceeded)
+
+	// Non-object input is still rejected
+	err = obj.UnmarshalJSON([]byte(`[1]`))
+	assert.ErrorIs(t, err, ErrExpectedObjectStart)
+}
+
+func TestDecodeLimitsKeepOptions(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().SetDecodeLimits(DecodeLimits{MaxDepth: 2})
+	err := json.Unmarshal([]byte(`{"b":"1","a":"2"}`), obj, json.StringifyNumbers(true))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, obj.Values())
+}
+
+// endlessArray is a reader producing an object holding an array that never ends.
+type endlessArray struct {
+	read int64
+}
+
+func (r *endlessArray) Read(p []byte) (int, error) {
+	n := copy(p, strings.Repeat("1, ", len(p)/3+1))
+	if r.read == 0 {
+		n = copy(p, `{"a": [`)
+	}
+	r.read += int64(n)
+	return n, nil
+}
+
+func TestDecodeLimitsStreaming(t *testing.T) {
+	t.Parallel()
+
+	// Reading stops at the limit instead of buffering the whole value
+	body := &endlessArray{}
+	obj := NewObject[any]().SetDecodeLimits(DecodeLimits{MaxBytes: 4 << 10})
+	err := obj.UnmarshalJSONFrom(jsontext.NewDecoder(body))
+	require.ErrorIs(t, err, ErrSizeLimitExceeded)
+	assert.Less(t, body.read, int64(64<<10))
+
+	// Values keep their original bytes, so positions stay exact
+	input := "{\n  \"a\" : [1, 2.50],\n\t\"b\":{ \"c\" : \"\\u0041\" } }\n{}"
+	dec := jsontext.NewDecoder(strings.NewReader(input))
+	value, err := DecodeLimits{MaxKeys: 3, MaxBytes: 100}.read(dec)
+	require.NoError(t, err)
+	assert.Equal(t, input[:len(input)-3], string(value))
+	_, err = dec.ReadValue()
+	require.NoError(t, err)
+	_, err = dec.ReadValue()
+	require.ErrorIs(t, err, io.EOF)
+
+	obj = NewObject[any]().SetDecodeLimits(DecodeLimits{MaxBytes: 100}).TrackPositions(true)
+	require.NoError(t, obj.UnmarshalJSONFrom(jsontext.NewDecoder(strings.NewReader(input))))
+	meta, ok := obj.Position("b.c")
+	require.True(t, ok)
+	assert.Equal(t, EntryMeta{Offset: 28, Line: 3, Column: 8}, meta)
+}
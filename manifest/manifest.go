@@ -0,0 +1,144 @@
+// Package manifest provides helpers for common edits of package manifests such as
+// package.json and composer.json that keep the rest of the document in its original order.
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kaptinlin/orderedobject"
+)
+
+var (
+	// ErrInvalidSection is returned when a dependency section is not an object
+	ErrInvalidSection = errors.New("dependency section is not an object")
+	// ErrInvalidVersion is returned when a version is not a valid semantic version
+	ErrInvalidVersion = errors.New("invalid semantic version")
+)
+
+// Dependency sections of package.json and composer.json.
+const (
+	Dependencies         = "dependencies"
+	DevDependencies      = "devDependencies"
+	PeerDependencies     = "peerDependencies"
+	OptionalDependencies = "optionalDependencies"
+	Require              = "require"
+	RequireDev           = "require-dev"
+)
+
+// VersionKey is the key holding the manifest version.
+const VersionKey = "version"
+
+// BumpLevel selects which part of a semantic version Bump increments.
+type BumpLevel int
+
+const (
+	// Patch increments the patch version.
+	Patch BumpLevel = iota
+	// Minor increments the minor version and resets the patch version.
+	Minor
+	// Major increments the major version and resets the minor and patch versions.
+	Major
+)
+
+// AddDependency sets the version constraint of a dependency in section and keeps the
+// section sorted by name. The section is appended to the document if it does not exist;
+// every other key keeps its position.
+func AddDependency(doc *orderedobject.Object[any], section, name, constraint string) error {
+	value, ok := doc.Get(section)
+	if !ok {
+		doc.Set(section, orderedobject.NewObject[any]().Set(name, constraint))
+		return nil
+	}
+	switch deps := value.(type) {
+	case *orderedobject.Object[any]:
+		deps.Set(name, constraint)
+		deps.ApplyOrder(orderedobject.Profile{Keys: slices.Sorted(slices.Values(deps.Keys()))})
+	case map[string]any:
+		// Maps are always encoded with sorted keys
+		deps[name] = constraint
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidSection, section)
+	}
+	return nil
+}
+
+// RemoveDependency removes a dependency from section and reports whether it existed.
+func RemoveDependency(doc *orderedobject.Object[any], section, name string) bool {
+	value, ok := doc.Get(section)
+	if !ok {
+		return false
+	}
+	switch deps := value.(type) {
+	case *orderedobject.Object[any]:
+		if deps.Has(name) {
+			deps.Delete(name)
+			return true
+		}
+	case map[string]any:
+		if _, ok := deps[name]; ok {
+			delete(deps, name)
+			return true
+		}
+	}
+	return false
+}
+
+// BumpVersion increments the version of the manifest in place and returns the new version.
+// Pre-release and build metadata are dropped. A missing version is treated as 0.0.0
+// and appended to the document.
+func BumpVersion(doc *orderedobject.Object[any], level BumpLevel) (string, error) {
+	current := "0.0.0"
+	if value, ok := doc.Get(VersionKey); ok {
+		s, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("%w: %v", ErrInvalidVersion, value)
+		}
+		current = s
+	}
+	next, err := Bump(current, level)
+	if err != nil {
+		return "", err
+	}
+	doc.Set(VersionKey, next)
+	return next, nil
+}
+
+// Bump increments a semantic version such as "1.2.3" or "v1.2.3-beta.1".
+// The "v" prefix is preserved, and pre-release and build metadata are dropped.
+func Bump(version string, level BumpLevel) (string, error) {
+	prefix := ""
+	core := version
+	if strings.HasPrefix(core, "v") {
+		prefix, core = "v", core[1:]
+	}
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+		}
+		nums[i] = n
+	}
+
+	switch level {
+	case Major:
+		nums = [3]int{nums[0] + 1, 0, 0}
+	case Minor:
+		nums = [3]int{nums[0], nums[1] + 1, 0}
+	case Patch:
+		nums[2]++
+	}
+	return fmt.Sprintf("%s%d.%d.%d", prefix, nums[0], nums[1], nums[2]), nil
+}
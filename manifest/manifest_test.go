@@ -0,0 +1,81 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/orderedobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddDependency(t *testing.T) {
+	t.Parallel()
+
+	doc := orderedobject.NewObject[any]().
+		Set("name", "app").
+		Set("version", "1.0.0").
+		Set("dependencies", orderedobject.NewObject[any]().
+			Set("axios", "^1.0.0").
+			Set("react", "^18.0.0")).
+		Set("scripts", orderedobject.NewObject[any]().Set("test", "jest"))
+
+	require.NoError(t, AddDependency(doc, Dependencies, "lodash", "^4.17.21"))
+	require.NoError(t, AddDependency(doc, DevDependencies, "jest", "^29.0.0"))
+
+	data, err := doc.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"name":"app","version":"1.0.0","dependencies":{"axios":"^1.0.0","lodash":"^4.17.21","react":"^18.0.0"},"scripts":{"test":"jest"},"devDependencies":{"jest":"^29.0.0"}}`,
+		string(data))
+
+	assert.True(t, RemoveDependency(doc, Dependencies, "axios"))
+	assert.False(t, RemoveDependency(doc, Dependencies, "axios"))
+
+	doc.Set("require", "invalid")
+	assert.ErrorIs(t, AddDependency(doc, Require, "php", ">=8.1"), ErrInvalidSection)
+}
+
+func TestAddDependencyDecoded(t *testing.T) {
+	t.Parallel()
+
+	doc, err := orderedobject.FromJSON[any]([]byte(`{"name":"vendor/pkg","require":{"php":">=8.1"},"license":"MIT"}`))
+	require.NoError(t, err)
+
+	require.NoError(t, AddDependency(doc, Require, "monolog/monolog", "^3.0"))
+
+	data, err := doc.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"vendor/pkg","require":{"monolog/monolog":"^3.0","php":">=8.1"},"license":"MIT"}`, string(data))
+}
+
+func TestBumpVersion(t *testing.T) {
+	t.Parallel()
+
+	doc := orderedobject.NewObject[any]().
+		Set("name", "app").
+		Set("version", "1.2.3-beta.1").
+		Set("private", true)
+
+	version, err := BumpVersion(doc, Minor)
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0", version)
+	assert.Equal(t, []string{"name", "version", "private"}, doc.Keys())
+
+	tests := []struct {
+		version string
+		level   BumpLevel
+		want    string
+	}{
+		{"1.2.3", Patch, "1.2.4"},
+		{"v1.2.3", Major, "v2.0.0"},
+		{"0.9.9+build.5", Minor, "0.10.0"},
+	}
+	for _, tt := range tests {
+		got, err := Bump(tt.version, tt.level)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err = Bump("1.2", Patch)
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+}
@@ -0,0 +1,104 @@
+package orderedobject
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// ErrInvalidIndent is returned when an indent or indent prefix contains characters
+// other than spaces and tabs
+var ErrInvalidIndent = errors.New("indent must only contain spaces and tabs")
+
+// MarshalOptions configures the output of MarshalWith. The zero value produces the
+// same compact output as ToJSON.
+type MarshalOptions struct {
+	// EscapeHTML escapes <, > and & in strings as \u003c, \u003e and \u0026.
+	EscapeHTML bool
+	// AllowInvalidUTF8 writes invalid UTF-8 as the replacement character instead of failing.
+	AllowInvalidUTF8 bool
+	// SortKeys orders keys alphabetically at every level instead of in insertion order.
+	SortKeys bool
+	// Multiline writes one value per line, indented with tabs unless Indent is set.
+	Multiline bool
+	// Indent is the indentation for each nesting level and implies Multiline.
+	// It may only contain spaces and tabs.
+	Indent string
+	// IndentPrefix is written at the start of every line after the first and implies Multiline.
+	// It may only contain spaces and tabs.
+	IndentPrefix string
+}
+
+// MarshalWith encodes the ordered object as JSON using the given options.
+func (object *Object[V]) MarshalWith(opts MarshalOptions) ([]byte, error) {
+	for _, indent := range []string{opts.Indent, opts.IndentPrefix} {
+		if strings.Trim(indent, " \t") != "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidIndent, indent)
+		}
+	}
+	options := []json.Options{
+		jsontext.EscapeForHTML(opts.EscapeHTML),
+		jsontext.AllowInvalidUTF8(opts.AllowInvalidUTF8),
+		jsontext.Multiline(opts.Multiline),
+	}
+	if opts.Indent != "" {
+		options = append(options, jsontext.WithIndent(opts.Indent))
+	}
+	if opts.IndentPrefix != "" {
+		options = append(options, jsontext.WithIndentPrefix(opts.IndentPrefix))
+	}
+	target := object
+	if opts.SortKeys {
+		target = object.ExportSorted(true)
+	}
+	data, err := json.Marshal(target, options...)
+	return data, withPath(err, -1)
+}
+
+// AppendJSON appends the JSON encoding of the ordered object to dst and returns the
+// extended buffer, like strconv.AppendInt. Encoders are pooled and scalar values are
+// written without reflection, so reusing dst across calls does not allocate for objects
+// holding strings, booleans, nulls, ints, float64s and nested objects. On error, dst is
+// returned with its original length.
+func (object *Object[V]) AppendJSON(dst []byte) ([]byte, error) {
+	n := len(dst)
+	e := appendEncoderPool.Get().(*appendEncoder)
+	e.w.buf = dst
+	e.enc.Reset(&e.w)
+	err := object.MarshalJSONTo(&e.enc)
+	dst = e.w.buf
+	e.w.buf = nil
+	appendEncoderPool.Put(e)
+	if err != nil {
+		return dst[:n], withPath(err, -1)
+	}
+	// The encoder terminates each top-level value with a newline
+	return bytes.TrimSuffix(dst, []byte("\n")), nil
+}
+
+// appendEncoder is an encoder writing to a caller-provided buffer, reused by AppendJSON.
+type appendEncoder struct {
+	enc jsontext.Encoder
+	w   appendWriter
+}
+
+// appendEncoderPool holds the encoders reused by AppendJSON.
+var appendEncoderPool = sync.Pool{
+	New: func() any { return new(appendEncoder) },
+}
+
+// appendWriter is an io.Writer appending to a byte slice.
+type appendWriter struct {
+	buf []byte
+}
+
+// Write appends p to the buffer.
+func (w *appendWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
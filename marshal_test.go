@@ -0,0 +1,160 @@
+package orderedobject
+
+import (
+	"math"
+	"testing"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalWith(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("z", "<b>&</b>").
+		Set("a", NewObject[any]().Set("y", 1).Set("b", 2))
+
+	data, err := obj.MarshalWith(MarshalOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"z":"<b>&</b>","a":{"y":1,"b":2}}`, string(data))
+
+	data, err = obj.MarshalWith(MarshalOptions{EscapeHTML: true, SortKeys: true})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":{"b":2,"y":1},"z":"\u003cb\u003e\u0026\u003c/b\u003e"}`, string(data))
+
+	data, err = obj.MarshalWith(MarshalOptions{Indent: "  "})
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"z\": \"<b>&</b>\",\n  \"a\": {\n    \"y\": 1,\n    \"b\": 2\n  }\n}", string(data))
+
+	data, err = obj.MarshalWith(MarshalOptions{Multiline: true, IndentPrefix: "  "})
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \t\"z\": \"<b>&</b>\",\n  \t\"a\": {\n  \t\t\"y\": 1,\n  \t\t\"b\": 2\n  \t}\n  }", string(data))
+
+	_, err = obj.MarshalWith(MarshalOptions{IndentPrefix: "//"})
+	require.ErrorIs(t, err, ErrInvalidIndent)
+}
+
+func TestMarshalWithInvalidUTF8(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[string]().Set("bad", "\xff")
+
+	_, err := obj.MarshalWith(MarshalOptions{})
+	require.Error(t, err)
+
+	data, err := obj.MarshalWith(MarshalOptions{AllowInvalidUTF8: true})
+	require.NoError(t, err)
+	assert.Equal(t, "{\"bad\":\"�\"}", string(data))
+}
+
+func TestAppendJSON(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("z", "x").
+		Set("a", NewObject[any]().Set("y", 1).Set("b", []any{true, nil}))
+
+	dst := []byte("data: ")
+	dst, err := obj.AppendJSON(dst)
+	require.NoError(t, err)
+	assert.Equal(t, `data: {"z":"x","a":{"y":1,"b":[true,null]}}`, string(dst))
+
+	dst, err = obj.AppendJSON(dst[:0])
+	require.NoError(t, err)
+	assert.Equal(t, `{"z":"x","a":{"y":1,"b":[true,null]}}`, string(dst))
+
+	bad := NewObject[any]().Set("ok", 1).Set("fn", func() {})
+	prefix := []byte("prefix")
+	out, err := bad.AppendJSON(prefix)
+	require.Error(t, err)
+	assert.Equal(t, "prefix", string(out))
+}
+
+func TestAppendJSONAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are not exact with the race detector")
+	}
+	obj := NewObject[any]().
+		Set("name", "John").
+		Set("age", 30).
+		Set("score", 4.5).
+		Set("active", true).
+		Set("manager", nil).
+		Set("address", NewObject[any]().Set("city", "Paris"))
+	buf := make([]byte, 0, 256)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = obj.AppendJSON(buf[:0])
+	})
+	assert.Zero(t, allocs)
+	assert.JSONEq(t, `{"name":"John","age":30,"score":4.5,"active":true,"manager":null,"address":{"city":"Paris"}}`, string(buf))
+}
+
+func TestMarshalScalars(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().Set("int", 1).Set("int64", int64(-2)).Set("float", 0.1).Set("s", "<a>")
+
+	// Scalars written as tokens honor the options of the caller
+	data, err := json.Marshal(obj, json.StringifyNumbers(true))
+	require.NoError(t, err)
+	assert.Equal(t, `{"int":"1","int64":"-2","float":"0.1","s":"<a>"}`, string(data))
+	data, err = obj.MarshalWith(MarshalOptions{EscapeHTML: true})
+	require.NoError(t, err)
+	assert.Equal(t, `{"int":1,"int64":-2,"float":0.1,"s":"\u003ca\u003e"}`, string(data))
+
+	// Non-finite numbers still fail like other unsupported values
+	_, err = NewObject[any]().Set("nan", math.NaN()).ToJSON()
+	require.Error(t, err)
+	_, err = NewObject[any]().Set("s", "\xff").ToJSON()
+	require.Error(t, err)
+}
+
+func BenchmarkAppendJSON(b *testing.B) {
+	obj := NewObject[any]().Set("name", "John").Set("age", 30).Set("active", true)
+	buf := make([]byte, 0, 256)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, _ = obj.AppendJSON(buf[:0])
+	}
+}
+
+func TestMarshalJSONAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are not exact with the race detector")
+	}
+	obj := NewObject[any]().Set("name", "John").Set("age", 30).Set("active", true)
+
+	// The returned slice is the only allocation
+	assert.LessOrEqual(t, testing.AllocsPerRun(100, func() { _, _ = obj.MarshalJSON() }), 1.0)
+	assert.LessOrEqual(t, testing.AllocsPerRun(100, func() { _, _ = obj.ToJSON() }), 1.0)
+}
+
+func BenchmarkToJSON(b *testing.B) {
+	obj := NewObject[any]().Set("name", "John").Set("age", 30).Set("active", true)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = obj.ToJSON()
+	}
+}
+
+func TestMarshalJSONResults(t *testing.T) {
+	t.Parallel()
+
+	first, err := NewObject[any]().Set("a", 1).MarshalJSON()
+	require.NoError(t, err)
+	second, err := NewObject[any]().Set("b", "two").MarshalJSON()
+	require.NoError(t, err)
+
+	// Results are owned by the caller, so later calls do not overwrite earlier results
+	assert.Equal(t, `{"a":1}`, string(first))
+	assert.Equal(t, `{"b":"two"}`, string(second))
+
+	_, err = NewObject[any]().Set("fn", func() {}).MarshalJSON()
+	var pathErr *PathError
+	require.ErrorAs(t, err, &pathErr)
+	assert.Equal(t, "fn", pathErr.Path)
+}
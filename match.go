@@ -0,0 +1,45 @@
+package orderedobject
+
+import (
+	"path"
+	"regexp"
+)
+
+// KeysMatching returns the keys matching the glob pattern in insertion order.
+// The pattern syntax is that of path.Match, e.g. "db.*" or "log?level".
+// It returns path.ErrBadPattern if the pattern is malformed.
+func (object *Object[V]) KeysMatching(glob string) ([]string, error) {
+	if _, err := path.Match(glob, ""); err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, entry := range object.entries {
+		if ok, _ := path.Match(glob, entry.Key); ok {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys, nil
+}
+
+// KeysRegexp returns the keys matched by re in insertion order.
+func (object *Object[V]) KeysRegexp(re *regexp.Regexp) []string {
+	var keys []string
+	for _, entry := range object.entries {
+		if re.MatchString(entry.Key) {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
+
+// FilterByKeyPattern returns a new ordered object with the key-value pairs whose keys
+// are matched by re, in their relative order.
+func (object *Object[V]) FilterByKeyPattern(re *regexp.Regexp) *Object[V] {
+	result := NewObject[V]()
+	for _, entry := range object.entries {
+		if re.MatchString(entry.Key) {
+			result.entries = append(result.entries, entry)
+		}
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package orderedobject
+
+import (
+	"path"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeysMatching(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().
+		Set("db.host", 1).
+		Set("log.level", 2).
+		Set("db.port", 3).
+		Set("log.format", 4)
+
+	keys, err := obj.KeysMatching("db.*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db.host", "db.port"}, keys)
+
+	keys, err = obj.KeysMatching("cache.*")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = obj.KeysMatching("db.[")
+	require.ErrorIs(t, err, path.ErrBadPattern)
+}
+
+func TestKeysRegexp(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().
+		Set("db.host", 1).
+		Set("log.level", 2).
+		Set("db.port", 3)
+
+	re := regexp.MustCompile(`\.(host|level)$`)
+	assert.Equal(t, []string{"db.host", "log.level"}, obj.KeysRegexp(re))
+
+	filtered := obj.FilterByKeyPattern(regexp.MustCompile(`^db\.`))
+	assert.Equal(t, []string{"db.host", "db.port"}, filtered.Keys())
+	assert.Equal(t, []int{1, 3}, filtered.Values())
+	assert.Equal(t, 3, obj.Length())
+}
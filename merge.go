@@ -0,0 +1,175 @@
+package orderedobject
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// ArrayMergeStrategy controls how DeepMerge combines two arrays found at the same key.
+type ArrayMergeStrategy int
+
+const (
+	// ArrayReplace replaces the base array with the override array.
+	ArrayReplace ArrayMergeStrategy = iota
+	// ArrayAppend appends the override elements to the base elements.
+	ArrayAppend
+	// ArrayUnion appends the override elements that are not already present in the base array.
+	ArrayUnion
+)
+
+// MergeOptions configures DeepMerge.
+type MergeOptions struct {
+	// Arrays selects how arrays present on both sides are combined.
+	Arrays ArrayMergeStrategy
+}
+
+// DeepMerge recursively merges override into base and returns the result as a new object.
+// Keys keep the order of base, and keys that only exist in override are appended in
+// their override order. Nested *Object[any] and map[string]any values present on both
+// sides are merged recursively; any other value from override replaces the base value.
+// The result holds only entries, sharing no containers with the inputs and none of the
+// settings of base such as hooks or TTLs. Neither base nor override is modified.
+func DeepMerge(base, override *Object[any], opts ...MergeOptions) *Object[any] {
+	var o MergeOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return mergeObjects(base, override, o)
+}
+
+// mergeObjects merges two ordered objects into a new one, built from their entries
+// alone: hooks, aliases, TTLs and other settings of base are not carried over.
+func mergeObjects(base, override *Object[any], opts MergeOptions) *Object[any] {
+	base.expire()
+	override.expire()
+	overrides := make(map[string]any, len(override.entries))
+	for _, entry := range override.entries {
+		overrides[entry.Key] = entry.Value
+	}
+	result := NewObject[any](len(base.entries) + len(override.entries))
+	inBase := make(map[string]bool, len(base.entries))
+	for _, entry := range base.entries {
+		inBase[entry.Key] = true
+		value := entry.Value
+		if o, ok := overrides[entry.Key]; ok {
+			value = mergeValues(value, o, opts)
+		} else {
+			value = cloneMergeValue(value)
+		}
+		result.entries = append(result.entries, Entry[any]{Key: entry.Key, Value: value})
+	}
+	for _, entry := range override.entries {
+		if !inBase[entry.Key] {
+			result.entries = append(result.entries, Entry[any]{Key: entry.Key, Value: cloneMergeValue(entry.Value)})
+		}
+	}
+	return result
+}
+
+// mergeValues merges two values found at the same key. A nil *Object[any] on
+// either side is a plain value, replaced by or replacing the other side.
+func mergeValues(base, override any, opts MergeOptions) any {
+	if isNilObject(base) || isNilObject(override) {
+		return cloneMergeValue(override)
+	}
+	switch b := base.(type) {
+	case *Object[any]:
+		switch o := override.(type) {
+		case *Object[any]:
+			return mergeObjects(b, o, opts)
+		case map[string]any:
+			return mergeObjects(b, sortedObjectFromMap(o), opts)
+		}
+	case map[string]any:
+		switch o := override.(type) {
+		case *Object[any]:
+			return mergeObjects(sortedObjectFromMap(b), o, opts)
+		case map[string]any:
+			merged := make(map[string]any, len(b)+len(o))
+			for k, v := range b {
+				if ov, ok := o[k]; ok {
+					merged[k] = mergeValues(v, ov, opts)
+				} else {
+					merged[k] = cloneMergeValue(v)
+				}
+			}
+			for k, v := range o {
+				if _, ok := b[k]; !ok {
+					merged[k] = cloneMergeValue(v)
+				}
+			}
+			return merged
+		}
+	case []any:
+		if o, ok := override.([]any); ok {
+			return mergeArrays(b, o, opts.Arrays)
+		}
+	}
+	return cloneMergeValue(override)
+}
+
+// isNilObject reports whether value is a nil *Object[any].
+func isNilObject(value any) bool {
+	obj, ok := value.(*Object[any])
+	return ok && obj == nil
+}
+
+// mergeArrays combines two arrays according to the strategy.
+func mergeArrays(base, override []any, strategy ArrayMergeStrategy) []any {
+	switch strategy {
+	case ArrayAppend:
+		return cloneMergeValue(slices.Concat(base, override)).([]any)
+	case ArrayUnion:
+		result := slices.Clone(base)
+		for _, v := range override {
+			if !slices.ContainsFunc(result, func(existing any) bool {
+				return reflect.DeepEqual(existing, v)
+			}) {
+				result = append(result, v)
+			}
+		}
+		return cloneMergeValue(result).([]any)
+	}
+	return cloneMergeValue(override).([]any)
+}
+
+// cloneMergeValue copies a value present on one side of a merge, so the result shares
+// no objects, maps or slices with the inputs. Objects are copied from their entries.
+func cloneMergeValue(value any) any {
+	switch v := value.(type) {
+	case *Object[any]:
+		if v == nil {
+			return v
+		}
+		return mergeObjects(v, NewObject[any](), MergeOptions{})
+	case map[string]any:
+		if v == nil {
+			return v
+		}
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[k] = cloneMergeValue(item)
+		}
+		return m
+	case []any:
+		if v == nil {
+			return v
+		}
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = cloneMergeValue(item)
+		}
+		return items
+	}
+	return value
+}
+
+// sortedObjectFromMap creates an ordered object from a map with keys in sorted order.
+func sortedObjectFromMap(m map[string]any) *Object[any] {
+	obj := NewObject[any](len(m))
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		obj.Set(k, m[k])
+	}
+	return obj
+}
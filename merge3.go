@@ -0,0 +1,110 @@
+package orderedobject
+
+// MergeConflict describes a key that was changed differently on both sides of a
+// three-way merge. A side that removed the key reports a nil value and false presence.
+type MergeConflict struct {
+	// Path is the key path from the root object to the conflicting key.
+	Path []string
+
+	Base   any
+	Ours   any
+	Theirs any
+
+	InBase   bool
+	InOurs   bool
+	InTheirs bool
+}
+
+// Merge3 performs a three-way merge of ours and theirs, which both descend from base,
+// and returns the merged object together with the conflicts it found.
+// Keys keep the order of ours, and keys added only by theirs are inserted after the
+// key that precedes them in theirs. Nested *Object[any] values changed on both sides
+// are merged recursively. When both sides change the same key differently, the value
+// from ours is kept and the key is reported as a conflict.
+// None of the inputs are modified.
+func Merge3(base, ours, theirs *Object[any]) (*Object[any], []MergeConflict) {
+	var conflicts []MergeConflict
+	merged := merge3Objects(nil, base, ours, theirs, &conflicts)
+	return merged, conflicts
+}
+
+// merge3Objects merges three versions of an ordered object into a new one.
+func merge3Objects(path []string, base, ours, theirs *Object[any], conflicts *[]MergeConflict) *Object[any] {
+	result := NewObject[any](ours.Length())
+	for _, entry := range ours.entries {
+		b, inBase := base.Get(entry.Key)
+		t, inTheirs := theirs.Get(entry.Key)
+		value, keep := merge3Value(appendPath(path, entry.Key),
+			b, inBase, entry.Value, true, t, inTheirs, conflicts)
+		if keep {
+			result.entries = append(result.entries, Entry[any]{Key: entry.Key, Value: value})
+		}
+	}
+
+	prev := ""
+	for _, entry := range theirs.entries {
+		key := entry.Key
+		if ours.Has(key) {
+			prev = key
+			continue
+		}
+		b, inBase := base.Get(key)
+		value, keep := merge3Value(appendPath(path, key),
+			b, inBase, nil, false, entry.Value, true, conflicts)
+		if !keep {
+			continue
+		}
+		idx := result.Length()
+		if prev != "" {
+			if i := result.findKeyIndex(prev); i >= 0 {
+				idx = i + 1
+			}
+		}
+		result.insertAt(idx, Entry[any]{Key: key, Value: value})
+		prev = key
+	}
+	return result
+}
+
+// merge3Value merges three versions of the value at a single key and reports whether
+// the key is present in the merged result.
+func merge3Value(path []string, base any, inBase bool, ours any, inOurs bool,
+	theirs any, inTheirs bool, conflicts *[]MergeConflict) (any, bool) {
+	switch {
+	case inOurs == inTheirs && equalValues(ours, theirs, false):
+		return ours, inOurs
+	case inOurs == inBase && equalValues(ours, base, false):
+		return theirs, inTheirs
+	case inTheirs == inBase && equalValues(theirs, base, false):
+		return ours, inOurs
+	}
+
+	// Nil objects on our or their side conflict like scalars; a nil base is empty
+	if o, ok := ours.(*Object[any]); ok && o != nil {
+		if t, ok := theirs.(*Object[any]); ok && t != nil {
+			b, ok := base.(*Object[any])
+			if !ok || b == nil {
+				b = NewObject[any]()
+			}
+			return merge3Objects(path, b, o, t, conflicts), true
+		}
+	}
+
+	*conflicts = append(*conflicts, MergeConflict{
+		Path:     path,
+		Base:     base,
+		Ours:     ours,
+		Theirs:   theirs,
+		InBase:   inBase,
+		InOurs:   inOurs,
+		InTheirs: inTheirs,
+	})
+	return ours, inOurs
+}
+
+// appendPath returns a new path with key appended, leaving path untouched.
+func appendPath(path []string, key string) []string {
+	result := make([]string, len(path), len(path)+1)
+	copy(result, path)
+	return append(result, key)
+}
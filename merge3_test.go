@@ -0,0 +1,123 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge3(t *testing.T) {
+	t.Parallel()
+
+	base := NewObject[any]().
+		Set("name", "app").
+		Set("version", "1.0.0").
+		Set("server", NewObject[any]().
+			Set("host", "localhost").
+			Set("port", 8080)).
+		Set("debug", false)
+
+	ours := NewObject[any]().
+		Set("name", "app").
+		Set("version", "1.1.0").
+		Set("server", NewObject[any]().
+			Set("host", "localhost").
+			Set("port", 9090)).
+		Set("debug", false)
+
+	theirs := NewObject[any]().
+		Set("name", "app").
+		Set("license", "MIT").
+		Set("version", "1.0.0").
+		Set("server", NewObject[any]().
+			Set("host", "0.0.0.0").
+			Set("port", 8080))
+
+	merged, conflicts := Merge3(base, ours, theirs)
+	assert.Empty(t, conflicts)
+
+	data, err := merged.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"name":"app","license":"MIT","version":"1.1.0","server":{"host":"0.0.0.0","port":9090}}`,
+		string(data))
+
+	// Inputs are left untouched
+	assert.Equal(t, []string{"name", "version", "server", "debug"}, ours.Keys())
+}
+
+func TestMerge3Conflicts(t *testing.T) {
+	t.Parallel()
+
+	base := NewObject[any]().
+		Set("server", NewObject[any]().Set("port", 8080)).
+		Set("mode", "dev").
+		Set("level", "info")
+
+	ours := NewObject[any]().
+		Set("server", NewObject[any]().Set("port", 9090)).
+		Set("mode", "prod")
+
+	theirs := NewObject[any]().
+		Set("server", NewObject[any]().Set("port", 7070)).
+		Set("level", "debug")
+
+	merged, conflicts := Merge3(base, ours, theirs)
+
+	data, err := merged.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"server":{"port":9090},"mode":"prod"}`, string(data))
+
+	require.Len(t, conflicts, 3)
+	assert.Equal(t, MergeConflict{
+		Path: []string{"server", "port"}, Base: 8080, Ours: 9090, Theirs: 7070,
+		InBase: true, InOurs: true, InTheirs: true,
+	}, conflicts[0])
+	assert.Equal(t, MergeConflict{
+		Path: []string{"mode"}, Base: "dev", Ours: "prod",
+		InBase: true, InOurs: true,
+	}, conflicts[1])
+	assert.Equal(t, MergeConflict{
+		Path: []string{"level"}, Base: "info", Theirs: "debug",
+		InBase: true, InTheirs: true,
+	}, conflicts[2])
+}
+
+func TestMerge3BothAdded(t *testing.T) {
+	t.Parallel()
+
+	base := NewObject[any]()
+	ours := NewObject[any]().Set("a", 1).Set("b", 2)
+	theirs := NewObject[any]().Set("a", 1).Set("b", 3)
+
+	merged, conflicts := Merge3(base, ours, theirs)
+	assert.Equal(t, []any{1, 2}, merged.Values())
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, []string{"b"}, conflicts[0].Path)
+	assert.False(t, conflicts[0].InBase)
+}
+
+func TestMerge3NilObjects(t *testing.T) {
+	t.Parallel()
+
+	var null *Object[any]
+	base := NewObject[any]().Set("a", null).Set("b", NewObject[any]().Set("x", 1)).Set("c", null)
+	ours := NewObject[any]().Set("a", NewObject[any]().Set("x", 1)).Set("b", null).Set("c", NewObject[any]().Set("x", 1))
+	theirs := NewObject[any]().Set("a", NewObject[any]().Set("y", 2)).Set("b", NewObject[any]().Set("x", 2)).Set("c", NewObject[any]().Set("y", 2))
+
+	var merged *Object[any]
+	var conflicts []MergeConflict
+	require.NotPanics(t, func() { merged, conflicts = Merge3(base, ours, theirs) })
+
+	assert.Equal(t, []string{"a", "b", "c"}, merged.Keys())
+	for _, key := range []string{"a", "c"} {
+		obj, _ := GetAs[*Object[any]](merged, key)
+		assert.True(t, obj.Equal(NewObject[any]().Set("x", 1).Set("y", 2)), key)
+	}
+	b, _ := merged.Get("b")
+	assert.Nil(t, b)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, []string{"b"}, conflicts[0].Path)
+	assert.Nil(t, conflicts[0].Ours)
+}
@@ -0,0 +1,141 @@
+package orderedobject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepMerge(t *testing.T) {
+	t.Parallel()
+
+	defaults := NewObject[any]().
+		Set("name", "app").
+		Set("server", NewObject[any]().
+			Set("host", "localhost").
+			Set("port", 8080)).
+		Set("tags", []any{"a", "b"})
+
+	overrides := NewObject[any]().
+		Set("server", NewObject[any]().
+			Set("port", 9090).
+			Set("tls", true)).
+		Set("debug", true)
+
+	merged := DeepMerge(defaults, overrides)
+
+	data, err := merged.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"name":"app","server":{"host":"localhost","port":9090,"tls":true},"tags":["a","b"],"debug":true}`,
+		string(data))
+
+	// Inputs are left untouched
+	server, _ := defaults.Get("server")
+	port, _ := server.(*Object[any]).Get("port")
+	assert.Equal(t, 8080, port)
+	assert.False(t, defaults.Has("debug"))
+}
+
+func TestDeepMergeMaps(t *testing.T) {
+	t.Parallel()
+
+	base := NewObject[any]().
+		Set("db", map[string]any{"user": "root", "pool": map[string]any{"min": 1, "max": 5}})
+	override := NewObject[any]().
+		Set("db", map[string]any{"pool": map[string]any{"max": 10}})
+
+	merged := DeepMerge(base, override)
+	db, _ := merged.Get("db")
+	assert.Equal(t, map[string]any{"user": "root", "pool": map[string]any{"min": 1, "max": 10}}, db)
+
+	// Mixing a map with an ordered object yields an ordered object
+	override.Set("db", NewObject[any]().Set("user", "admin"))
+	merged = DeepMerge(base, override)
+	data, err := merged.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"db":{"pool":{"max":5,"min":1},"user":"admin"}}`, string(data))
+}
+
+func TestDeepMergeArrays(t *testing.T) {
+	t.Parallel()
+
+	base := NewObject[any]().Set("list", []any{1, 2})
+	override := NewObject[any]().Set("list", []any{2, 3})
+
+	testCases := []struct {
+		name     string
+		strategy ArrayMergeStrategy
+		expected []any
+	}{
+		{name: "Replace", strategy: ArrayReplace, expected: []any{2, 3}},
+		{name: "Append", strategy: ArrayAppend, expected: []any{1, 2, 2, 3}},
+		{name: "Union", strategy: ArrayUnion, expected: []any{1, 2, 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged := DeepMerge(base, override, MergeOptions{Arrays: tc.strategy})
+			list, _ := merged.Get("list")
+			assert.Equal(t, tc.expected, list)
+		})
+	}
+}
+
+func TestDeepMergeEntriesOnly(t *testing.T) {
+	t.Parallel()
+
+	var warnings []string
+	nested := NewObject[any]().Set("list", []any{NewObject[any]().Set("a", 1)})
+	base := NewObject[any]().
+		Set("old", 1).
+		Set("shared", NewObject[any]().Set("x", 1)).
+		Set("nested", nested).
+		SetWithTTL("session", "s", time.Hour).
+		MarkDeprecated("old", "use new").
+		OnDeprecated(func(warning DeprecationWarning) { warnings = append(warnings, warning.Key) }).
+		RegisterValueHook(ValueHook{Encode: func(string, any) (any, error) { return "hooked", nil }})
+	only := map[string]any{"m": []any{1}}
+	override := NewObject[any]().Set("shared", NewObject[any]().Set("y", 2)).Set("only", only)
+
+	merged := DeepMerge(base, override)
+	assert.Nil(t, merged.ext)
+	assert.Empty(t, warnings)
+	data, err := merged.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"old":1,"shared":{"x":1,"y":2},"nested":{"list":[{"a":1}]},"session":"s","only":{"m":[1]}}`, string(data))
+
+	// Values from one side are copies
+	copied, _ := GetAs[*Object[any]](merged, "nested")
+	assert.NotSame(t, nested, copied)
+	list, _ := copied.Get("list")
+	list.([]any)[0].(*Object[any]).Set("a", 2)
+	inner, _ := nested.GetPath("list.0.a")
+	assert.Equal(t, 1, inner)
+	m, _ := GetAs[map[string]any](merged, "only")
+	m["m"].([]any)[0] = 2
+	assert.Equal(t, 1, only["m"].([]any)[0])
+}
+
+func TestDeepMergeNilObjects(t *testing.T) {
+	t.Parallel()
+
+	var null *Object[any]
+	nested := NewObject[any]().Set("x", 1)
+	base := NewObject[any]().Set("a", null).Set("b", null).Set("c", nested).Set("d", map[string]any{"x": 1})
+	override := NewObject[any]().Set("a", null).Set("b", nested).Set("c", null).Set("d", null)
+
+	var merged *Object[any]
+	require.NotPanics(t, func() { merged = DeepMerge(base, override) })
+	a, _ := merged.Get("a")
+	assert.Nil(t, a)
+	b, _ := GetAs[*Object[any]](merged, "b")
+	assert.True(t, b.Equal(nested))
+	assert.NotSame(t, nested, b)
+	c, _ := GetAs[*Object[any]](merged, "c")
+	assert.Nil(t, c)
+	d, _ := GetAs[*Object[any]](merged, "d")
+	assert.Nil(t, d)
+}
@@ -0,0 +1,40 @@
+package orderedobject
+
+import "fmt"
+
+// MustGet is like Get but panics if the key does not exist.
+// It is intended for tests and initialization where a missing key is a bug.
+func (object *Object[V]) MustGet(key string) V {
+	value, ok := object.Get(key)
+	if !ok {
+		panic(fmt.Errorf("%w: %q", ErrKeyNotFound, key))
+	}
+	return value
+}
+
+// MustFromJSON is like FromJSON but panics if the JSON cannot be decoded.
+func MustFromJSON[V any](data []byte) *Object[V] {
+	obj, err := FromJSON[V](data)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// MustFromJSONDeep is like FromJSONDeep but panics if the JSON cannot be decoded.
+func MustFromJSONDeep(data []byte) *Object[any] {
+	obj, err := FromJSONDeep(data)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// MustToJSON is like ToJSON but panics if the object cannot be encoded.
+func (object *Object[V]) MustToJSON() []byte {
+	data, err := object.ToJSON()
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -0,0 +1,44 @@
+package orderedobject
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMustGet(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("a", 1)
+	assert.Equal(t, 1, obj.MustGet("a"))
+
+	defer func() {
+		err, ok := recover().(error)
+		assert.True(t, ok)
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+	}()
+	obj.MustGet("missing")
+}
+
+func TestMustFromJSON(t *testing.T) {
+	t.Parallel()
+
+	obj := MustFromJSON[int]([]byte(`{"b":2,"a":1}`))
+	assert.Equal(t, []string{"b", "a"}, obj.Keys())
+
+	deep := MustFromJSONDeep([]byte(`{"n":{"y":1,"x":2}}`))
+	nested, _ := GetAs[*Object[any]](deep, "n")
+	assert.Equal(t, []string{"y", "x"}, nested.Keys())
+
+	assert.Panics(t, func() { MustFromJSON[int]([]byte(`{"a":"x"}`)) })
+	assert.Panics(t, func() { MustFromJSONDeep([]byte(`[]`)) })
+}
+
+func TestMustToJSON(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `{"a":1}`, string(NewObject[int]().Set("a", 1).MustToJSON()))
+	assert.Panics(t, func() { NewObject[float64]().Set("a", math.NaN()).MustToJSON() })
+}
@@ -0,0 +1,75 @@
+package orderedobject
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// FromMapDeep creates an ordered object from a map, converting every nested
+// map[string]any, including maps inside []any values, to an *Object[any].
+// When sorted is true, keys are ordered alphabetically at every level;
+// otherwise they follow the map iteration order.
+func FromMapDeep(m map[string]any, sorted bool) *Object[any] {
+	obj := NewObject[any](len(m))
+	keys := maps.Keys(m)
+	if sorted {
+		keys = slices.Values(slices.Sorted(keys))
+	}
+	for k := range keys {
+		obj.Set(k, Normalize(m[k], sorted))
+	}
+	return obj
+}
+
+// FromJSONDeep parses a JSON object into an ordered object, decoding nested objects
+// as *Object[any] so the key order is preserved at every level.
+func FromJSONDeep(data []byte) (*Object[any], error) {
+	dec := jsontext.NewDecoder(bytes.NewReader(data))
+	value, err := decodeOrderedFrom(dec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", withPath(err, dec.InputOffset()))
+	}
+	obj, ok := value.(*Object[any])
+	if !ok {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w, got %T", ErrExpectedObjectStart, value)
+	}
+	return obj, nil
+}
+
+// Normalize returns a copy of value in which every map[string]any is replaced
+// with an *Object[any], descending into maps, []any values and existing objects.
+// It adopts trees produced by other decoders into ordered objects.
+// Other values are returned unchanged.
+func Normalize(value any, sorted bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if v == nil {
+			return v
+		}
+		return FromMapDeep(v, sorted)
+	case *Object[any]:
+		if v == nil {
+			return v
+		}
+		obj := v.Clone()
+		for i, entry := range obj.entries {
+			obj.entries[i].Value = Normalize(entry.Value, sorted)
+		}
+		obj.reindex()
+		return obj
+	case []any:
+		if v == nil {
+			return v
+		}
+		s := make([]any, len(v))
+		for i, item := range v {
+			s[i] = Normalize(item, sorted)
+		}
+		return s
+	}
+	return value
+}
@@ -0,0 +1,110 @@
+package orderedobject
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromMapDeep(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"port": 8080,
+			"host": "localhost",
+		},
+		"routes": []any{map[string]any{"path": "/", "method": "GET"}},
+	}
+
+	obj := FromMapDeep(m, true)
+	assert.Equal(t, []string{"name", "routes", "server"}, obj.Keys())
+
+	server, _ := obj.Get("server")
+	require.IsType(t, &Object[any]{}, server)
+	assert.Equal(t, []string{"host", "port"}, server.(*Object[any]).Keys())
+
+	routes, _ := obj.Get("routes")
+	route := routes.([]any)[0]
+	require.IsType(t, &Object[any]{}, route)
+	assert.Equal(t, []string{"method", "path"}, route.(*Object[any]).Keys())
+
+	unsorted := FromMapDeep(m, false)
+	assert.ElementsMatch(t, []string{"name", "routes", "server"}, unsorted.Keys())
+	assert.True(t, obj.EqualUnordered(unsorted))
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("z", map[string]any{"b": 1, "a": 2}).
+		Set("a", 1)
+
+	normalized := Normalize(obj, true).(*Object[any])
+	assert.Equal(t, []string{"z", "a"}, normalized.Keys())
+	z, _ := normalized.Get("z")
+	assert.Equal(t, []string{"a", "b"}, z.(*Object[any]).Keys())
+
+	// The input is left untouched
+	original, _ := obj.Get("z")
+	assert.IsType(t, map[string]any{}, original)
+
+	assert.Equal(t, 42, Normalize(42, true))
+}
+
+func TestFromJSONDeep(t *testing.T) {
+	t.Parallel()
+
+	obj, err := FromJSONDeep([]byte(`{"z":{"y":1,"x":[{"b":2,"a":3}]},"a":true}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"z", "a"}, obj.Keys())
+
+	z, _ := obj.Get("z")
+	nested, ok := z.(*Object[any])
+	require.True(t, ok)
+	assert.Equal(t, []string{"y", "x"}, nested.Keys())
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"z":{"y":1,"x":[{"b":2,"a":3}]},"a":true}`, string(data))
+
+	_, err = FromJSONDeep([]byte(`[1,2]`))
+	require.ErrorIs(t, err, ErrExpectedObjectStart)
+	_, err = FromJSONDeep([]byte(`{"a":`))
+	require.Error(t, err)
+}
+
+func TestDecodeOrderedMembers(t *testing.T) {
+	t.Parallel()
+
+	dec := jsontext.NewDecoder(strings.NewReader(`{"a":1,"b":{"x":1,"x":2},"a":3}`), jsontext.AllowDuplicateNames(true))
+	value, err := decodeOrderedFrom(dec)
+	require.NoError(t, err)
+	obj := value.(*Object[any])
+	assert.Equal(t, []string{"a", "b"}, obj.Keys())
+	a, _ := obj.Get("a")
+	assert.Equal(t, 3.0, a)
+	x, _ := obj.GetPath("b.x")
+	assert.Equal(t, 2.0, x)
+
+	var buf strings.Builder
+	buf.WriteByte('{')
+	for i := range 50000 {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `"k%d":%d`, i, i)
+	}
+	buf.WriteByte('}')
+	large, err := FromJSONDeep([]byte(buf.String()))
+	require.NoError(t, err)
+	assert.Equal(t, 50000, large.Length())
+	last, _ := large.Get("k49999")
+	assert.Equal(t, 49999.0, last)
+}
@@ -0,0 +1,32 @@
+package orderedobject
+
+import "golang.org/x/text/unicode/norm"
+
+// NFC normalizes a key to Unicode Normalization Form C. It can be passed to NormalizeKeys.
+func NFC(key string) string {
+	return norm.NFC.String(key)
+}
+
+// NormalizeKeys sets the function applied to keys on Set, on lookup and when decoding,
+// so visually identical keys with different code-point sequences map to the same entry.
+// Existing keys are normalized immediately; entries that collide are merged into the
+// first one, keeping the last value. Pass nil to stop normalizing keys.
+// Returns the object for chaining.
+func (object *Object[V]) NormalizeKeys(normalize func(key string) string) *Object[V] {
+	object.extend().normalizeKey = normalize
+	if normalize == nil {
+		return object
+	}
+	entries := object.entries
+	object.entries = make([]Entry[V], 0, len(entries))
+	for _, entry := range entries {
+		key := normalize(entry.Key)
+		if idx := object.indexOf(key); idx >= 0 {
+			object.entries[idx].Value = entry.Value
+		} else {
+			object.entries = append(object.entries, Entry[V]{Key: key, Value: entry.Value})
+		}
+	}
+	object.reindex()
+	return object
+}
@@ -0,0 +1,50 @@
+package orderedobject
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeKeys(t *testing.T) {
+	t.Parallel()
+
+	composed := "caf\u00e9"
+	decomposed := "cafe\u0301"
+
+	obj := NewObject[int]().NormalizeKeys(NFC)
+	obj.Set(composed, 1)
+	obj.Set(decomposed, 2)
+	assert.Equal(t, 1, obj.Length())
+
+	value, ok := obj.Get(decomposed)
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+
+	obj.Delete(decomposed)
+	assert.Equal(t, 0, obj.Length())
+
+	require.NoError(t, obj.UnmarshalJSON([]byte(`{"`+composed+`":1,"`+decomposed+`":2}`)))
+	assert.Equal(t, []string{composed}, obj.Keys())
+	value, _ = obj.Get(composed)
+	assert.Equal(t, 2, value)
+}
+
+func TestNormalizeKeysExisting(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().
+		Set(" Name", 1).
+		Set("age", 2).
+		Set("NAME ", 3).
+		NormalizeKeys(func(key string) string { return strings.ToLower(strings.TrimSpace(key)) })
+
+	assert.Equal(t, []string{"name", "age"}, obj.Keys())
+	assert.Equal(t, []int{3, 2}, obj.Values())
+	assert.True(t, obj.Has("  NaMe"))
+
+	obj.NormalizeKeys(nil)
+	assert.False(t, obj.Has("NAME"))
+}
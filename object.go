@@ -4,10 +4,15 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"math"
+	"regexp"
 	"slices"
+	"time"
 
-	json "github.com/go-json-experiment/json"
-	"github.com/go-json-experiment/json/jsontext"
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
 )
 
 var (
@@ -17,6 +22,12 @@ var (
 	ErrExpectedStringKey = errors.New("expected string key")
 )
 
+// Object implements the json v2 MarshalerTo and UnmarshalerFrom interfaces.
+var (
+	_ json.MarshalerTo     = (*Object[any])(nil)
+	_ json.UnmarshalerFrom = (*Object[any])(nil)
+)
+
 // OrderedMarshaler is an interface for objects that can marshal themselves to JSON
 // while preserving key order.
 type OrderedMarshaler interface {
@@ -32,6 +43,103 @@ type Entry[V any] struct {
 // Object is an ordered JSON object that preserves insertion order.
 type Object[V any] struct {
 	entries []Entry[V]
+	// ext is nil until an opt-in feature is used, so plain objects stay small
+	// and their lookups skip the feature checks.
+	ext *objectExt[V]
+}
+
+// objectExt holds the state of the opt-in features of an object.
+type objectExt[V any] struct {
+	deleted []deletedEntry[V]
+
+	aliases      map[string]string
+	emitAliases  bool
+	caseFallback bool
+	normalizeKey func(key string) string
+	keyEqual     func(a, b string) bool
+	omitEmpty    bool
+	redact       []*regexp.Regexp
+	hooks        []ValueHook
+
+	deprecated   map[string]string
+	onDeprecated func(warning DeprecationWarning)
+
+	traceLabel string
+	onLookup   func(event LookupEvent)
+
+	defaults     map[string]V
+	descriptions map[string]string
+
+	limits         DecodeLimits
+	trackPositions bool
+	positions      map[string]EntryMeta
+
+	comments    map[string]EntryComments
+	endComments []string
+
+	subscribers    []subscriber
+	lastSubscriber int
+
+	snapshots []snapshot[V]
+
+	strict        StrictOptions
+	collectErrors bool
+	onInvalid     func(key string, raw jsontext.Value, err error)
+	types         *TypedDecoder
+
+	indexes map[string]*valueIndex[V]
+
+	version uint64
+	changes map[string]keyChange
+
+	expires    map[string]time.Time
+	nextExpiry time.Time
+	clock      func() time.Time
+}
+
+// extend returns the opt-in state of the object, allocating it on first use.
+func (object *Object[V]) extend() *objectExt[V] {
+	if object.ext == nil {
+		object.ext = &objectExt[V]{}
+	}
+	return object.ext
+}
+
+// clone returns a copy of the opt-in state for a clone of the object.
+// Subscribers are not copied, and indexes are rebuilt on the clone by the caller.
+func (ext *objectExt[V]) clone() *objectExt[V] {
+	return &objectExt[V]{
+		deleted:        slices.Clone(ext.deleted),
+		aliases:        maps.Clone(ext.aliases),
+		emitAliases:    ext.emitAliases,
+		caseFallback:   ext.caseFallback,
+		normalizeKey:   ext.normalizeKey,
+		keyEqual:       ext.keyEqual,
+		omitEmpty:      ext.omitEmpty,
+		redact:         ext.redact,
+		hooks:          slices.Clone(ext.hooks),
+		deprecated:     maps.Clone(ext.deprecated),
+		onDeprecated:   ext.onDeprecated,
+		traceLabel:     ext.traceLabel,
+		onLookup:       ext.onLookup,
+		defaults:       maps.Clone(ext.defaults),
+		descriptions:   maps.Clone(ext.descriptions),
+		limits:         ext.limits,
+		trackPositions: ext.trackPositions,
+		positions:      maps.Clone(ext.positions),
+		comments:       maps.Clone(ext.comments),
+		endComments:    slices.Clone(ext.endComments),
+		snapshots:      slices.Clone(ext.snapshots),
+		strict:         ext.strict,
+		collectErrors:  ext.collectErrors,
+		onInvalid:      ext.onInvalid,
+		types:          ext.types,
+		version:        ext.version,
+		changes:        maps.Clone(ext.changes),
+		expires:        maps.Clone(ext.expires),
+		nextExpiry:     ext.nextExpiry,
+		clock:          ext.clock,
+	}
 }
 
 // NewObject returns an ordered object with optional pre-allocated capacity.
@@ -68,23 +176,108 @@ func FromJSON[V any](data []byte) (*Object[V], error) {
 
 // findKeyIndex returns the index of the key in the entries slice, or -1 if not found.
 func (object *Object[V]) findKeyIndex(key string) int {
-	for i, entry := range object.entries {
-		if entry.Key == key {
+	if object.ext != nil {
+		return object.findKeyIndexExt(key)
+	}
+	return indexOfKey(object.entries, key)
+}
+
+// findKeyIndexExt is findKeyIndex for objects using opt-in features: it expires keys
+// and honors aliases, key normalization, case fallback and KeyEqual.
+func (object *Object[V]) findKeyIndexExt(key string) int {
+	object.expire()
+	if object.ext.aliases != nil || object.ext.normalizeKey != nil {
+		key = object.resolveKey(key)
+	}
+	idx := object.indexOf(key)
+	if idx < 0 && object.ext.caseFallback {
+		idx = object.indexOfFolded(key)
+	}
+	return idx
+}
+
+// indexOf returns the index of the key in the entries slice, or -1 if not found.
+// Keys are compared exactly unless KeyEqual is set.
+func (object *Object[V]) indexOf(key string) int {
+	if object.ext != nil && object.ext.keyEqual != nil {
+		for i, entry := range object.entries {
+			if object.ext.keyEqual(entry.Key, key) {
+				return i
+			}
+		}
+		return -1
+	}
+	return indexOfKey(object.entries, key)
+}
+
+// indexOfKey returns the index of the entry with exactly key, or -1 if not found.
+// Keys of the same length often share a prefix or a suffix, so the first and the
+// last two bytes are compared before the whole key, which avoids most calls to the
+// runtime string comparison.
+func indexOfKey[V any](entries []Entry[V], key string) int {
+	n := len(key)
+	var first, prev, last byte
+	if n >= 2 {
+		first, prev, last = key[0], key[n-2], key[n-1]
+	}
+	for i := range entries {
+		k := entries[i].Key
+		if len(k) == n && (n < 2 || k[n-1] == last && k[n-2] == prev && k[0] == first) && k == key {
 			return i
 		}
 	}
 	return -1
 }
 
+// insertAt inserts an entry at the given index of the entries slice.
+func (object *Object[V]) insertAt(idx int, entry Entry[V]) {
+	object.forgetExpiry(entry.Key)
+	object.entries = slices.Insert(object.entries, idx, entry)
+	var zero V
+	object.notifySet(entry.Key, entry.Value, zero, false)
+}
+
+// removeIndex removes the entry at the given index of the entries slice.
+func (object *Object[V]) removeIndex(idx int) {
+	entry := object.entries[idx]
+	object.entries = slices.Delete(object.entries, idx, idx+1)
+	object.forgetExpiry(entry.Key)
+	object.notifyDelete(entry)
+}
+
 // Set sets the value for a key in the ordered object.
 // If the key already exists, its value is updated.
 // Otherwise, the key-value pair is appended to the end.
 // Returns the object for chaining.
 func (object *Object[V]) Set(key string, value V) *Object[V] {
+	if object.ext != nil {
+		return object.setExt(key, value)
+	}
+	if idx := indexOfKey(object.entries, key); idx >= 0 {
+		object.entries[idx].Value = value
+	} else {
+		object.entries = append(object.entries, Entry[V]{Key: key, Value: value})
+	}
+	return object
+}
+
+// setExt is Set for objects using opt-in features, which resolves the key, clears its
+// expiry and soft-deleted entry, and notifies observers.
+func (object *Object[V]) setExt(key string, value V) *Object[V] {
+	if object.ext.aliases != nil || object.ext.normalizeKey != nil {
+		key = object.resolveKey(key)
+	}
 	if idx := object.findKeyIndex(key); idx >= 0 {
+		object.forgetExpiry(object.entries[idx].Key)
+		old := object.entries[idx].Value
 		object.entries[idx].Value = value
+		object.notifySet(object.entries[idx].Key, value, old, true)
 	} else {
+		object.forgetDeleted(key)
+		object.forgetExpiry(key)
 		object.entries = append(object.entries, Entry[V]{Key: key, Value: value})
+		var zero V
+		object.notifySet(key, value, zero, false)
 	}
 	return object
 }
@@ -92,9 +285,25 @@ func (object *Object[V]) Set(key string, value V) *Object[V] {
 // Get returns the value for a key and whether the key exists.
 // If the key does not exist, it returns the zero value and false.
 func (object *Object[V]) Get(key string) (V, bool) {
+	if object.ext != nil {
+		return object.getExt(key)
+	}
+	if idx := indexOfKey(object.entries, key); idx >= 0 {
+		return object.entries[idx].Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// getExt is Get for objects using opt-in features, which also reports deprecated
+// keys and traces the lookup.
+func (object *Object[V]) getExt(key string) (V, bool) {
+	object.warnDeprecated(key, DeprecationRead)
 	if idx := object.findKeyIndex(key); idx >= 0 {
+		object.traceLookup(key, true)
 		return object.entries[idx].Value, true
 	}
+	object.traceLookup(key, false)
 	var zero V
 	return zero, false
 }
@@ -105,22 +314,27 @@ func (object *Object[V]) Has(key string) bool {
 }
 
 // Delete removes a key-value pair from the ordered object.
+// A soft-deleted entry with the same key is discarded as well.
 // If the key does not exist, it does nothing.
 // Returns the object for chaining.
 func (object *Object[V]) Delete(key string) *Object[V] {
 	if idx := object.findKeyIndex(key); idx >= 0 {
-		object.entries = slices.Delete(object.entries, idx, idx+1)
+		object.removeIndex(idx)
+	} else {
+		object.forgetDeleted(key)
 	}
 	return object
 }
 
 // Length returns the number of key-value pairs in the ordered object.
 func (object *Object[V]) Length() int {
+	object.expire()
 	return len(object.entries)
 }
 
 // Keys returns all keys in the ordered object.
 func (object *Object[V]) Keys() []string {
+	object.expire()
 	keys := make([]string, len(object.entries))
 	for i, entry := range object.entries {
 		keys[i] = entry.Key
@@ -130,6 +344,7 @@ func (object *Object[V]) Keys() []string {
 
 // Values returns all values in the ordered object.
 func (object *Object[V]) Values() []V {
+	object.expire()
 	values := make([]V, len(object.entries))
 	for i, entry := range object.entries {
 		values[i] = entry.Value
@@ -139,6 +354,7 @@ func (object *Object[V]) Values() []V {
 
 // Entries returns all key-value pairs in the ordered object.
 func (object *Object[V]) Entries() []Entry[V] {
+	object.expire()
 	entries := make([]Entry[V], len(object.entries))
 	copy(entries, object.entries)
 	return entries
@@ -146,63 +362,196 @@ func (object *Object[V]) Entries() []Entry[V] {
 
 // ForEach executes a function for each key-value pair in the ordered object.
 func (object *Object[V]) ForEach(fn func(key string, value V)) {
+	object.expire()
 	for _, entry := range object.entries {
 		fn(entry.Key, entry.Value)
 	}
 }
 
-// Clone returns a deep copy of the ordered object.
+// Clone returns a shallow copy of the ordered object.
+// Nested objects, maps and slices are shared with the original; use DeepClone to copy them.
 func (object *Object[V]) Clone() *Object[V] {
 	entries := make([]Entry[V], len(object.entries))
 	copy(entries, object.entries)
-	return &Object[V]{entries: entries}
+	clone := &Object[V]{entries: entries}
+	if object.ext != nil {
+		clone.ext = object.ext.clone()
+	}
+	object.cloneIndexes(clone)
+	return clone
 }
 
 // MarshalJSON encodes the ordered object as JSON.
 func (object *Object[V]) MarshalJSON() ([]byte, error) {
-	var buf bytes.Buffer
-	enc := jsontext.NewEncoder(&buf)
-	if err := object.MarshalJSONTo(enc); err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
+	data, err := json.Marshal(object)
+	return data, withPath(err, -1)
 }
 
 // MarshalJSONTo encodes the ordered object to a JSON encoder.
+// It implements json.MarshalerTo; the options the caller passed to json.Marshal are
+// carried by the encoder and apply to nested values as well.
 func (object *Object[V]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	object.expire()
 	if err := enc.WriteToken(jsontext.BeginObject); err != nil {
 		return err
 	}
+	ext := object.ext
+	if ext == nil {
+		for _, entry := range object.entries {
+			if err := marshalEntry(enc, entry.Key, entry.Value); err != nil {
+				return err
+			}
+		}
+		return enc.WriteToken(jsontext.EndObject)
+	}
+	hooks := object.encodeHooks()
 	for _, entry := range object.entries {
-		if err := enc.WriteToken(jsontext.String(entry.Key)); err != nil {
-			return err
+		if ext.omitEmpty && isEmptyEntry(any(entry.Value)) {
+			continue
 		}
-
-		// Check if value implements OrderedMarshaler and handle it specially
-		if orderedMarshaler, ok := any(entry.Value).(OrderedMarshaler); ok {
-			if err := orderedMarshaler.MarshalJSONTo(enc); err != nil {
+		value := any(entry.Value)
+		if len(hooks) > 0 {
+			var err error
+			if value, err = applyHooks(hooks, entry.Key, value); err != nil {
 				return err
 			}
-		} else {
-			// Use Deterministic option to ensure nested maps have consistent ordering
-			if err := json.MarshalEncode(enc, entry.Value, json.Deterministic(true)); err != nil {
-				return err
+		}
+		if len(ext.redact) > 0 {
+			value = redactEntry(entry.Key, value, ext.redact)
+		}
+		if err := marshalEntry(enc, entry.Key, value); err != nil {
+			return err
+		}
+		if ext.emitAliases && len(ext.aliases) > 0 {
+			for _, alias := range object.aliasesOf(entry.Key) {
+				if err := marshalEntry(enc, alias, value); err != nil {
+					return err
+				}
 			}
 		}
 	}
 	return enc.WriteToken(jsontext.EndObject)
 }
 
+// marshalEntry encodes a single key-value pair to a JSON encoder.
+func marshalEntry[V any](enc *jsontext.Encoder, key string, value V) error {
+	if err := enc.WriteToken(jsontext.String(key)); err != nil {
+		return err
+	}
+	return marshalValue(enc, value)
+}
+
+// marshalValue encodes a single value to a JSON encoder.
+func marshalValue[V any](enc *jsontext.Encoder, value V) error {
+	// Check if value implements OrderedMarshaler and handle it specially
+	if orderedMarshaler, ok := any(value).(OrderedMarshaler); ok {
+		return orderedMarshaler.MarshalJSONTo(enc)
+	}
+	if codec := customCodec(); codec != nil {
+		data, err := codec.Marshal(value)
+		if err != nil {
+			return err
+		}
+		return enc.WriteValue(data)
+	}
+	if ok, err := marshalScalar(enc, any(value)); ok {
+		return err
+	}
+	// Use Deterministic option to ensure nested maps have consistent ordering
+	return json.MarshalEncode(enc, value, json.Deterministic(true))
+}
+
+// marshalScalar writes strings, booleans, nulls and finite numbers directly as tokens,
+// skipping the allocations of a reflective marshal. It reports false for other values,
+// and for numbers when the encoder was configured to stringify them.
+func marshalScalar(enc *jsontext.Encoder, value any) (bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return true, enc.WriteToken(jsontext.Null)
+	case string:
+		return true, enc.WriteToken(jsontext.String(v))
+	case bool:
+		return true, enc.WriteToken(jsontext.Bool(v))
+	}
+	if stringify, _ := json.GetOption(enc.Options(), json.StringifyNumbers); stringify {
+		return false, nil
+	}
+	switch v := value.(type) {
+	case int:
+		return true, enc.WriteToken(jsontext.Int(int64(v)))
+	case int64:
+		return true, enc.WriteToken(jsontext.Int(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false, nil
+		}
+		return true, enc.WriteToken(jsontext.Float(v))
+	}
+	return false, nil
+}
+
 // UnmarshalJSON decodes a JSON object into the ordered object.
 func (object *Object[V]) UnmarshalJSON(data []byte) error {
 	dec := jsontext.NewDecoder(bytes.NewReader(data))
-	return object.UnmarshalJSONFrom(dec)
+	if err := object.UnmarshalJSONFrom(dec); err != nil {
+		if ext := object.ext; ext != nil && ext.strict.RejectNonStringKeys {
+			// Non-string keys fail as syntax errors while reading; scan again to type them
+			if keyErr := ext.strict.check(data, 0); errors.Is(keyErr, ErrExpectedStringKey) {
+				return keyErr
+			}
+		}
+		return withPath(err, dec.InputOffset())
+	}
+	ext := object.ext
+	if ext == nil {
+		return nil
+	}
+	if ext.strict.RejectTrailingData {
+		offset := dec.InputOffset()
+		if _, err := dec.ReadToken(); err != io.EOF {
+			return fmt.Errorf("%w at offset %d", ErrTrailingData, offset)
+		}
+	}
+	if ext.positions != nil {
+		// Positions are counted from the opening brace; account for whitespace before it
+		shiftPositions(ext.positions, data[:len(data)-len(bytes.TrimLeft(data, " \t\r\n"))])
+	}
+	return nil
 }
 
 // UnmarshalJSONFrom decodes a JSON object from a decoder into the ordered object.
+// It implements json.UnmarshalerFrom; the options the caller passed to json.Unmarshal
+// are carried by the decoder and apply to the decoded values as well.
 func (object *Object[V]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 	// Reset the object
 	object.entries = object.entries[:0]
+	ext := object.ext
+	if ext != nil {
+		ext.deleted = nil
+		ext.positions = nil
+		ext.expires, ext.nextExpiry = nil, time.Time{}
+		defer object.reindex()
+	}
+
+	// Check the whole object against the limits and record key positions before decoding any value
+	if ext != nil && (ext.limits.enabled() || ext.trackPositions || ext.strict.enabled()) {
+		value, err := ext.limits.read(dec)
+		if err != nil {
+			return ext.strict.duplicateError(err, 0)
+		}
+		if ext.strict.enabled() {
+			if err := ext.strict.check(value, dec.InputOffset()-int64(len(value))); err != nil {
+				return err
+			}
+		}
+		if ext.trackPositions {
+			base := dec.InputOffset() - int64(len(value))
+			if ext.positions, err = scanPositions(value, base); err != nil {
+				return err
+			}
+		}
+		dec = jsontext.NewDecoder(bytes.NewReader(value), dec.Options())
+	}
 
 	// Check for object start
 	tok, err := dec.ReadToken()
@@ -214,6 +563,9 @@ func (object *Object[V]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 	}
 
 	// Parse key-value pairs
+	hooks := object.decodeHooks()
+	collect := ext != nil && (ext.collectErrors || ext.onInvalid != nil)
+	var errs []error
 	for dec.PeekKind() != '}' {
 		// Read key
 		tok, err := dec.ReadToken()
@@ -223,16 +575,48 @@ func (object *Object[V]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 		if tok.Kind() != '"' {
 			return fmt.Errorf("%w, got %v", ErrExpectedStringKey, tok.Kind())
 		}
-		key := tok.String()
+		object.warnDeprecated(tok.String(), DeprecationDecode)
+		key := object.resolveKey(tok.String())
 
 		// Read value
 		var value V
-		if err := json.UnmarshalDecode(dec, &value); err != nil {
-			return err
+		if collect {
+			// Decode from a copy of the value so a type mismatch leaves dec after it
+			raw, err := dec.ReadValue()
+			if err != nil {
+				return err
+			}
+			start := dec.InputOffset() - int64(len(raw))
+			err = object.decodeValue(jsontext.NewDecoder(bytes.NewReader(raw), dec.Options()), key, &value)
+			if err == nil {
+				value, err = decodeHooked(hooks, key, value)
+			}
+			if err != nil && ext.onInvalid != nil {
+				ext.onInvalid(key, raw.Clone(), keyError(key, start, err))
+				continue
+			}
+			if err != nil {
+				errs = append(errs, keyError(key, start, err))
+				continue
+			}
+		} else {
+			if err := object.decodeValue(dec, key, &value); err != nil {
+				return err
+			}
+			if value, err = decodeHooked(hooks, key, value); err != nil {
+				return err
+			}
 		}
 
-		// Add to entries
-		object.entries = append(object.entries, Entry[V]{Key: key, Value: value})
+		// Add to entries; aliased, alternate-case, normalized or custom-equal keys may collide with an entry that was already decoded
+		if ext != nil && (len(ext.aliases) > 0 || ext.caseFallback || ext.normalizeKey != nil || ext.keyEqual != nil) {
+			if ext.strict.RejectDuplicateKeys && object.findKeyIndex(key) >= 0 {
+				return &PathError{Path: key, Offset: dec.InputOffset(), Err: fmt.Errorf("%w: %q", ErrDuplicateKey, key)}
+			}
+			object.Set(key, value)
+		} else {
+			object.entries = append(object.entries, Entry[V]{Key: key, Value: value})
+		}
 	}
 
 	// Read the closing '}'
@@ -240,12 +624,46 @@ func (object *Object[V]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 		return err
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// decodeHooked applies the decode hooks to a decoded value of key.
+func decodeHooked[V any](hooks []func(key string, value any) (any, error), key string, value V) (V, error) {
+	if len(hooks) == 0 {
+		return value, nil
+	}
+	decoded, err := applyHooks(hooks, key, any(value))
+	if err != nil {
+		return value, err
+	}
+	v, ok := decoded.(V)
+	if !ok {
+		return value, mismatch(key, decoded, fmt.Sprintf("%T", value))
+	}
+	return v, nil
+}
+
+// unmarshalValue decodes the next value from a decoder into value.
+func unmarshalValue[V any](dec *jsontext.Decoder, value *V) error {
+	return unmarshalInto(dec, value)
+}
+
+// unmarshalInto decodes the next value from a decoder into the value target points to.
+func unmarshalInto(dec *jsontext.Decoder, target any) error {
+	if codec := customCodec(); codec != nil {
+		data, err := dec.ReadValue()
+		if err != nil {
+			return err
+		}
+		return codec.Unmarshal(data, target)
+	}
+	return json.UnmarshalDecode(dec, target)
 }
 
 // ToMap converts the ordered object to a standard Go map.
 // The returned map will not preserve the insertion order.
 func (object *Object[V]) ToMap() map[string]V {
+	object.expire()
 	m := make(map[string]V, len(object.entries))
 	for _, entry := range object.entries {
 		m[entry.Key] = entry.Value
@@ -256,5 +674,6 @@ func (object *Object[V]) ToMap() map[string]V {
 // ToJSON converts the ordered object to a JSON byte slice.
 // This is a convenience method that internally uses json.Marshal.
 func (object *Object[V]) ToJSON() ([]byte, error) {
-	return json.Marshal(object)
+	data, err := json.Marshal(object)
+	return data, withPath(err, -1)
 }
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	json "github.com/go-json-experiment/json"
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
 )
 
 func TestMarshal(t *testing.T) {
@@ -701,6 +703,44 @@ func TestDeterministicMapOrdering(t *testing.T) {
 	assert.Less(t, bananaIdx, cherryIdx, "banana should come before cherry")
 }
 
+func TestObjectExtension(t *testing.T) {
+	t.Parallel()
+
+	// Opt-in state lives behind a single pointer, so plain objects stay small
+	assert.Equal(t, unsafe.Sizeof([]Entry[any]{})+unsafe.Sizeof(uintptr(0)), unsafe.Sizeof(Object[any]{}))
+
+	obj, err := FromJSONDeep([]byte(`{"a":{"b":1},"c":[{"d":2}]}`))
+	require.NoError(t, err)
+	obj.Set("e", 3).Delete("e")
+	_, _ = obj.Get("a")
+	_, err = obj.ToJSON()
+	require.NoError(t, err)
+	assert.Nil(t, obj.ext)
+	nested, _ := obj.GetPath("c.0")
+	assert.Nil(t, nested.(*Object[any]).ext)
+	assert.Nil(t, obj.Clone().ext)
+
+	obj.Alias("x", "a")
+	assert.NotNil(t, obj.ext)
+	clone := obj.Clone()
+	assert.True(t, clone.Has("x"))
+	obj.Unalias("x")
+	assert.True(t, clone.Has("x"), "clones do not share opt-in state")
+}
+
+func TestIndexOfKey(t *testing.T) {
+	t.Parallel()
+
+	entries := []Entry[int]{{Key: ""}, {Key: "a"}, {Key: "ab"}, {Key: "key10"}, {Key: "key50"}, {Key: "kez50"}, {Key: "éa"}}
+	for i, entry := range entries {
+		assert.Equal(t, i, indexOfKey(entries, entry.Key), entry.Key)
+	}
+	for _, key := range []string{"b", "ba", "key5", "key51", "kex50", "ea"} {
+		assert.Equal(t, -1, indexOfKey(entries, key), key)
+	}
+	assert.Equal(t, -1, indexOfKey([]Entry[int](nil), ""))
+}
+
 // Benchmark tests
 func BenchmarkObjectSet(b *testing.B) {
 	obj := NewObject[any](100)
@@ -754,3 +794,30 @@ func BenchmarkObjectMarshalJSON(b *testing.B) {
 		_, _ = obj.MarshalJSON()
 	}
 }
+
+func TestMarshalOptionsPropagation(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("n", 1).
+		Set("s", []int(nil)).
+		Set("o", NewObject[any]().Set("n", 2).Set("s", []int(nil)))
+
+	data, err := json.Marshal(obj, json.StringifyNumbers(true), json.FormatNilSliceAsNull(true))
+	require.NoError(t, err)
+	assert.Equal(t, `{"n":"1","s":null,"o":{"n":"2","s":null}}`, string(data))
+
+	data, err = json.Marshal(obj, jsontext.Multiline(true), jsontext.WithIndent(" "))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n \"n\": 1,\n \"s\": [],\n \"o\": {\n  \"n\": 2,\n  \"s\": []\n }\n}", string(data))
+}
+
+func TestUnmarshalOptionsPropagation(t *testing.T) {
+	t.Parallel()
+
+	var obj Object[int]
+	err := json.Unmarshal([]byte(`{"b":"1","a":"2"}`), &obj, json.StringifyNumbers(true))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, obj.Keys())
+	assert.Equal(t, []int{1, 2}, obj.Values())
+}
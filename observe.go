@@ -0,0 +1,118 @@
+package orderedobject
+
+import "slices"
+
+// EventKind identifies the kind of mutation described by an Event.
+type EventKind int
+
+const (
+	// EventSet is fired when a key is added or its value is replaced.
+	EventSet EventKind = iota + 1
+	// EventDelete is fired when a key is removed.
+	EventDelete
+	// EventReorder is fired when the order of the keys changes.
+	EventReorder
+)
+
+// String returns the name of the event kind.
+func (kind EventKind) String() string {
+	switch kind {
+	case EventSet:
+		return "set"
+	case EventDelete:
+		return "delete"
+	case EventReorder:
+		return "reorder"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a single mutation delivered to subscribers.
+type Event struct {
+	// Kind is the kind of mutation.
+	Kind EventKind
+	// Key is the key that was set or deleted; it is empty for reorder events.
+	Key string
+	// Value is the new value for set events and the removed value for delete events.
+	Value any
+	// OldValue is the previous value when a set event replaced an existing value.
+	OldValue any
+	// Replaced reports whether a set event replaced an existing value.
+	Replaced bool
+}
+
+// subscriber is a registered mutation callback.
+type subscriber struct {
+	id int
+	fn func(event Event)
+}
+
+// Subscribe registers fn to be called after every mutation of the object: keys set,
+// added or restored, keys deleted, popped or pruned, and keys reordered by ApplyOrder.
+// Apply and TransformValues fire a set event for every top-level value they visit.
+// Replacing all entries by decoding, NormalizeKeys or RestoreSnapshot does not fire events.
+// Subscribers are called in registration order and are not copied by Clone.
+// The returned function removes the subscription.
+func (object *Object[V]) Subscribe(fn func(event Event)) (unsubscribe func()) {
+	ext := object.extend()
+	ext.lastSubscriber++
+	id := ext.lastSubscriber
+	ext.subscribers = append(ext.subscribers, subscriber{id: id, fn: fn})
+	return func() {
+		ext.subscribers = slices.DeleteFunc(ext.subscribers, func(s subscriber) bool {
+			return s.id == id
+		})
+	}
+}
+
+// observed reports whether mutations are observed by subscribers, recorded for
+// ChangedSince or tracked by indexes.
+func (object *Object[V]) observed() bool {
+	ext := object.ext
+	return ext != nil && (len(ext.subscribers) > 0 || ext.changes != nil || ext.indexes != nil)
+}
+
+// notify delivers event to every subscriber.
+func (object *Object[V]) notify(event Event) {
+	if object.ext == nil {
+		return
+	}
+	for _, s := range slices.Clone(object.ext.subscribers) {
+		s.fn(event)
+	}
+}
+
+// notifySet records a set of key and fires a set event, if the object has subscribers.
+func (object *Object[V]) notifySet(key string, value, old V, replaced bool) {
+	if object.ext == nil {
+		return
+	}
+	if replaced {
+		object.recordChange(key, ChangeModified)
+	} else {
+		object.recordChange(key, ChangeAdded)
+	}
+	object.indexSet(key, value, old, replaced)
+	if len(object.ext.subscribers) == 0 {
+		return
+	}
+	event := Event{Kind: EventSet, Key: key, Value: value, Replaced: replaced}
+	if replaced {
+		event.OldValue = old
+	}
+	object.notify(event)
+}
+
+// notifyDelete records the removal of an entry and fires a delete event, if the
+// object has subscribers.
+func (object *Object[V]) notifyDelete(entry Entry[V]) {
+	if object.ext == nil {
+		return
+	}
+	object.recordChange(entry.Key, ChangeRemoved)
+	object.indexDelete(entry)
+	if len(object.ext.subscribers) > 0 {
+		object.notify(Event{Kind: EventDelete, Key: entry.Key, Value: entry.Value})
+	}
+}
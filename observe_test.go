@@ -0,0 +1,117 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Set fires add and replace events", func(t *testing.T) {
+		obj := NewObject[int]()
+		var events []Event
+		obj.Subscribe(func(event Event) { events = append(events, event) })
+
+		obj.Set("a", 1).Set("a", 2)
+
+		assert.Equal(t, []Event{
+			{Kind: EventSet, Key: "a", Value: 1},
+			{Kind: EventSet, Key: "a", Value: 2, OldValue: 1, Replaced: true},
+		}, events)
+	})
+
+	t.Run("Deletes fire delete events", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1).Set("b", 2).Set("c", 3).Set("d", 4).Set("e", 5)
+		var deleted []string
+		obj.Subscribe(func(event Event) {
+			assert.Equal(t, EventDelete, event.Kind)
+			deleted = append(deleted, event.Key)
+		})
+
+		obj.Delete("a").Delete("missing")
+		obj.DeleteMany("b", "c")
+		obj.DeleteFunc(func(key string, _ int) bool { return key == "d" })
+		obj.PopLast()
+
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, deleted)
+		assert.Zero(t, obj.Length())
+	})
+
+	t.Run("Compact fires events on each level", func(t *testing.T) {
+		nested := NewObject[any]().Set("x", nil)
+		obj := NewObject[any]().Set("empty", "").Set("nested", nested)
+		var keys []string
+		obj.Subscribe(func(event Event) { keys = append(keys, event.Key) })
+		nested.Subscribe(func(event Event) { keys = append(keys, "nested."+event.Key) })
+
+		obj.Compact()
+
+		assert.Equal(t, []string{"empty", "nested.x"}, keys)
+	})
+
+	t.Run("Soft delete and restore", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1).Set("b", 2)
+		var kinds []EventKind
+		obj.Subscribe(func(event Event) { kinds = append(kinds, event.Kind) })
+
+		obj.SoftDelete("a")
+		obj.Restore("a")
+
+		assert.Equal(t, []EventKind{EventDelete, EventSet}, kinds)
+	})
+
+	t.Run("ApplyOrder fires reorder only on change", func(t *testing.T) {
+		obj := NewObject[int]().Set("b", 1).Set("a", 2)
+		var kinds []EventKind
+		obj.Subscribe(func(event Event) { kinds = append(kinds, event.Kind) })
+
+		obj.ApplyOrder(Profile{Keys: []string{"a", "b"}})
+		obj.ApplyOrder(Profile{Keys: []string{"a", "b"}})
+
+		assert.Equal(t, []EventKind{EventReorder}, kinds)
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		obj := NewObject[int]()
+		var first, second int
+		unsubscribe := obj.Subscribe(func(Event) { first++ })
+		obj.Subscribe(func(Event) { second++ })
+
+		obj.Set("a", 1)
+		unsubscribe()
+		obj.Set("b", 2)
+
+		assert.Equal(t, 1, first)
+		assert.Equal(t, 2, second)
+	})
+
+	t.Run("Clone does not copy subscribers", func(t *testing.T) {
+		obj := NewObject[int]()
+		calls := 0
+		obj.Subscribe(func(Event) { calls++ })
+
+		obj.Clone().Set("a", 1)
+
+		assert.Zero(t, calls)
+	})
+}
+
+func TestEventKindString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "set", EventSet.String())
+	assert.Equal(t, "delete", EventDelete.String())
+	assert.Equal(t, "reorder", EventReorder.String())
+	assert.Equal(t, "unknown", EventKind(0).String())
+}
+
+func BenchmarkSetUnobserved(b *testing.B) {
+	obj := NewObject[int]()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		obj.Set("key", i)
+	}
+}
@@ -0,0 +1,17 @@
+package orderedobject
+
+import "reflect"
+
+// OmitEmpty controls whether marshaling skips entries whose value is empty in the sense
+// of the omitempty struct tag option: nil, false, 0, or an empty string, slice or map.
+// The setting applies to this object only; nested objects use their own setting.
+// Returns the object for chaining.
+func (object *Object[V]) OmitEmpty(omit bool) *Object[V] {
+	object.extend().omitEmpty = omit
+	return object
+}
+
+// isEmptyEntry reports whether value is nil or empty in the sense of omitempty.
+func isEmptyEntry(value any) bool {
+	return value == nil || isEmptyValue(reflect.ValueOf(value))
+}
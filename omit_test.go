@@ -0,0 +1,35 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOmitEmpty(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("name", "app").
+		Set("description", "").
+		Set("port", 0).
+		Set("tags", []any(nil)).
+		Set("labels", map[string]any{}).
+		Set("parent", nil).
+		Set("debug", false).
+		Set("server", NewObject[any]().Set("host", ""))
+
+	obj.OmitEmpty(true)
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"app","server":{"host":""}}`, string(data))
+
+	// Entries are kept, only the encoding skips them
+	assert.Equal(t, 8, obj.Length())
+
+	obj.OmitEmpty(false)
+	data, err = obj.ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"port":0`)
+}
@@ -0,0 +1,222 @@
+// Package orderedobjecttest provides golden file helpers for testing code that
+// produces ordered objects.
+package orderedobjecttest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kaptinlin/orderedobject"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// UpdateEnv is the environment variable that makes MatchGolden rewrite golden files
+// instead of comparing against them when set to a non-empty value other than "0".
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// GoldenOptions configures MatchGolden.
+type GoldenOptions struct {
+	// Indent is the indentation used for golden files. Defaults to two spaces.
+	Indent string
+	// IgnoreOrder compares objects regardless of key order.
+	// Golden files are still written in the object's key order.
+	IgnoreOrder bool
+	// Update rewrites the golden file instead of comparing against it, as does
+	// setting the UpdateEnv environment variable.
+	Update bool
+}
+
+// UpdateGolden writes the indented JSON encoding of obj to the golden file at path,
+// creating parent directories as needed.
+func UpdateGolden[V any](t testing.TB, path string, obj *orderedobject.Object[V], opts ...GoldenOptions) {
+	t.Helper()
+
+	data, err := encodeGolden(obj, goldenOptions(opts))
+	if err != nil {
+		t.Fatalf("encode golden %s: %v", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("create golden directory: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write golden %s: %v", path, err)
+	}
+}
+
+// MatchGolden compares the indented JSON encoding of obj with the golden file at path
+// and reports a line diff on mismatch. With GoldenOptions.Update or the UpdateEnv
+// environment variable set, the golden file is rewritten instead.
+func MatchGolden[V any](t testing.TB, path string, obj *orderedobject.Object[V], opts ...GoldenOptions) {
+	t.Helper()
+
+	o := goldenOptions(opts)
+	if o.Update || updateFromEnv() {
+		UpdateGolden(t, path, obj, opts...)
+		return
+	}
+
+	got, err := encodeGolden(obj, o)
+	if err != nil {
+		t.Fatalf("encode golden %s: %v", path, err)
+	}
+	want, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("golden %s does not exist; set %s=1 to create it", path, UpdateEnv)
+	}
+	if err != nil {
+		t.Fatalf("read golden %s: %v", path, err)
+	}
+
+	if o.IgnoreOrder {
+		if got, err = canonicalize(got, o); err != nil {
+			t.Fatalf("canonicalize output: %v", err)
+		}
+		if want, err = canonicalize(want, o); err != nil {
+			t.Fatalf("canonicalize golden %s: %v", path, err)
+		}
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("golden %s mismatch (-want +got):\n%s", path, lineDiff(string(want), string(got)))
+	}
+}
+
+// goldenOptions returns the first options with defaults applied.
+func goldenOptions(opts []GoldenOptions) GoldenOptions {
+	var o GoldenOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.Indent == "" {
+		o.Indent = "  "
+	}
+	return o
+}
+
+// updateFromEnv reports whether UpdateEnv asks for golden files to be rewritten.
+func updateFromEnv() bool {
+	value := os.Getenv(UpdateEnv)
+	return value != "" && value != "0"
+}
+
+// encodeGolden encodes obj as indented JSON terminated by a newline.
+func encodeGolden[V any](obj *orderedobject.Object[V], opts GoldenOptions) ([]byte, error) {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	value := jsontext.Value(data)
+	if err := value.Indent(jsontext.WithIndent(opts.Indent)); err != nil {
+		return nil, err
+	}
+	return append(value, '\n'), nil
+}
+
+// canonicalize sorts object keys and re-indents the JSON document.
+func canonicalize(data []byte, opts GoldenOptions) ([]byte, error) {
+	value := jsontext.Value(strings.TrimSpace(string(data)))
+	if err := value.Canonicalize(); err != nil {
+		return nil, err
+	}
+	if err := value.Indent(jsontext.WithIndent(opts.Indent)); err != nil {
+		return nil, err
+	}
+	return append(value, '\n'), nil
+}
+
+// lineDiff returns a line-oriented diff of want and got based on their longest
+// common subsequence of lines.
+func lineDiff(want, got string) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// Only the lines between the common prefix and suffix need to be aligned
+	prefix := 0
+	for prefix < min(len(a), len(b)) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < min(len(a), len(b))-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var sb strings.Builder
+	for _, line := range a[:prefix] {
+		fmt.Fprintf(&sb, "  %s\n", line)
+	}
+	writeLineDiff(&sb, a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	for _, line := range a[len(a)-suffix:] {
+		fmt.Fprintf(&sb, "  %s\n", line)
+	}
+	return sb.String()
+}
+
+// writeLineDiff writes the diff of a and b with Hirschberg's algorithm: it splits a
+// in half and b where the halves share the most lines, and recurses, so only two
+// rows of subsequence lengths are held in memory instead of a full table.
+func writeLineDiff(sb *strings.Builder, a, b []string) {
+	switch {
+	case len(a) == 0:
+		for _, line := range b {
+			fmt.Fprintf(sb, "+ %s\n", line)
+		}
+		return
+	case len(b) == 0:
+		for _, line := range a {
+			fmt.Fprintf(sb, "- %s\n", line)
+		}
+		return
+	case len(a) == 1:
+		j := slices.Index(b, a[0])
+		if j < 0 {
+			writeLineDiff(sb, a, nil)
+			writeLineDiff(sb, nil, b)
+			return
+		}
+		writeLineDiff(sb, nil, b[:j])
+		fmt.Fprintf(sb, "  %s\n", a[0])
+		writeLineDiff(sb, nil, b[j+1:])
+		return
+	}
+
+	mid := len(a) / 2
+	head := lcsLengths(a[:mid], b)
+	tail := lcsLengths(reversed(a[mid:]), reversed(b))
+	split := 0
+	for j := range b {
+		if head[j+1]+tail[len(b)-j-1] > head[split]+tail[len(b)-split] {
+			split = j + 1
+		}
+	}
+	writeLineDiff(sb, a[:mid], b[:split])
+	writeLineDiff(sb, a[mid:], b[split:])
+}
+
+// lcsLengths returns, for every prefix b[:j], the length of the longest common
+// subsequence of a and b[:j].
+func lcsLengths(a, b []string) []int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for _, line := range a {
+		for j := range b {
+			if line == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
+
+// reversed returns a reversed copy of lines.
+func reversed(lines []string) []string {
+	r := slices.Clone(lines)
+	slices.Reverse(r)
+	return r
+}
@@ -0,0 +1,91 @@
+package orderedobjecttest
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kaptinlin/orderedobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGolden(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "testdata", "config.golden.json")
+	obj := orderedobject.NewObject[any]().
+		Set("name", "app").
+		Set("server", orderedobject.NewObject[any]().Set("port", 8080))
+
+	UpdateGolden(t, path, obj)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"name\": \"app\",\n  \"server\": {\n    \"port\": 8080\n  }\n}\n", string(data))
+
+	MatchGolden(t, path, obj)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm()&^0o022)
+
+	reordered := orderedobject.NewObject[any]().
+		Set("server", orderedobject.NewObject[any]().Set("port", 8080)).
+		Set("name", "app")
+	MatchGolden(t, path, reordered, GoldenOptions{IgnoreOrder: true})
+}
+
+func TestLineDiff(t *testing.T) {
+	t.Parallel()
+
+	diff := lineDiff("{\n  \"a\": 1,\n  \"b\": 2\n}\n", "{\n  \"a\": 1,\n  \"b\": 3\n}\n")
+	assert.Equal(t, "  {\n    \"a\": 1,\n-   \"b\": 2\n+   \"b\": 3\n  }\n", diff)
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.golden.json")
+	obj := orderedobject.NewObject[any]().Set("name", "app")
+
+	MatchGolden(t, path, obj, GoldenOptions{Update: true})
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"name\": \"app\"\n}\n", string(data))
+
+	t.Setenv(UpdateEnv, "1")
+	MatchGolden(t, path, obj.Set("port", 8080))
+	t.Setenv(UpdateEnv, "0")
+	MatchGolden(t, path, obj)
+}
+
+func TestLineDiffLarge(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "- a\n  b\n  c\n+ d\n", lineDiff("a\nb\nc\n", "b\nc\nd\n"))
+	assert.Equal(t, "- a\n+ b\n", lineDiff("a\n", "b\n"))
+
+	want := make([]string, 20000)
+	for i := range want {
+		want[i] = strconv.Itoa(i)
+	}
+	got := slices.Clone(want)
+	got[10000] = "changed"
+	diff := lineDiff(strings.Join(want, "\n"), strings.Join(got, "\n"))
+	assert.Contains(t, diff, "- 10000\n+ changed\n")
+	assert.Equal(t, 20001, strings.Count(diff, "\n"))
+
+	// Changes spread over the whole file keep the longest common subsequence
+	var kept []string
+	for i, line := range want[:3000] {
+		if i%3 != 0 {
+			kept = append(kept, line)
+		}
+	}
+	diff = lineDiff(strings.Join(want[:3000], "\n"), strings.Join(kept, "\n"))
+	assert.Equal(t, 1000, strings.Count(diff, "- "))
+	assert.Equal(t, 2000, strings.Count(diff, "\n  "))
+	assert.NotContains(t, diff, "+ ")
+}
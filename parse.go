@@ -0,0 +1,104 @@
+package orderedobject
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// Handler receives the events of a JSON document from Parse in document order.
+// Returning an error from any method stops parsing, and Parse returns that error.
+type Handler interface {
+	// OnObjectStart is called for each opening brace.
+	OnObjectStart() error
+	// OnObjectEnd is called for each closing brace.
+	OnObjectEnd() error
+	// OnArrayStart is called for each opening bracket.
+	OnArrayStart() error
+	// OnArrayEnd is called for each closing bracket.
+	OnArrayEnd() error
+	// OnKey is called for each object key, before the events of its value.
+	OnKey(key string) error
+	// OnValue is called for each scalar value: a string, a float64, a bool or nil.
+	OnValue(value any) error
+}
+
+// NopHandler implements Handler by ignoring every event. Embed it to implement only
+// the callbacks of interest.
+type NopHandler struct{}
+
+// OnObjectStart implements Handler.
+func (NopHandler) OnObjectStart() error { return nil }
+
+// OnObjectEnd implements Handler.
+func (NopHandler) OnObjectEnd() error { return nil }
+
+// OnArrayStart implements Handler.
+func (NopHandler) OnArrayStart() error { return nil }
+
+// OnArrayEnd implements Handler.
+func (NopHandler) OnArrayEnd() error { return nil }
+
+// OnKey implements Handler.
+func (NopHandler) OnKey(string) error { return nil }
+
+// OnValue implements Handler.
+func (NopHandler) OnValue(any) error { return nil }
+
+// Parse reads a single JSON value from r and reports its structure to handler as it is
+// read, without building objects in memory. Keys and values are reported in document
+// order, so custom indexes or validators can rely on the original key order.
+func Parse(r io.Reader, handler Handler) error {
+	dec := jsontext.NewDecoder(r)
+	for {
+		// An object member at an even position is a key
+		kind, length := dec.StackIndex(dec.StackDepth())
+		expectKey := kind == '{' && length%2 == 0
+
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+
+		switch tok.Kind() {
+		case '{':
+			err = handler.OnObjectStart()
+		case '}':
+			err = handler.OnObjectEnd()
+		case '[':
+			err = handler.OnArrayStart()
+		case ']':
+			err = handler.OnArrayEnd()
+		default:
+			if expectKey {
+				err = handler.OnKey(tok.String())
+			} else {
+				var value any
+				if value, err = tokenValue(tok); err == nil {
+					err = handler.OnValue(value)
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if dec.StackDepth() == 0 {
+			return nil
+		}
+	}
+}
+
+// tokenValue converts a scalar JSON token to its Go value.
+func tokenValue(tok jsontext.Token) (any, error) {
+	switch tok.Kind() {
+	case 'n':
+		return nil, nil
+	case 't', 'f':
+		return tok.Bool(), nil
+	case '0':
+		return strconv.ParseFloat(tok.String(), 64)
+	default:
+		return tok.String(), nil
+	}
+}
@@ -0,0 +1,88 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHandler records every event as a string.
+type recordingHandler struct {
+	events []string
+}
+
+func (h *recordingHandler) OnObjectStart() error { h.events = append(h.events, "{"); return nil }
+func (h *recordingHandler) OnObjectEnd() error   { h.events = append(h.events, "}"); return nil }
+func (h *recordingHandler) OnArrayStart() error  { h.events = append(h.events, "["); return nil }
+func (h *recordingHandler) OnArrayEnd() error    { h.events = append(h.events, "]"); return nil }
+func (h *recordingHandler) OnKey(key string) error {
+	h.events = append(h.events, "key:"+key)
+	return nil
+}
+func (h *recordingHandler) OnValue(value any) error {
+	h.events = append(h.events, fmt.Sprintf("value:%v", value))
+	return nil
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	h := &recordingHandler{}
+	err := Parse(strings.NewReader(`{"z":1,"a":{"k":"v","n":null},"list":[true,{"x":[]}],"s":"key"}`), h)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"{",
+		"key:z", "value:1",
+		"key:a", "{", "key:k", "value:v", "key:n", "value:<nil>", "}",
+		"key:list", "[", "value:true", "{", "key:x", "[", "]", "}", "]",
+		"key:s", "value:key",
+		"}",
+	}, h.events)
+}
+
+func TestParseScalar(t *testing.T) {
+	t.Parallel()
+
+	h := &recordingHandler{}
+	require.NoError(t, Parse(strings.NewReader(`"hello"`), h))
+	assert.Equal(t, []string{"value:hello"}, h.events)
+}
+
+// keyCounter counts keys, stopping at a limit.
+type keyCounter struct {
+	NopHandler
+	keys  []string
+	limit int
+}
+
+var errEnoughKeys = errors.New("enough keys")
+
+func (h *keyCounter) OnKey(key string) error {
+	h.keys = append(h.keys, key)
+	if len(h.keys) == h.limit {
+		return errEnoughKeys
+	}
+	return nil
+}
+
+func TestParseHandlerError(t *testing.T) {
+	t.Parallel()
+
+	h := &keyCounter{limit: 2}
+	err := Parse(strings.NewReader(`{"a":1,"b":2,"c":3}`), h)
+	require.ErrorIs(t, err, errEnoughKeys)
+	assert.Equal(t, []string{"a", "b"}, h.keys)
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	assert.ErrorIs(t, Parse(strings.NewReader(``), NopHandler{}), io.ErrUnexpectedEOF)
+	assert.ErrorIs(t, Parse(strings.NewReader(`{"a":[1,`), NopHandler{}), io.ErrUnexpectedEOF)
+	assert.Error(t, Parse(strings.NewReader(`{"a" 1}`), NopHandler{}))
+}
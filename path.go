@@ -0,0 +1,229 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPath is returned when a path is empty or contains an empty segment
+	ErrInvalidPath = errors.New("invalid path")
+	// ErrPathConflict is returned when a path traverses a value that is not an object or array
+	ErrPathConflict = errors.New("path traverses a non-container value")
+)
+
+// GetPath returns the value at a dotted path such as "server.ssl.cert" and whether it exists.
+// Each segment selects a key of a nested *Object[any] or map[string]any, or an index
+// of a []any. Keys containing dots cannot be addressed.
+func (object *Object[V]) GetPath(path string) (V, bool) {
+	var zero V
+	keys, err := splitPath(path)
+	if err != nil {
+		return zero, false
+	}
+	idx := object.findKeyIndex(keys[0])
+	if idx < 0 {
+		object.traceLookup(path, false)
+		return zero, false
+	}
+	if len(keys) == 1 {
+		object.traceLookup(path, true)
+		return object.entries[idx].Value, true
+	}
+
+	current := any(object.entries[idx].Value)
+	for _, key := range keys[1:] {
+		next, ok := childValue(current, key)
+		if !ok {
+			object.traceLookup(path, false)
+			return zero, false
+		}
+		current = next
+	}
+	value, ok := current.(V)
+	object.traceLookup(path, ok)
+	return value, ok
+}
+
+// SetPath sets the value at a dotted path such as "server.ssl.cert", creating missing
+// intermediate objects as *Object[any]. Intermediate values may also be maps or arrays;
+// array segments must be valid indexes. Nil intermediate values are replaced by new
+// objects at every level. Creating the first level of intermediate objects requires the
+// object to hold values of type any. The whole path is checked before anything is
+// changed, so a failing SetPath leaves the object untouched.
+func (object *Object[V]) SetPath(path string, value V) error {
+	keys, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 1 {
+		object.Set(keys[0], value)
+		return nil
+	}
+
+	// Find the deepest existing container, where new objects would be attached
+	var current any
+	missing := 0
+	if idx := object.findKeyIndex(keys[0]); idx >= 0 && !isNilValue(object.entries[idx].Value) {
+		current = any(object.entries[idx].Value)
+		missing = -1
+		for i, key := range keys[1 : len(keys)-1] {
+			next, ok := childValue(current, key)
+			if !ok || isNilValue(next) {
+				missing = i + 1
+				break
+			}
+			current = next
+		}
+	}
+	if missing == 0 {
+		if _, ok := any(NewObject[any]()).(V); !ok {
+			return fmt.Errorf("%w: cannot create object at %q", ErrPathConflict, keys[0])
+		}
+	} else {
+		at, key := len(keys), keys[len(keys)-1]
+		if missing > 0 {
+			at, key = missing+1, keys[missing]
+		}
+		if err := checkChild(current, key); err != nil {
+			return fmt.Errorf("%w at %q", err, strings.Join(keys[:at], "."))
+		}
+	}
+
+	if missing < 0 {
+		return setChildValue(current, keys[len(keys)-1], any(value))
+	}
+	// Build the missing objects from the innermost one outwards and attach them
+	var created any = value
+	for i := len(keys) - 1; i > missing; i-- {
+		created = NewObject[any]().Set(keys[i], created)
+	}
+	if missing == 0 {
+		object.Set(keys[0], created.(V))
+		return nil
+	}
+	return setChildValue(current, keys[missing], created)
+}
+
+// DeletePath removes the value at a dotted path and reports whether it existed.
+// Array elements cannot be deleted.
+func (object *Object[V]) DeletePath(path string) bool {
+	keys, err := splitPath(path)
+	if err != nil {
+		return false
+	}
+	if len(keys) == 1 {
+		if !object.Has(keys[0]) {
+			return false
+		}
+		object.Delete(keys[0])
+		return true
+	}
+
+	parent, ok := object.GetPath(strings.Join(keys[:len(keys)-1], "."))
+	if !ok {
+		return false
+	}
+	last := keys[len(keys)-1]
+	switch v := any(parent).(type) {
+	case *Object[any]:
+		if v != nil && v.Has(last) {
+			v.Delete(last)
+			return true
+		}
+	case map[string]any:
+		if _, ok := v[last]; ok {
+			delete(v, last)
+			return true
+		}
+	}
+	return false
+}
+
+// splitPath splits a dotted path into its keys.
+func splitPath(path string) ([]string, error) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+	}
+	return keys, nil
+}
+
+// childValue returns the value stored under key in an object, map or array.
+func childValue(container any, key string) (any, bool) {
+	switch c := container.(type) {
+	case *Object[any]:
+		if c == nil {
+			return nil, false
+		}
+		return c.Get(key)
+	case map[string]any:
+		value, ok := c[key]
+		return value, ok
+	case []any:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(c) {
+			return nil, false
+		}
+		return c[i], true
+	}
+	return nil, false
+}
+
+// checkChild reports the error setChildValue would return for key in container.
+func checkChild(container any, key string) error {
+	switch c := container.(type) {
+	case *Object[any]:
+		if c != nil {
+			return nil
+		}
+	case map[string]any:
+		if c != nil {
+			return nil
+		}
+	case []any:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(c) {
+			return fmt.Errorf("%w: index %q out of range", ErrPathConflict, key)
+		}
+		return nil
+	}
+	return ErrPathConflict
+}
+
+// setChildValue stores value under key in an object, map or array.
+func setChildValue(container any, key string, value any) error {
+	if err := checkChild(container, key); err != nil {
+		return err
+	}
+	switch c := container.(type) {
+	case *Object[any]:
+		c.Set(key, value)
+	case map[string]any:
+		c[key] = value
+	case []any:
+		i, _ := strconv.Atoi(key)
+		c[i] = value
+	}
+	return nil
+}
+
+// isNilValue reports whether value is nil or a nil object, map or array, which
+// SetPath replaces with a new object.
+func isNilValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case *Object[any]:
+		return v == nil
+	case map[string]any:
+		return v == nil
+	case []any:
+		return v == nil
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPath(t *testing.T) {
+	t.Parallel()
+
+	config := NewObject[any]().
+		Set("server", NewObject[any]().
+			Set("port", 8080).
+			Set("ssl", NewObject[any]().Set("cert", "/path/to/cert.pem"))).
+		Set("db", map[string]any{"hosts": []any{"a", map[string]any{"name": "b"}}})
+
+	value, ok := config.GetPath("server.ssl.cert")
+	assert.True(t, ok)
+	assert.Equal(t, "/path/to/cert.pem", value)
+
+	value, ok = config.GetPath("db.hosts.1.name")
+	assert.True(t, ok)
+	assert.Equal(t, "b", value)
+
+	for _, path := range []string{"server.ssl.key", "server.port.x", "db.hosts.5", "missing", "server..port", ""} {
+		_, ok = config.GetPath(path)
+		assert.False(t, ok, path)
+	}
+
+	typed := NewObject[string]().Set("name", "app")
+	name, ok := typed.GetPath("name")
+	assert.True(t, ok)
+	assert.Equal(t, "app", name)
+}
+
+func TestSetPath(t *testing.T) {
+	t.Parallel()
+
+	config := NewObject[any]().Set("name", "app")
+
+	require.NoError(t, config.SetPath("server.ssl.cert", "cert.pem"))
+	require.NoError(t, config.SetPath("server.port", 8080))
+	require.NoError(t, config.SetPath("name", "renamed"))
+
+	data, err := config.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"renamed","server":{"ssl":{"cert":"cert.pem"},"port":8080}}`, string(data))
+
+	require.ErrorIs(t, config.SetPath("name.first", "x"), ErrPathConflict)
+	require.ErrorIs(t, config.SetPath("a..b", "x"), ErrInvalidPath)
+
+	config.Set("list", []any{1, 2})
+	require.NoError(t, config.SetPath("list.0", 10))
+	require.ErrorIs(t, config.SetPath("list.2", 30), ErrPathConflict)
+	list, _ := config.Get("list")
+	assert.Equal(t, []any{10, 2}, list)
+
+	typed := NewObject[int]()
+	require.ErrorIs(t, typed.SetPath("a.b", 1), ErrPathConflict)
+}
+
+func TestSetPathNilAndFailures(t *testing.T) {
+	t.Parallel()
+
+	// Nil values are replaced by objects at every depth
+	var null *Object[any]
+	obj := NewObject[any]().Set("a", nil).Set("b", NewObject[any]().Set("c", nil).Set("d", null))
+	require.NoError(t, obj.SetPath("a.x", 1))
+	require.NoError(t, obj.SetPath("b.c.x", 2))
+	require.NoError(t, obj.SetPath("b.d.x.y", 3))
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":{"x":1},"b":{"c":{"x":2},"d":{"x":{"y":3}}}}`, string(data))
+
+	// A failing path creates nothing
+	obj = NewObject[any]().Set("list", []any{1})
+	err = obj.SetPath("list.5.a.b", 1)
+	require.ErrorIs(t, err, ErrPathConflict)
+	assert.ErrorContains(t, err, `"list.5"`)
+	err = obj.SetPath("list.0.a.b", 1)
+	require.ErrorIs(t, err, ErrPathConflict)
+	assert.ErrorContains(t, err, `"list.0.a"`)
+	assert.Equal(t, []any{1}, obj.Values()[0])
+	assert.Equal(t, []string{"list"}, obj.Keys())
+}
+
+func TestDeletePath(t *testing.T) {
+	t.Parallel()
+
+	config := NewObject[any]().
+		Set("server", NewObject[any]().Set("port", 8080).Set("host", "localhost")).
+		Set("db", map[string]any{"user": "root"})
+
+	assert.True(t, config.DeletePath("server.port"))
+	assert.True(t, config.DeletePath("db.user"))
+	assert.False(t, config.DeletePath("server.port"))
+	assert.False(t, config.DeletePath("missing.key"))
+
+	data, err := config.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"server":{"host":"localhost"},"db":{}}`, string(data))
+
+	assert.True(t, config.DeletePath("db"))
+	assert.Equal(t, []string{"server"}, config.Keys())
+}
@@ -0,0 +1,78 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// PathError reports where in a document encoding or decoding a value failed.
+type PathError struct {
+	// Path is the dotted path of the value, such as "settings.ssl.cert", with array
+	// elements addressed by index as in GetPath.
+	Path string
+	// Offset is the byte offset in the input at which decoding failed, or -1 for
+	// encoding errors.
+	Offset int64
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the path and offset followed by the message of the underlying error.
+func (e *PathError) Error() string {
+	if e.Offset < 0 {
+		return fmt.Sprintf("at %s: %v", e.Path, e.Err)
+	}
+	return fmt.Sprintf("at %s (offset %d): %v", e.Path, e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// withPath wraps err in a PathError if it carries the JSON pointer of the value that
+// failed. The byte offset is taken from err if known, and otherwise from offset.
+func withPath(err error, offset int64) error {
+	if err == nil {
+		return nil
+	}
+	var pathErr *PathError
+	if errors.As(err, &pathErr) {
+		return err
+	}
+	var pointer jsontext.Pointer
+	var syntactic *jsontext.SyntacticError
+	var semantic *json.SemanticError
+	switch {
+	case errors.As(err, &syntactic):
+		pointer = syntactic.JSONPointer
+		if syntactic.ByteOffset > 0 {
+			offset = syntactic.ByteOffset
+		}
+	case errors.As(err, &semantic):
+		pointer = semantic.JSONPointer
+		if offset >= 0 && semantic.ByteOffset > 0 {
+			offset = semantic.ByteOffset
+		}
+	}
+	if pointer == "" {
+		return err
+	}
+	return &PathError{Path: pointerPath(pointer), Offset: offset, Err: err}
+}
+
+// pointerUnescaper decodes the escape sequences of a JSON pointer segment.
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// pointerPath converts a JSON pointer such as "/settings/ssl/cert" to a dotted path.
+func pointerPath(pointer jsontext.Pointer) string {
+	segments := strings.Split(strings.TrimPrefix(string(pointer), "/"), "/")
+	for i, segment := range segments {
+		segments[i] = pointerUnescaper.Replace(segment)
+	}
+	return strings.Join(segments, ".")
+}
@@ -0,0 +1,75 @@
+package orderedobject
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pathErrorSettings struct {
+	SSL struct {
+		Cert int `json:"cert"`
+	} `json:"ssl"`
+}
+
+func TestPathError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Decoding into nested struct", func(t *testing.T) {
+		data := []byte(`{"settings": {"ssl": {"cert": "x"}}}`)
+		_, err := FromJSON[pathErrorSettings](data)
+
+		var pathErr *PathError
+		require.ErrorAs(t, err, &pathErr)
+		assert.Equal(t, "settings.ssl.cert", pathErr.Path)
+		assert.Equal(t, int64(30), pathErr.Offset)
+		assert.Contains(t, err.Error(), "at settings.ssl.cert (offset 30)")
+	})
+
+	t.Run("Syntax error in deep decoding", func(t *testing.T) {
+		_, err := FromJSONDeep([]byte(`{"a": [1, {"b": tru}]}`))
+
+		var pathErr *PathError
+		require.ErrorAs(t, err, &pathErr)
+		assert.Equal(t, "a.1.b", pathErr.Path)
+		assert.Equal(t, int64(19), pathErr.Offset)
+	})
+
+	t.Run("Nested ordered objects", func(t *testing.T) {
+		_, err := FromJSON[*Object[int]]([]byte(`{"a": {"b": 1}, "c": {"d": true}}`))
+
+		var pathErr *PathError
+		require.ErrorAs(t, err, &pathErr)
+		assert.Equal(t, "c.d", pathErr.Path)
+	})
+
+	t.Run("Encoding", func(t *testing.T) {
+		obj := NewObject[any]().Set("a", NewObject[any]().Set("b", []any{1, math.NaN()}))
+
+		for _, encode := range []func() ([]byte, error){obj.ToJSON, obj.MarshalJSON} {
+			_, err := encode()
+			var pathErr *PathError
+			require.ErrorAs(t, err, &pathErr)
+			assert.Equal(t, "a.b.1", pathErr.Path)
+			assert.Equal(t, int64(-1), pathErr.Offset)
+		}
+	})
+
+	t.Run("Errors without location are not wrapped", func(t *testing.T) {
+		_, err := FromJSON[int]([]byte(`[]`))
+
+		var pathErr *PathError
+		assert.False(t, errors.As(err, &pathErr))
+		assert.ErrorIs(t, err, ErrExpectedObjectStart)
+	})
+}
+
+func TestPointerPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "a.0.b", pointerPath("/a/0/b"))
+	assert.Equal(t, "a/b~c", pointerPath("/a~1b~0c"))
+}
@@ -0,0 +1,138 @@
+package orderedobject
+
+import (
+	"bytes"
+	"strconv"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// EntryMeta describes where a key was found in the decoded source.
+type EntryMeta struct {
+	// Offset is the byte offset of the key's opening quote in the input.
+	Offset int64
+	// Line is the 1-based line of the key.
+	Line int
+	// Column is the 1-based byte column of the key within its line.
+	Column int
+}
+
+// TrackPositions makes UnmarshalJSON and UnmarshalJSONFrom record where every key,
+// including the keys of nested objects, was found, so validators can report errors
+// such as "port: invalid value at line 12". Positions describe the decoded source and
+// are not updated by later edits. Returns the object for chaining.
+func (object *Object[V]) TrackPositions(enabled bool) *Object[V] {
+	ext := object.extend()
+	ext.trackPositions = enabled
+	if !enabled {
+		ext.positions = nil
+	}
+	return object
+}
+
+// Position returns where the key at a dotted path such as "server.port" was found when
+// the object was decoded with TrackPositions enabled. Array elements are addressed by
+// index, as in GetPath.
+func (object *Object[V]) Position(path string) (EntryMeta, bool) {
+	if object.ext == nil {
+		return EntryMeta{}, false
+	}
+	meta, ok := object.ext.positions[path]
+	return meta, ok
+}
+
+// scanPositions records the position of every key in the JSON object value, which
+// starts at byte offset base of the input. Lines and columns are counted from the
+// start of value.
+func scanPositions(value jsontext.Value, base int64) (map[string]EntryMeta, error) {
+	positions := make(map[string]EntryMeta)
+	scan := jsontext.NewDecoder(bytes.NewReader(value))
+
+	// path holds the keys and indexes of the containers being scanned, key the last key read
+	var path []string
+	var key string
+	line, lineStart := 1, 0
+	counted := 0
+	for {
+		kind, length := scan.StackIndex(scan.StackDepth())
+		if kind == '{' && length%2 == 0 && scan.PeekKind() == '"' {
+			raw, err := scan.ReadValue()
+			if err != nil {
+				return nil, err
+			}
+			start := int(scan.InputOffset()) - len(raw)
+			if bytes.IndexByte(raw, '\\') < 0 {
+				key = string(raw[1 : len(raw)-1])
+			} else if err := json.Unmarshal(raw, &key); err != nil {
+				return nil, err
+			}
+
+			for ; counted < start; counted++ {
+				if value[counted] == '\n' {
+					line++
+					lineStart = counted + 1
+				}
+			}
+			positions[joinPath(path, key)] = EntryMeta{
+				Offset: base + int64(start),
+				Line:   line,
+				Column: start - lineStart + 1,
+			}
+			continue
+		}
+
+		segment := key
+		if kind == '[' {
+			segment = strconv.FormatInt(length, 10)
+		}
+		tok, err := scan.ReadToken()
+		if err != nil {
+			return nil, err
+		}
+		switch tok.Kind() {
+		case '{', '[':
+			if kind != 0 {
+				path = append(path, segment)
+			}
+		case '}', ']':
+			if len(path) > 0 {
+				path = path[:len(path)-1]
+			}
+		}
+		if scan.StackDepth() == 0 {
+			return positions, nil
+		}
+	}
+}
+
+// shiftPositions moves the lines and columns of positions counted from the start of an
+// object to account for the text preceding the object.
+func shiftPositions(positions map[string]EntryMeta, prefix []byte) {
+	if len(prefix) == 0 {
+		return
+	}
+	lines := bytes.Count(prefix, []byte("\n"))
+	column := len(prefix) - (bytes.LastIndexByte(prefix, '\n') + 1)
+	for path, meta := range positions {
+		if meta.Line == 1 {
+			meta.Column += column
+		}
+		meta.Line += lines
+		positions[path] = meta
+	}
+}
+
+// joinPath joins a key to the dotted path of its container.
+func joinPath(path []string, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	var b bytes.Buffer
+	for _, segment := range path {
+		b.WriteString(segment)
+		b.WriteByte('.')
+	}
+	b.WriteString(key)
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package orderedobject
+
+import (
+	"testing"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackPositions(t *testing.T) {
+	t.Parallel()
+
+	input := "{\n" +
+		"  \"name\": \"app\",\n" +
+		"  \"server\": {\n" +
+		"    \"port\": 8080, \"host\": \"x\"\n" +
+		"  },\n" +
+		"  \"items\": [{\"id\": 1}, {\"a\\u002eb\": 2}]\n" +
+		"}"
+
+	obj := NewObject[any]().TrackPositions(true)
+	require.NoError(t, obj.UnmarshalJSON([]byte(input)))
+	assert.Equal(t, []string{"name", "server", "items"}, obj.Keys())
+
+	tests := []struct {
+		path string
+		want EntryMeta
+	}{
+		{path: "name", want: EntryMeta{Offset: 4, Line: 2, Column: 3}},
+		{path: "server", want: EntryMeta{Offset: 21, Line: 3, Column: 3}},
+		{path: "server.port", want: EntryMeta{Offset: 37, Line: 4, Column: 5}},
+		{path: "server.host", want: EntryMeta{Offset: 51, Line: 4, Column: 19}},
+		{path: "items", want: EntryMeta{Offset: 70, Line: 6, Column: 3}},
+		{path: "items.0.id", want: EntryMeta{Offset: 81, Line: 6, Column: 14}},
+		{path: "items.1.a.b", want: EntryMeta{Offset: 92, Line: 6, Column: 25}},
+	}
+	for _, tt := range tests {
+		meta, ok := obj.Position(tt.path)
+		require.True(t, ok, tt.path)
+		assert.Equal(t, tt.want, meta, tt.path)
+		assert.Equal(t, byte('"'), input[meta.Offset], tt.path)
+	}
+
+	_, ok := obj.Position("missing")
+	assert.False(t, ok)
+}
+
+func TestTrackPositionsLeadingWhitespace(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().TrackPositions(true)
+	require.NoError(t, obj.UnmarshalJSON([]byte("\n\n  {\"a\": 1,\n\"b\": 2}")))
+
+	meta, ok := obj.Position("a")
+	require.True(t, ok)
+	assert.Equal(t, EntryMeta{Offset: 5, Line: 3, Column: 4}, meta)
+
+	meta, ok = obj.Position("b")
+	require.True(t, ok)
+	assert.Equal(t, EntryMeta{Offset: 13, Line: 4, Column: 1}, meta)
+}
+
+func TestTrackPositionsUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().TrackPositions(true).SetDecodeLimits(DecodeLimits{MaxKeys: 5})
+	require.NoError(t, json.Unmarshal([]byte(`{"x":1,"y":2}`), obj))
+	meta, ok := obj.Position("y")
+	require.True(t, ok)
+	assert.Equal(t, EntryMeta{Offset: 7, Line: 1, Column: 8}, meta)
+
+	// Clones keep the recorded positions
+	_, ok = obj.Clone().Position("x")
+	assert.True(t, ok)
+
+	obj.TrackPositions(false)
+	_, ok = obj.Position("x")
+	assert.False(t, ok)
+}
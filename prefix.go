@@ -0,0 +1,21 @@
+package orderedobject
+
+import "strings"
+
+// SubObject returns a new ordered object with the key-value pairs whose keys start with
+// prefix, such as "db." for namespaced configuration, in their relative order.
+// If stripPrefix is true, the prefix is removed from the keys of the result.
+func (object *Object[V]) SubObject(prefix string, stripPrefix bool) *Object[V] {
+	result := NewObject[V]()
+	for _, entry := range object.entries {
+		if !strings.HasPrefix(entry.Key, prefix) {
+			continue
+		}
+		key := entry.Key
+		if stripPrefix {
+			key = strings.TrimPrefix(key, prefix)
+		}
+		result.entries = append(result.entries, Entry[V]{Key: key, Value: entry.Value})
+	}
+	return result
+}
@@ -0,0 +1,27 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubObject(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[string]().
+		Set("db.host", "localhost").
+		Set("app.name", "demo").
+		Set("db.port", "5432").
+		Set("db.user", "admin")
+
+	sub := obj.SubObject("db.", false)
+	assert.Equal(t, []string{"db.host", "db.port", "db.user"}, sub.Keys())
+
+	sub = obj.SubObject("db.", true)
+	assert.Equal(t, []string{"host", "port", "user"}, sub.Keys())
+	assert.Equal(t, []string{"localhost", "5432", "admin"}, sub.Values())
+
+	assert.True(t, obj.SubObject("cache.", true).IsEmpty())
+	assert.Equal(t, 4, obj.Length())
+}
@@ -0,0 +1,295 @@
+package orderedobject
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// Preserved is a JSON object document edited in place. Edits rewrite only the bytes of
+// the values they touch, so whitespace, indentation and number formatting elsewhere in
+// the document are retained and a programmatic change produces a minimal diff.
+// Paths are dotted as in GetPath; array elements are addressed by index.
+type Preserved struct {
+	src []byte
+}
+
+// ParsePreserved returns a Preserved document for data, which must hold a JSON object.
+func ParsePreserved(data []byte) (*Preserved, error) {
+	dec := jsontext.NewDecoder(bytes.NewReader(data))
+	if dec.PeekKind() != '{' {
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+		}
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", ErrExpectedObjectStart)
+	}
+	if _, err := dec.ReadValue(); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return &Preserved{src: slices.Clone(data)}, nil
+}
+
+// Bytes returns the current text of the document.
+func (p *Preserved) Bytes() []byte {
+	return slices.Clone(p.src)
+}
+
+// Object decodes the current document, with nested objects as ordered objects.
+func (p *Preserved) Object() (*Object[any], error) {
+	return FromJSONDeep(p.src)
+}
+
+// Get decodes the value at path, with nested objects as ordered objects, and reports
+// whether it exists.
+func (p *Preserved) Get(path string) (any, bool) {
+	loc, err := p.locate(path)
+	if err != nil || !loc.found {
+		return nil, false
+	}
+	value, err := decodeOrdered(p.src[loc.member.valueStart:loc.member.valueEnd])
+	return value, err == nil
+}
+
+// Set replaces the value at path, or inserts it after the last member of its object,
+// creating missing intermediate objects. The new value is written in the indentation
+// style of the surrounding document; the rest of the document is left untouched.
+// Array elements can be replaced but not added.
+func (p *Preserved) Set(path string, value any) error {
+	loc, err := p.locate(path)
+	if err != nil {
+		return err
+	}
+	if loc.found {
+		m := loc.member
+		data, err := p.encode(value, p.lineIndent(m.start()))
+		if err != nil {
+			return err
+		}
+		p.replace(m.valueStart, m.valueEnd, data)
+		return nil
+	}
+
+	if loc.kind != '{' {
+		return fmt.Errorf("%w: index %q out of range", ErrPathConflict, loc.keys[loc.depth])
+	}
+	// Nest the value in objects for the missing intermediate keys
+	for i := len(loc.keys) - 1; i > loc.depth; i-- {
+		value = NewObject[any]().Set(loc.keys[i], value)
+	}
+	key, err := json.Marshal(loc.keys[loc.depth])
+	if err != nil {
+		return err
+	}
+
+	if last := loc.last; last.keyStart >= 0 {
+		// Copy the separator and indentation of the last member
+		sep := p.src[last.prevEnd:last.keyStart]
+		if i := bytes.IndexByte(sep, ','); i >= 0 {
+			sep = sep[i+1:]
+		}
+		data, err := p.encode(value, p.lineIndent(last.keyStart))
+		if err != nil {
+			return err
+		}
+		member := slices.Concat([]byte(","), sep, key, p.src[last.keyEnd:last.valueStart], data)
+		p.replace(last.valueEnd, last.valueEnd, member)
+		return nil
+	}
+
+	// Insert into an empty object, on its own line in multiline documents
+	unit := p.indentUnit()
+	indent := p.lineIndent(loc.open)
+	data, err := p.encode(value, indent+unit)
+	if err != nil {
+		return err
+	}
+	member := slices.Concat(key, []byte(": "), data)
+	if unit != "" {
+		member = slices.Concat([]byte("\n"+indent+unit), member, []byte("\n"+indent))
+	}
+	p.replace(loc.open+1, loc.close, member)
+	return nil
+}
+
+// Delete removes the member at path together with its separator and reports whether it
+// existed. Array elements cannot be deleted.
+func (p *Preserved) Delete(path string) bool {
+	loc, err := p.locate(path)
+	if err != nil || !loc.found || loc.kind != '{' {
+		return false
+	}
+	m := loc.member
+	switch {
+	case m.prevEnd != loc.open+1:
+		// Remove the preceding comma along with the member
+		p.replace(m.prevEnd, m.valueEnd, nil)
+	case m.nextStart >= 0:
+		p.replace(m.keyStart, m.nextStart, nil)
+	default:
+		p.replace(loc.open+1, loc.close, nil)
+	}
+	return true
+}
+
+// replace replaces the bytes between start and end with data.
+func (p *Preserved) replace(start, end int, data []byte) {
+	p.src = slices.Concat(p.src[:start], data, p.src[end:])
+}
+
+// encode encodes a value, indenting nested lines with prefix in multiline documents.
+func (p *Preserved) encode(value any, prefix string) ([]byte, error) {
+	unit := p.indentUnit()
+	if unit == "" {
+		return json.Marshal(value, json.Deterministic(true))
+	}
+	return json.Marshal(value, json.Deterministic(true),
+		jsontext.Multiline(true), jsontext.WithIndent(unit), jsontext.WithIndentPrefix(prefix))
+}
+
+// indentUnit returns the indentation of the first member of the root object, or an
+// empty string if it is on the same line as the opening brace.
+func (p *Preserved) indentUnit() string {
+	open := bytes.IndexByte(p.src, '{')
+	rest := p.src[open+1:]
+	start := len(rest) - len(bytes.TrimLeft(rest, " \t\r\n"))
+	if bytes.IndexByte(rest[:start], '\n') < 0 || rest[start] == '}' {
+		return ""
+	}
+	return p.lineIndent(open + 1 + start)
+}
+
+// lineIndent returns the leading whitespace of the line containing offset.
+func (p *Preserved) lineIndent(offset int) string {
+	start := bytes.LastIndexByte(p.src[:offset], '\n') + 1
+	end := start
+	for end < offset && (p.src[end] == ' ' || p.src[end] == '\t') {
+		end++
+	}
+	return string(p.src[start:end])
+}
+
+// preservedMember holds the offsets of an object member or array element.
+type preservedMember struct {
+	// keyStart and keyEnd delimit the quoted key; keyStart is -1 for array elements
+	keyStart, keyEnd int
+	// valueStart and valueEnd delimit the value
+	valueStart, valueEnd int
+	// prevEnd is the end of the previous value, or just after the opening bracket
+	prevEnd int
+	// nextStart is the start of the next member, or -1 if this is the last one
+	nextStart int
+}
+
+// start returns the offset where the member begins.
+func (m preservedMember) start() int {
+	if m.keyStart >= 0 {
+		return m.keyStart
+	}
+	return m.valueStart
+}
+
+// preservedLocation is the result of resolving a path in a Preserved document.
+type preservedLocation struct {
+	keys []string
+	// found reports whether the whole path exists, in which case member is its last segment
+	found  bool
+	member preservedMember
+	// depth is the index of the key looked up in the innermost existing container
+	depth int
+	// kind, open and close describe that container
+	kind        jsontext.Kind
+	open, close int
+	// last is its last member; last.keyStart is -1 when it is empty
+	last preservedMember
+}
+
+// locate resolves path in the document.
+func (p *Preserved) locate(path string) (*preservedLocation, error) {
+	keys, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	loc := &preservedLocation{keys: keys}
+	dec := jsontext.NewDecoder(bytes.NewReader(p.src))
+	return loc, p.locateIn(dec, loc, 0)
+}
+
+// locateIn looks up keys[depth] in the container at the decoder's position.
+func (p *Preserved) locateIn(dec *jsontext.Decoder, loc *preservedLocation, depth int) error {
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return err
+	}
+	loc.depth, loc.kind = depth, tok.Kind()
+	loc.open = int(dec.InputOffset()) - 1
+	loc.last = preservedMember{keyStart: -1}
+	closing := jsontext.Kind('}')
+	if loc.kind == '[' {
+		closing = ']'
+	}
+
+	target := loc.keys[depth]
+	prevEnd := loc.open + 1
+	for index := 0; dec.PeekKind() != closing; index++ {
+		m := preservedMember{keyStart: -1, prevEnd: prevEnd, nextStart: -1}
+		key := strconv.Itoa(index)
+		if loc.kind == '{' {
+			raw, err := dec.ReadValue()
+			if err != nil {
+				return err
+			}
+			m.keyEnd = int(dec.InputOffset())
+			m.keyStart = m.keyEnd - len(raw)
+			if err := json.Unmarshal(raw, &key); err != nil {
+				return err
+			}
+		}
+
+		if key == target && depth < len(loc.keys)-1 {
+			if kind := dec.PeekKind(); kind != '{' && kind != '[' {
+				return fmt.Errorf("%w: %q", ErrPathConflict, target)
+			}
+			return p.locateIn(dec, loc, depth+1)
+		}
+
+		raw, err := dec.ReadValue()
+		if err != nil {
+			return err
+		}
+		m.valueEnd = int(dec.InputOffset())
+		m.valueStart = m.valueEnd - len(raw)
+
+		if key == target {
+			if dec.PeekKind() != closing {
+				next, err := dec.ReadValue()
+				if err != nil {
+					return err
+				}
+				m.nextStart = int(dec.InputOffset()) - len(next)
+			}
+			loc.found, loc.member = true, m
+			return p.findClose(dec, loc, closing)
+		}
+		loc.last = m
+		prevEnd = m.valueEnd
+	}
+	return p.findClose(dec, loc, closing)
+}
+
+// findClose records the offset of the closing bracket of the current container.
+func (p *Preserved) findClose(dec *jsontext.Decoder, loc *preservedLocation, closing jsontext.Kind) error {
+	for dec.PeekKind() != closing {
+		if err := dec.SkipValue(); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.ReadToken(); err != nil {
+		return err
+	}
+	loc.close = int(dec.InputOffset()) - 1
+	return nil
+}
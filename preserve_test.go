@@ -0,0 +1,119 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const preservedInput = `{
+    "name":   "app",
+    "version": 1.50,
+    "ratio": 1e3,
+    "server": {
+        "port": 8080,
+        "hosts": [ "a",  "b" ]
+    },
+    "empty": {}
+}
+`
+
+func TestPreservedSet(t *testing.T) {
+	t.Parallel()
+
+	doc, err := ParsePreserved([]byte(preservedInput))
+	require.NoError(t, err)
+
+	require.NoError(t, doc.Set("server.port", 9090))
+	require.NoError(t, doc.Set("server.hosts.1", "c"))
+	require.NoError(t, doc.Set("server.tls", NewObject[any]().Set("enabled", true)))
+	require.NoError(t, doc.Set("empty.key", "v"))
+	require.NoError(t, doc.Set("extra.nested", []any{1}))
+
+	assert.Equal(t, `{
+    "name":   "app",
+    "version": 1.50,
+    "ratio": 1e3,
+    "server": {
+        "port": 9090,
+        "hosts": [ "a",  "c" ],
+        "tls": {
+            "enabled": true
+        }
+    },
+    "empty": {
+        "key": "v"
+    },
+    "extra": {
+        "nested": [
+            1
+        ]
+    }
+}
+`, string(doc.Bytes()))
+
+	value, ok := doc.Get("server.tls")
+	require.True(t, ok)
+	assert.Equal(t, []string{"enabled"}, value.(*Object[any]).Keys())
+	value, ok = doc.Get("version")
+	require.True(t, ok)
+	assert.Equal(t, 1.5, value)
+
+	_, ok = doc.Get("server.missing")
+	assert.False(t, ok)
+}
+
+func TestPreservedCompact(t *testing.T) {
+	t.Parallel()
+
+	doc, err := ParsePreserved([]byte(`{"a":1, "b":{"c":2},"d":{}}`))
+	require.NoError(t, err)
+
+	require.NoError(t, doc.Set("b.e", map[string]any{"y": 1, "x": 2}))
+	require.NoError(t, doc.Set("d.f", true))
+	require.NoError(t, doc.Set("g", "h"))
+	assert.Equal(t, `{"a":1, "b":{"c":2,"e":{"x":2,"y":1}},"d":{"f": true},"g":"h"}`, string(doc.Bytes()))
+
+	obj, err := doc.Object()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "d", "g"}, obj.Keys())
+}
+
+func TestPreservedDelete(t *testing.T) {
+	t.Parallel()
+
+	doc, err := ParsePreserved([]byte(preservedInput))
+	require.NoError(t, err)
+
+	assert.True(t, doc.Delete("ratio"))
+	assert.True(t, doc.Delete("name"))
+	assert.True(t, doc.Delete("server.hosts"))
+	assert.True(t, doc.Delete("server.port"))
+	assert.False(t, doc.Delete("server.port"))
+	assert.False(t, doc.Delete("missing.key"))
+
+	assert.Equal(t, `{
+    "version": 1.50,
+    "server": {},
+    "empty": {}
+}
+`, string(doc.Bytes()))
+}
+
+func TestPreservedErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParsePreserved([]byte(`[1]`))
+	require.ErrorIs(t, err, ErrExpectedObjectStart)
+	_, err = ParsePreserved([]byte(`{"a":`))
+	require.Error(t, err)
+
+	doc, err := ParsePreserved([]byte(`{"a":1,"list":[1]}`))
+	require.NoError(t, err)
+	assert.ErrorIs(t, doc.Set("a.b", 2), ErrPathConflict)
+	assert.ErrorIs(t, doc.Set("list.3", 2), ErrPathConflict)
+	assert.ErrorIs(t, doc.Set("a..b", 2), ErrInvalidPath)
+	assert.False(t, doc.Delete("list.0"))
+	assert.Equal(t, `{"a":1,"list":[1]}`, string(doc.Bytes()))
+}
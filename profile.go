@@ -0,0 +1,188 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// ErrUnknownProfile is returned when an ordering profile is not registered
+var ErrUnknownProfile = errors.New("unknown ordering profile")
+
+// ProfileWildcard marks the position of unlisted keys in Profile.Keys.
+const ProfileWildcard = "*"
+
+// Profile describes a conventional key order for a document type.
+type Profile struct {
+	// Keys lists keys in their preferred order. Keys that are not listed keep their
+	// relative order and are placed at ProfileWildcard, or after all listed keys.
+	Keys []string
+	// Nested holds profiles applied to nested *Object[any] values under a key,
+	// including objects inside an array at that key.
+	Nested map[string]Profile
+}
+
+var (
+	profilesMu sync.RWMutex
+	profiles   = map[string]Profile{
+		"k8s-manifest": {
+			Keys: []string{"apiVersion", "kind", "metadata", "spec", "data", "stringData", "status"},
+			Nested: map[string]Profile{
+				"metadata": {Keys: []string{"name", "generateName", "namespace", "labels", "annotations"}},
+				"items": {
+					Keys: []string{"apiVersion", "kind", "metadata", "spec", "data", "stringData", "status"},
+					Nested: map[string]Profile{
+						"metadata": {Keys: []string{"name", "generateName", "namespace", "labels", "annotations"}},
+					},
+				},
+			},
+		},
+		"openapi": {
+			Keys: []string{
+				"openapi", "swagger", "info", "jsonSchemaDialect", "servers", "host", "basePath", "schemes",
+				"consumes", "produces", "paths", "webhooks", "components", "definitions", "parameters",
+				"responses", "securityDefinitions", "security", "tags", "externalDocs",
+			},
+			Nested: map[string]Profile{
+				"info": {Keys: []string{"title", "summary", "description", "termsOfService", "contact", "license", "version"}},
+			},
+		},
+		"package-json": {
+			Keys: []string{
+				"$schema", "name", "displayName", "version", "private", "description", "keywords", "homepage",
+				"bugs", "repository", "funding", "license", "author", "contributors", "sideEffects", "type",
+				"exports", "main", "module", "browser", "types", "typings", "bin", "man", "directories", "files",
+				"workspaces", "scripts", "config", "dependencies", "devDependencies", "peerDependencies",
+				"peerDependenciesMeta", "optionalDependencies", "bundledDependencies", "overrides", "resolutions",
+				"engines", "os", "cpu", "packageManager", "publishConfig",
+			},
+		},
+		"composer-json": {
+			Keys: []string{
+				"name", "description", "version", "type", "keywords", "homepage", "readme", "time", "license",
+				"authors", "support", "funding", "require", "require-dev", "conflict", "replace", "provide",
+				"suggest", "autoload", "autoload-dev", "include-path", "target-dir", "minimum-stability",
+				"prefer-stable", "repositories", "config", "scripts", "scripts-descriptions", "extra", "bin",
+				"archive", "abandoned", "non-feature-branches",
+			},
+		},
+		"json-schema": {
+			Keys: []string{
+				"$schema", "$id", "$ref", "$anchor", "$comment", "title", "description", "type", "format",
+				"enum", "const", "default", "examples", ProfileWildcard, "properties", "required",
+				"additionalProperties", "$defs", "definitions",
+			},
+		},
+	}
+)
+
+// RegisterProfile registers an ordering profile under name, replacing any
+// profile previously registered with the same name.
+func RegisterProfile(name string, profile Profile) {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	profiles[name] = profile
+}
+
+// LookupProfile returns the ordering profile registered under name.
+func LookupProfile(name string) (Profile, bool) {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	profile, ok := profiles[name]
+	return profile, ok
+}
+
+// Profiles returns the names of all registered ordering profiles in sorted order.
+func Profiles() []string {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	return slices.Sorted(maps.Keys(profiles))
+}
+
+// ApplyProfile reorders the keys of the object using the ordering profile
+// registered under name.
+func (object *Object[V]) ApplyProfile(name string) error {
+	profile, ok := LookupProfile(name)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownProfile, name)
+	}
+	object.ApplyOrder(profile)
+	return nil
+}
+
+// ApplyOrder reorders the keys of the object according to profile.
+// Nested objects listed in the profile are reordered in place.
+// Returns the object for chaining.
+func (object *Object[V]) ApplyOrder(profile Profile) *Object[V] {
+	wildcard := len(profile.Keys)
+	rank := make(map[string]int, len(profile.Keys))
+	for i, key := range profile.Keys {
+		if key == ProfileWildcard {
+			wildcard = i
+		} else if _, ok := rank[key]; !ok {
+			rank[key] = i
+		}
+	}
+	rankOf := func(key string) int {
+		if r, ok := rank[key]; ok {
+			return r
+		}
+		return wildcard
+	}
+	var before []string
+	if object.observed() {
+		before = object.Keys()
+	}
+	slices.SortStableFunc(object.entries, func(a, b Entry[V]) int {
+		return rankOf(a.Key) - rankOf(b.Key)
+	})
+	if before != nil && !slices.Equal(before, object.Keys()) {
+		object.notify(Event{Kind: EventReorder})
+	}
+
+	for _, entry := range object.entries {
+		if nested, ok := profile.Nested[entry.Key]; ok {
+			applyNestedOrder(any(entry.Value), nested)
+		}
+	}
+	return object
+}
+
+// EnsureOrder reorders the keys of the object to follow a canonical key order, such
+// as the property order of an OpenAPI schema. Listed keys that are present move to the
+// front in the listed order, and the keys that are not listed follow them in their
+// current order, or take the place of ProfileWildcard if it is listed. It returns the
+// unlisted keys, so callers can report fields missing from the spec, or nil if every
+// key is listed.
+func (object *Object[V]) EnsureOrder(keys []string) []string {
+	listed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		listed[key] = true
+	}
+	var extras []string
+	for _, key := range object.Keys() {
+		if !listed[key] {
+			extras = append(extras, key)
+		}
+	}
+	object.ApplyOrder(Profile{Keys: keys})
+	return extras
+}
+
+// applyNestedOrder applies profile to value if it is an object or an array of objects.
+func applyNestedOrder(value any, profile Profile) {
+	switch v := value.(type) {
+	case *Object[any]:
+		if v != nil {
+			v.ApplyOrder(profile)
+		}
+	case []any:
+		for _, item := range v {
+			if obj, ok := item.(*Object[any]); ok && obj != nil {
+				obj.ApplyOrder(profile)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyProfile(t *testing.T) {
+	t.Parallel()
+
+	metadata := NewObject[any]().
+		Set("labels", map[string]any{"app": "web"}).
+		Set("name", "web")
+	obj := NewObject[any]().
+		Set("spec", NewObject[any]().Set("replicas", 1)).
+		Set("metadata", metadata).
+		Set("kind", "Deployment").
+		Set("x-custom", true).
+		Set("apiVersion", "apps/v1")
+
+	require.NoError(t, obj.ApplyProfile("k8s-manifest"))
+	assert.Equal(t, []string{"apiVersion", "kind", "metadata", "spec", "x-custom"}, obj.Keys())
+	assert.Equal(t, []string{"name", "labels"}, metadata.Keys())
+
+	err := obj.ApplyProfile("missing")
+	assert.ErrorIs(t, err, ErrUnknownProfile)
+}
+
+func TestApplyOrderWildcard(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().
+		Set("z", 1).
+		Set("last", 2).
+		Set("a", 3).
+		Set("first", 4)
+
+	obj.ApplyOrder(Profile{Keys: []string{"first", ProfileWildcard, "last"}})
+	assert.Equal(t, []string{"first", "z", "a", "last"}, obj.Keys())
+}
+
+func TestRegisterProfile(t *testing.T) {
+	t.Parallel()
+
+	RegisterProfile("test-custom", Profile{Keys: []string{"id", "name"}})
+	assert.Contains(t, Profiles(), "test-custom")
+	assert.Contains(t, Profiles(), "package-json")
+
+	obj := NewObject[string]().Set("name", "x").Set("id", "1")
+	require.NoError(t, obj.ApplyProfile("test-custom"))
+	assert.Equal(t, []string{"id", "name"}, obj.Keys())
+}
+
+func TestEnsureOrder(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("email", "a@example.com").
+		Set("nickname", "al").
+		Set("id", 1).
+		Set("legacy", true).
+		Set("name", "Al")
+
+	extras := obj.EnsureOrder([]string{"id", "name", "email", "createdAt"})
+	assert.Equal(t, []string{"id", "name", "email", "nickname", "legacy"}, obj.Keys())
+	assert.Equal(t, []string{"nickname", "legacy"}, extras)
+
+	assert.Nil(t, obj.EnsureOrder([]string{"legacy", "nickname", "email", "name", "id"}))
+	assert.Equal(t, []string{"legacy", "nickname", "email", "name", "id"}, obj.Keys())
+
+	extras = obj.EnsureOrder([]string{"id", ProfileWildcard, "legacy"})
+	assert.Equal(t, []string{"id", "nickname", "email", "name", "legacy"}, obj.Keys())
+	assert.Equal(t, []string{"nickname", "email", "name"}, extras)
+}
@@ -0,0 +1,151 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidProjection is returned when a projection spec contains a malformed path
+var ErrInvalidProjection = errors.New("invalid projection")
+
+// Projection describes the shape of an output object in terms of paths into an input
+// document. It is compiled once with CompileProjection and can be applied to many
+// documents concurrently.
+type Projection struct {
+	fields []projectionField
+}
+
+// projectionField is a single compiled output key.
+type projectionField struct {
+	key     string
+	path    []string
+	nested  *Projection
+	literal any
+}
+
+// CompileProjection compiles a projection spec. Each key of spec becomes an output key
+// in the same order, and its value determines the output value:
+//   - a string starting with "$" is a path into the input, such as "$.user.id" or
+//     "$.items[0].name"; "$" alone selects the whole input
+//   - a nested *Object[any] or map[string]any describes a nested output object
+//   - any other value is copied to the output as a literal
+func CompileProjection(spec *Object[any]) (*Projection, error) {
+	projection := &Projection{fields: make([]projectionField, 0, spec.Length())}
+	for _, entry := range spec.entries {
+		field := projectionField{key: entry.Key}
+		switch v := entry.Value.(type) {
+		case string:
+			if !strings.HasPrefix(v, "$") {
+				field.literal = v
+				break
+			}
+			path, err := parseProjectionPath(v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %w", ErrInvalidProjection, entry.Key, err)
+			}
+			field.path = path
+		case *Object[any]:
+			nested, err := CompileProjection(v)
+			if err != nil {
+				return nil, err
+			}
+			field.nested = nested
+		case map[string]any:
+			nested, err := CompileProjection(sortedObjectFromMap(v))
+			if err != nil {
+				return nil, err
+			}
+			field.nested = nested
+		default:
+			field.literal = v
+		}
+		projection.fields = append(projection.fields, field)
+	}
+	return projection, nil
+}
+
+// MustCompileProjection is like CompileProjection but panics if the spec is invalid.
+func MustCompileProjection(spec *Object[any]) *Projection {
+	projection, err := CompileProjection(spec)
+	if err != nil {
+		panic(err)
+	}
+	return projection
+}
+
+// Apply builds a new object from doc following the projection.
+// Output keys whose path does not exist in doc are omitted.
+func (projection *Projection) Apply(doc *Object[any]) *Object[any] {
+	result := NewObject[any](len(projection.fields))
+	for _, field := range projection.fields {
+		switch {
+		case field.nested != nil:
+			result.entries = append(result.entries, Entry[any]{Key: field.key, Value: field.nested.Apply(doc)})
+		case field.path != nil:
+			if value, ok := lookupProjectionPath(doc, field.path); ok {
+				result.entries = append(result.entries, Entry[any]{Key: field.key, Value: value})
+			}
+		default:
+			result.entries = append(result.entries, Entry[any]{Key: field.key, Value: field.literal})
+		}
+	}
+	return result
+}
+
+// Keys returns the output keys of the projection in order.
+func (projection *Projection) Keys() []string {
+	keys := make([]string, len(projection.fields))
+	for i, field := range projection.fields {
+		keys[i] = field.key
+	}
+	return keys
+}
+
+// parseProjectionPath parses a path such as "$.a.b[0].c" into its segments.
+func parseProjectionPath(path string) ([]string, error) {
+	rest := strings.TrimPrefix(path, "$")
+	segments := []string{}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("empty segment in %q", path)
+			}
+			segments = append(segments, key)
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated index in %q", path)
+			}
+			key := strings.Trim(rest[1:end], `"'`)
+			if key == "" {
+				return nil, fmt.Errorf("empty index in %q", path)
+			}
+			segments = append(segments, key)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", rest[0], path)
+		}
+	}
+	return segments, nil
+}
+
+// lookupProjectionPath returns the value at path within doc.
+func lookupProjectionPath(doc *Object[any], path []string) (any, bool) {
+	var current any = doc
+	for _, key := range path {
+		next, ok := childValue(current, key)
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return current, true
+}
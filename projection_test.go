@@ -0,0 +1,63 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjection(t *testing.T) {
+	t.Parallel()
+
+	projection, err := CompileProjection(NewObject[any]().
+		Set("userId", "$.user.id").
+		Set("name", "$.user.profile.name").
+		Set("firstTag", "$.tags[0]").
+		Set("source", "api").
+		Set("contact", NewObject[any]().
+			Set("email", "$.user.profile.email").
+			Set("phone", "$.user.profile.phone")))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"userId", "name", "firstTag", "source", "contact"}, projection.Keys())
+
+	docs := []string{
+		`{"user":{"id":1,"profile":{"name":"Ann","email":"ann@example.com"}},"tags":["a","b"]}`,
+		`{"user":{"id":2,"profile":{"name":"Bob","phone":"555"}}}`,
+	}
+	want := []string{
+		`{"userId":1,"name":"Ann","firstTag":"a","source":"api","contact":{"email":"ann@example.com"}}`,
+		`{"userId":2,"name":"Bob","source":"api","contact":{"phone":"555"}}`,
+	}
+	for i, input := range docs {
+		doc, err := FromJSON[any]([]byte(input))
+		require.NoError(t, err)
+
+		data, err := projection.Apply(doc).ToJSON()
+		require.NoError(t, err)
+		assert.Equal(t, want[i], string(data))
+	}
+}
+
+func TestProjectionRoot(t *testing.T) {
+	t.Parallel()
+
+	projection := MustCompileProjection(NewObject[any]().Set("original", "$"))
+	doc := NewObject[any]().Set("a", 1)
+
+	value, _ := projection.Apply(doc).Get("original")
+	assert.Same(t, doc, value)
+}
+
+func TestCompileProjectionInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range []string{"$.a..b", "$.items[0", "$x", "$.a[]"} {
+		_, err := CompileProjection(NewObject[any]().Set("key", path))
+		assert.ErrorIs(t, err, ErrInvalidProjection, path)
+	}
+
+	assert.Panics(t, func() {
+		MustCompileProjection(NewObject[any]().Set("key", "$."))
+	})
+}
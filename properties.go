@@ -0,0 +1,233 @@
+package orderedobject
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+)
+
+// ErrInvalidProperties is returned when .properties content cannot be parsed
+var ErrInvalidProperties = errors.New("invalid properties")
+
+// ToProperties encodes the object as Java .properties content with one key=value line
+// per value in order. Keys of nested objects and maps are joined with ".", and array
+// elements use their index, so "servers.0.host" names the host of the first server.
+// Keys and values are escaped as by java.util.Properties, including \uXXXX escapes
+// for non-ASCII characters. Strings are written as is and other scalars as JSON.
+func (object *Object[V]) ToProperties() ([]byte, error) {
+	var buf bytes.Buffer
+	for _, entry := range object.entries {
+		if err := writeProperty(&buf, entry.Key, any(entry.Value)); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// writeProperty writes the .properties lines for a value under key.
+func writeProperty(buf *bytes.Buffer, key string, value any) error {
+	switch v := value.(type) {
+	case *Object[any]:
+		if v != nil {
+			for _, entry := range v.entries {
+				if err := writeProperty(buf, key+"."+entry.Key, entry.Value); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			if err := writeProperty(buf, key+"."+k, v[k]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		for i, item := range v {
+			if err := writeProperty(buf, key+"."+strconv.Itoa(i), item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var s string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		s = v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		s = string(data)
+	}
+	buf.WriteString(escapeProperty(key, true))
+	buf.WriteByte('=')
+	buf.WriteString(escapeProperty(s, false))
+	buf.WriteByte('\n')
+	return nil
+}
+
+// escapeProperty escapes a .properties key or value.
+func escapeProperty(s string, isKey bool) string {
+	var sb strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case ' ':
+			if isKey || i == 0 {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(' ')
+		default:
+			switch {
+			case r < 0x20 || r > 0x7e:
+				if r > 0xffff {
+					r1, r2 := utf16.EncodeRune(r)
+					fmt.Fprintf(&sb, `\u%04X\u%04X`, r1, r2)
+				} else {
+					fmt.Fprintf(&sb, `\u%04X`, r)
+				}
+			default:
+				sb.WriteRune(r)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// FromProperties parses Java .properties content into an ordered object in file order.
+// Dotted keys become nested objects, so "server.port" is stored under "server".
+// Comments, line continuations, the "=", ":" and whitespace separators and backslash
+// escapes are handled as by java.util.Properties. Values are kept as strings.
+func FromProperties(data []byte) (*Object[any], error) {
+	obj := NewObject[any]()
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+	for scanner.Scan() {
+		line++
+		start := line
+		text := strings.TrimLeft(scanner.Text(), " \t\f")
+		if text == "" || text[0] == '#' || text[0] == '!' {
+			continue
+		}
+		for continuesLine(text) && scanner.Scan() {
+			line++
+			text = text[:len(text)-1] + strings.TrimLeft(scanner.Text(), " \t\f")
+		}
+
+		rawKey, rawValue := splitProperty(text)
+		key, err := unescapeProperty(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidProperties, start, err)
+		}
+		value, err := unescapeProperty(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidProperties, start, err)
+		}
+		if err := obj.SetPath(key, value); err != nil {
+			return nil, fmt.Errorf("line %d: %w", start, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// continuesLine reports whether a line ends with an odd number of backslashes.
+func continuesLine(text string) bool {
+	n := len(text) - len(strings.TrimRight(text, `\`))
+	return n%2 == 1
+}
+
+// splitProperty splits a logical line into its raw key and value.
+func splitProperty(text string) (string, string) {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '=', ':':
+			return text[:i], strings.TrimLeft(text[i+1:], " \t\f")
+		case ' ', '\t', '\f':
+			rest := strings.TrimLeft(text[i:], " \t\f")
+			if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+				rest = rest[1:]
+			}
+			return text[:i], strings.TrimLeft(rest, " \t\f")
+		}
+	}
+	return text, ""
+}
+
+// unescapeProperty resolves the backslash escapes of a .properties key or value.
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var sb strings.Builder
+	var pending rune // high surrogate awaiting its pair
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", fmt.Errorf("malformed \\u escape in %q", s)
+			}
+			n, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\u escape in %q", s)
+			}
+			i += 4
+			r := rune(n)
+			switch {
+			case utf16.IsSurrogate(r) && pending == 0:
+				pending = r
+				continue
+			case pending != 0:
+				r = utf16.DecodeRune(pending, r)
+				pending = 0
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,66 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToProperties(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("app name", "demo: café").
+		Set("server", NewObject[any]().
+			Set("port", 8080).
+			Set("hosts", []any{"a", "b"})).
+		Set("motd", " hello\nworld 😀").
+		Set("empty", nil)
+
+	data, err := obj.ToProperties()
+	require.NoError(t, err)
+	assert.Equal(t, `app\ name=demo\: caf\u00E9
+server.port=8080
+server.hosts.0=a
+server.hosts.1=b
+motd=\ hello\nworld \uD83D\uDE00
+empty=
+`, string(data))
+
+	parsed, err := FromProperties(data)
+	require.NoError(t, err)
+	name, _ := parsed.Get("app name")
+	assert.Equal(t, "demo: café", name)
+	motd, _ := parsed.Get("motd")
+	assert.Equal(t, " hello\nworld 😀", motd)
+	port, _ := parsed.GetPath("server.port")
+	assert.Equal(t, "8080", port)
+}
+
+func TestFromProperties(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`# comment
+! another comment
+db.url = jdbc:postgresql://localhost/app
+db.user:admin
+db.pool  10
+message = first \
+          second
+empty
+`)
+	obj, err := FromProperties(data)
+	require.NoError(t, err)
+
+	out, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"db":{"url":"jdbc:postgresql://localhost/app","user":"admin","pool":"10"},"message":"first second","empty":""}`,
+		string(out))
+
+	_, err = FromProperties([]byte(`key=\u12`))
+	require.ErrorIs(t, err, ErrInvalidProperties)
+	_, err = FromProperties([]byte("a=1\na.b=2\n"))
+	require.ErrorIs(t, err, ErrPathConflict)
+}
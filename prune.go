@@ -0,0 +1,138 @@
+package orderedobject
+
+import (
+	"reflect"
+	"slices"
+)
+
+// DeleteMany removes the key-value pairs for all keys in a single pass.
+// Keys that do not exist are ignored, and soft-deleted entries with those keys are discarded.
+// Returns the object for chaining.
+func (object *Object[V]) DeleteMany(keys ...string) *Object[V] {
+	remove := make(map[int]bool, len(keys))
+	for _, key := range keys {
+		if idx := object.findKeyIndex(key); idx >= 0 {
+			remove[idx] = true
+		} else {
+			object.forgetDeleted(object.resolveKey(key))
+		}
+	}
+	object.removeIndexes(remove)
+	return object
+}
+
+// DeleteFunc removes every key-value pair for which pred returns true in a single pass.
+// Returns the object for chaining.
+func (object *Object[V]) DeleteFunc(pred func(key string, value V) bool) *Object[V] {
+	var removed []Entry[V]
+	object.entries = slices.DeleteFunc(object.entries, func(entry Entry[V]) bool {
+		if !pred(entry.Key, entry.Value) {
+			return false
+		}
+		if object.observed() {
+			removed = append(removed, entry)
+		}
+		return true
+	})
+	for _, entry := range removed {
+		object.notifyDelete(entry)
+	}
+	return object
+}
+
+// RetainKeys removes every key-value pair whose key is not in keys, keeping the
+// remaining pairs in their current order.
+// Returns the object for chaining.
+func (object *Object[V]) RetainKeys(keys []string) *Object[V] {
+	keep := make(map[int]bool, len(keys))
+	for _, key := range keys {
+		if idx := object.findKeyIndex(key); idx >= 0 {
+			keep[idx] = true
+		}
+	}
+	remove := make(map[int]bool, len(object.entries)-len(keep))
+	for i := range object.entries {
+		if !keep[i] {
+			remove[i] = true
+		}
+	}
+	object.removeIndexes(remove)
+	return object
+}
+
+// removeIndexes removes the entries at the given indexes, preserving the order of the rest.
+func (object *Object[V]) removeIndexes(remove map[int]bool) {
+	if len(remove) == 0 {
+		return
+	}
+	var removed []Entry[V]
+	n := 0
+	for i, entry := range object.entries {
+		if !remove[i] {
+			object.entries[n] = entry
+			n++
+			continue
+		}
+		object.forgetExpiry(entry.Key)
+		if object.observed() {
+			removed = append(removed, entry)
+		}
+	}
+	clear(object.entries[n:])
+	object.entries = object.entries[:n]
+	for _, entry := range removed {
+		object.notifyDelete(entry)
+	}
+}
+
+// Compact removes entries whose value is nil or the zero value of its type, and
+// compacts nested *Object[any] values, including those inside []any values, the same way.
+// If pred is given, it decides instead which entries are removed at every level.
+// Returns the object for chaining.
+func (object *Object[V]) Compact(pred ...func(key string, value any) bool) *Object[V] {
+	remove := isZeroEntry
+	if len(pred) > 0 && pred[0] != nil {
+		remove = pred[0]
+	}
+	compactObject(object, remove)
+	return object
+}
+
+// compactObject removes matching entries from object and recurses into nested objects.
+func compactObject[V any](object *Object[V], remove func(key string, value any) bool) {
+	var removed []Entry[V]
+	object.entries = slices.DeleteFunc(object.entries, func(entry Entry[V]) bool {
+		if !remove(entry.Key, any(entry.Value)) {
+			return false
+		}
+		if object.observed() {
+			removed = append(removed, entry)
+		}
+		return true
+	})
+	for _, entry := range removed {
+		object.notifyDelete(entry)
+	}
+	for _, entry := range object.entries {
+		compactValue(any(entry.Value), remove)
+	}
+}
+
+// compactValue compacts nested objects within value.
+func compactValue(value any, remove func(key string, value any) bool) {
+	switch v := value.(type) {
+	case *Object[any]:
+		if v != nil {
+			compactObject(v, remove)
+		}
+	case []any:
+		for _, item := range v {
+			compactValue(item, remove)
+		}
+	}
+}
+
+// isZeroEntry reports whether value is nil or the zero value of its type.
+func isZeroEntry(_ string, value any) bool {
+	return value == nil || reflect.ValueOf(value).IsZero()
+}
@@ -0,0 +1,78 @@
+package orderedobject
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteMany(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("a", 1).Set("b", 2).Set("c", 3).Set("d", 4)
+
+	obj.DeleteMany("b", "d", "missing")
+	assert.Equal(t, []string{"a", "c"}, obj.Keys())
+	assert.Equal(t, []int{1, 3}, obj.Values())
+
+	obj.DeleteMany()
+	assert.Equal(t, 2, obj.Length())
+}
+
+func TestDeleteFunc(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("x_tmp", 1).Set("a", 2).Set("y_tmp", 3)
+
+	obj.DeleteFunc(func(key string, _ int) bool {
+		return strings.HasSuffix(key, "_tmp")
+	})
+	assert.Equal(t, []string{"a"}, obj.Keys())
+}
+
+func TestRetainKeys(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("a", 1).Set("b", 2).Set("c", 3)
+
+	obj.RetainKeys([]string{"c", "a", "missing"})
+	assert.Equal(t, []string{"a", "c"}, obj.Keys())
+
+	obj.RetainKeys(nil)
+	assert.True(t, obj.IsEmpty())
+}
+
+func TestCompact(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("name", "app").
+		Set("empty", "").
+		Set("nil", nil).
+		Set("zero", 0).
+		Set("server", NewObject[any]().Set("host", "").Set("port", 8080)).
+		Set("items", []any{NewObject[any]().Set("a", nil).Set("b", 1)})
+
+	obj.Compact()
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"app","server":{"port":8080},"items":[{"b":1}]}`, string(data))
+}
+
+func TestCompactPredicate(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("a", nil).
+		Set("b", 0).
+		Set("nested", NewObject[any]().Set("c", nil).Set("d", false))
+
+	obj.Compact(func(_ string, value any) bool {
+		return value == nil
+	})
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"b":0,"nested":{"d":false}}`, string(data))
+}
@@ -0,0 +1,65 @@
+package orderedobject
+
+// Pop removes the key-value pair for key and returns its value.
+// It returns the zero value and false if the key does not exist.
+func (object *Object[V]) Pop(key string) (V, bool) {
+	idx := object.findKeyIndex(key)
+	if idx < 0 {
+		var zero V
+		return zero, false
+	}
+	value := object.entries[idx].Value
+	object.removeIndex(idx)
+	return value, true
+}
+
+// PopFirst removes and returns the first key-value pair.
+// It returns false if the object is empty.
+func (object *Object[V]) PopFirst() (Entry[V], bool) {
+	object.expire()
+	if len(object.entries) == 0 {
+		return Entry[V]{}, false
+	}
+	entry := object.entries[0]
+	object.removeIndex(0)
+	return entry, true
+}
+
+// PopLast removes and returns the last key-value pair.
+// It returns false if the object is empty.
+func (object *Object[V]) PopLast() (Entry[V], bool) {
+	object.expire()
+	if len(object.entries) == 0 {
+		return Entry[V]{}, false
+	}
+	idx := len(object.entries) - 1
+	entry := object.entries[idx]
+	object.removeIndex(idx)
+	return entry, true
+}
+
+// First returns the first key-value pair without removing it.
+// It returns false if the object is empty.
+func (object *Object[V]) First() (Entry[V], bool) {
+	object.expire()
+	if len(object.entries) == 0 {
+		return Entry[V]{}, false
+	}
+	return object.entries[0], true
+}
+
+// Last returns the last key-value pair without removing it.
+// It returns false if the object is empty.
+func (object *Object[V]) Last() (Entry[V], bool) {
+	object.expire()
+	if len(object.entries) == 0 {
+		return Entry[V]{}, false
+	}
+	return object.entries[len(object.entries)-1], true
+}
+
+// IsEmpty returns whether the ordered object has no key-value pairs.
+func (object *Object[V]) IsEmpty() bool {
+	object.expire()
+	return len(object.entries) == 0
+}
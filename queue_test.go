@@ -0,0 +1,67 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPop(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("a", 1).Set("b", 2).Set("c", 3)
+
+	value, ok := obj.Pop("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, []string{"a", "c"}, obj.Keys())
+
+	_, ok = obj.Pop("b")
+	assert.False(t, ok)
+}
+
+func TestPopFirstLast(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("a", 1).Set("b", 2).Set("c", 3)
+
+	entry, ok := obj.PopFirst()
+	assert.True(t, ok)
+	assert.Equal(t, Entry[int]{Key: "a", Value: 1}, entry)
+
+	entry, ok = obj.PopLast()
+	assert.True(t, ok)
+	assert.Equal(t, Entry[int]{Key: "c", Value: 3}, entry)
+
+	entry, ok = obj.PopLast()
+	assert.True(t, ok)
+	assert.Equal(t, "b", entry.Key)
+
+	_, ok = obj.PopFirst()
+	assert.False(t, ok)
+	_, ok = obj.PopLast()
+	assert.False(t, ok)
+}
+
+func TestFirstLast(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]()
+	assert.True(t, obj.IsEmpty())
+	_, ok := obj.First()
+	assert.False(t, ok)
+	_, ok = obj.Last()
+	assert.False(t, ok)
+
+	obj.Set("a", 1).Set("b", 2)
+	assert.False(t, obj.IsEmpty())
+
+	first, ok := obj.First()
+	assert.True(t, ok)
+	assert.Equal(t, Entry[int]{Key: "a", Value: 1}, first)
+
+	last, ok := obj.Last()
+	assert.True(t, ok)
+	assert.Equal(t, Entry[int]{Key: "b", Value: 2}, last)
+	assert.Equal(t, 2, obj.Length())
+}
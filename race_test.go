@@ -0,0 +1,7 @@
+//go:build race
+
+package orderedobject
+
+// raceEnabled reports whether tests run with the race detector, which adds
+// allocations that break exact allocation counts.
+const raceEnabled = true
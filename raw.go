@@ -0,0 +1,37 @@
+package orderedobject
+
+import (
+	"fmt"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// FromJSONRaw creates an ordered object from JSON, keeping each top-level value as its
+// raw encoding. Only the top level is parsed, so callers reading a few fields of a large
+// document can decode just those with DecodeValue. The object marshals back to the
+// original values unchanged.
+func FromJSONRaw(data []byte) (*Object[jsontext.Value], error) {
+	return FromJSON[jsontext.Value](data)
+}
+
+// DecodeValue decodes the raw value for key into target, which must be a pointer.
+// It returns ErrKeyNotFound if the key does not exist.
+func DecodeValue(obj *Object[jsontext.Value], key string, target any) error {
+	value, ok := obj.Get(key)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrKeyNotFound, key)
+	}
+	if err := json.Unmarshal(value, target); err != nil {
+		return fmt.Errorf("failed to decode %q: %w", key, err)
+	}
+	return nil
+}
+
+// DecodeValueAs decodes the raw value for key as a T.
+// It returns ErrKeyNotFound if the key does not exist.
+func DecodeValueAs[T any](obj *Object[jsontext.Value], key string) (T, error) {
+	var result T
+	err := DecodeValue(obj, key, &result)
+	return result, err
+}
@@ -0,0 +1,57 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromJSONRaw(t *testing.T) {
+	t.Parallel()
+
+	input := `{"id":42,"user":{"name":"alice","tags":["a","b"]},"payload":[1, 2, 3],"note":null}`
+	obj, err := FromJSONRaw([]byte(input))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "user", "payload", "note"}, obj.Keys())
+
+	user, _ := obj.Get("user")
+	assert.Equal(t, jsontext.Value(`{"name":"alice","tags":["a","b"]}`), user)
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, input, string(data))
+
+	_, err = FromJSONRaw([]byte(`[1]`))
+	assert.ErrorIs(t, err, ErrExpectedObjectStart)
+}
+
+func TestDecodeValue(t *testing.T) {
+	t.Parallel()
+
+	obj, err := FromJSONRaw([]byte(`{"id":42,"user":{"name":"alice","tags":["a","b"]}}`))
+	require.NoError(t, err)
+
+	var user struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+	require.NoError(t, DecodeValue(obj, "user", &user))
+	assert.Equal(t, "alice", user.Name)
+	assert.Equal(t, []string{"a", "b"}, user.Tags)
+
+	id, err := DecodeValueAs[int](obj, "id")
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	nested, err := DecodeValueAs[*Object[any]](obj, "user")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "tags"}, nested.Keys())
+
+	_, err = DecodeValueAs[int](obj, "missing")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = DecodeValueAs[string](obj, "id")
+	assert.ErrorContains(t, err, `failed to decode "id"`)
+}
@@ -0,0 +1,85 @@
+package orderedobject
+
+import "regexp"
+
+// RedactedValue replaces the values of redacted keys in the encoded output.
+const RedactedValue = "***"
+
+// DefaultRedactPatterns are the key patterns Redact uses when none are given.
+var DefaultRedactPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)password`),
+	regexp.MustCompile(`(?i)token`),
+	regexp.MustCompile(`(?i)secret`),
+}
+
+// Redact controls whether marshaling replaces the values of keys matching any of the
+// patterns with RedactedValue, so the object can be logged safely. Keys of nested
+// objects and maps are redacted as well. Without patterns, DefaultRedactPatterns is used.
+// Only the encoded output is affected; the stored values are unchanged.
+// Returns the object for chaining.
+func (object *Object[V]) Redact(enabled bool, patterns ...*regexp.Regexp) *Object[V] {
+	ext := object.extend()
+	switch {
+	case !enabled:
+		ext.redact = nil
+	case len(patterns) > 0:
+		ext.redact = patterns
+	default:
+		ext.redact = DefaultRedactPatterns
+	}
+	return object
+}
+
+// redactEntry returns the value to encode for key, redacting it if the key matches
+// a pattern and redacting nested keys otherwise.
+func redactEntry(key string, value any, patterns []*regexp.Regexp) any {
+	if matchesAny(key, patterns) {
+		return RedactedValue
+	}
+	return redactValue(value, patterns)
+}
+
+// redactValue returns a copy of value with the values of matching keys redacted
+// in nested objects, maps and slices.
+func redactValue(value any, patterns []*regexp.Regexp) any {
+	switch v := value.(type) {
+	case *Object[any]:
+		if v == nil {
+			return v
+		}
+		obj := v.Clone()
+		for i, entry := range obj.entries {
+			obj.entries[i].Value = redactEntry(entry.Key, entry.Value, patterns)
+		}
+		return obj
+	case map[string]any:
+		if v == nil {
+			return v
+		}
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[k] = redactEntry(k, item, patterns)
+		}
+		return m
+	case []any:
+		if v == nil {
+			return v
+		}
+		s := make([]any, len(v))
+		for i, item := range v {
+			s[i] = redactValue(item, patterns)
+		}
+		return s
+	}
+	return value
+}
+
+// matchesAny reports whether key matches any of the patterns.
+func matchesAny(key string, patterns []*regexp.Regexp) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package orderedobject
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("user", "admin").
+		Set("password", "hunter2").
+		Set("db", NewObject[any]().
+			Set("host", "localhost").
+			Set("DB_PASSWORD", "pw")).
+		Set("clients", []any{map[string]any{"id": "c1", "client_secret": "s"}}).
+		Set("accessToken", NewObject[any]().Set("value", "t"))
+
+	obj.Redact(true)
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"user":"admin","password":"***","db":{"host":"localhost","DB_PASSWORD":"***"},"clients":[{"client_secret":"***","id":"c1"}],"accessToken":"***"}`,
+		string(data))
+
+	// Stored values are unchanged
+	password, _ := obj.Get("password")
+	assert.Equal(t, "hunter2", password)
+	db, _ := obj.Get("db")
+	dbPassword, _ := db.(*Object[any]).Get("DB_PASSWORD")
+	assert.Equal(t, "pw", dbPassword)
+
+	obj.Redact(false)
+	data, err = obj.ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "hunter2")
+}
+
+func TestRedactCustomPatterns(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[string]().
+		Set("ssn", "123-45-6789").
+		Set("password", "visible").
+		Redact(true, regexp.MustCompile(`^ssn$`))
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"ssn":"***","password":"visible"}`, string(data))
+}
@@ -0,0 +1,94 @@
+package orderedobject
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RefViolation describes a reference that does not resolve to a key of the target object.
+type RefViolation struct {
+	// Path is the key path from the root object to the reference. Array elements
+	// are identified by their index.
+	Path []string
+	// Ref is the referenced key that is missing from the target object.
+	Ref string
+}
+
+// Error returns a description of the violation.
+func (violation RefViolation) Error() string {
+	return fmt.Sprintf("%s: reference %q not found", formatPath(violation.Path), violation.Ref)
+}
+
+// ValidateRefs checks that every value stored under a key matching refKeyPattern in
+// source, at any depth, exists as a key in target. The pattern uses path.Match syntax,
+// such as "*_id". Array values under a matching key are checked element by element,
+// scalars are compared by their string form and nil values are ignored.
+// Violations are reported in document order. An error is returned only for a malformed pattern.
+func ValidateRefs[T any](source *Object[any], refKeyPattern string, target *Object[T]) ([]RefViolation, error) {
+	if _, err := path.Match(refKeyPattern, ""); err != nil {
+		return nil, err
+	}
+	check := func(p []string, value any) []RefViolation {
+		return checkRefs(p, value, target)
+	}
+	return walkRefs(nil, source, refKeyPattern, check), nil
+}
+
+// walkRefs collects reference violations below value.
+func walkRefs(p []string, value any, pattern string, check func([]string, any) []RefViolation) []RefViolation {
+	var violations []RefViolation
+	visit := func(key string, v any) {
+		kp := appendPath(p, key)
+		if matched, _ := path.Match(pattern, key); matched {
+			violations = append(violations, check(kp, v)...)
+			return
+		}
+		violations = append(violations, walkRefs(kp, v, pattern, check)...)
+	}
+
+	switch v := value.(type) {
+	case *Object[any]:
+		if v != nil {
+			for _, entry := range v.entries {
+				visit(entry.Key, entry.Value)
+			}
+		}
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			visit(k, v[k])
+		}
+	case []any:
+		for i, item := range v {
+			violations = append(violations, walkRefs(appendPath(p, strconv.Itoa(i)), item, pattern, check)...)
+		}
+	}
+	return violations
+}
+
+// checkRefs reports the references held by value that are missing from target.
+func checkRefs[T any](p []string, value any, target *Object[T]) []RefViolation {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		var violations []RefViolation
+		for i, item := range v {
+			violations = append(violations, checkRefs(appendPath(p, strconv.Itoa(i)), item, target)...)
+		}
+		return violations
+	}
+	ref := fmt.Sprint(value)
+	if target.Has(ref) {
+		return nil
+	}
+	return []RefViolation{{Path: p, Ref: ref}}
+}
+
+// formatPath joins a key path with dots for display.
+func formatPath(p []string) string {
+	return strings.Join(p, ".")
+}
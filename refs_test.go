@@ -0,0 +1,43 @@
+package orderedobject
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRefs(t *testing.T) {
+	t.Parallel()
+
+	users := NewObject[any]().
+		Set("alice", NewObject[any]().Set("role", "admin")).
+		Set("bob", NewObject[any]().Set("role", "dev"))
+
+	projects := NewObject[any]().
+		Set("web", NewObject[any]().
+			Set("owner_id", "alice").
+			Set("member_ids", []any{"bob", "carol"})).
+		Set("api", NewObject[any]().
+			Set("owner_id", "dave").
+			Set("reviewer_id", nil).
+			Set("tasks", []any{map[string]any{"assignee_id": "bob"}, map[string]any{"assignee_id": "eve"}}))
+
+	violations, err := ValidateRefs(projects, "*_id", users)
+	require.NoError(t, err)
+	assert.Equal(t, []RefViolation{
+		{Path: []string{"api", "owner_id"}, Ref: "dave"},
+		{Path: []string{"api", "tasks", "1", "assignee_id"}, Ref: "eve"},
+	}, violations)
+	assert.Equal(t, `api.owner_id: reference "dave" not found`, violations[0].Error())
+
+	violations, err = ValidateRefs(projects, "*_ids", users)
+	require.NoError(t, err)
+	assert.Equal(t, []RefViolation{
+		{Path: []string{"web", "member_ids", "1"}, Ref: "carol"},
+	}, violations)
+
+	_, err = ValidateRefs(projects, "[", users)
+	assert.ErrorIs(t, err, path.ErrBadPattern)
+}
@@ -0,0 +1,80 @@
+package orderedobject
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// RowsToObjects reads all remaining rows into ordered objects keyed by column name in
+// SELECT order, so query results serialize with the columns in the order the query
+// specified. []byte column values are converted to strings. A result set naming a
+// column twice, as joins often do, returns ErrDuplicateKey; alias the columns apart.
+// The rows are closed.
+func RowsToObjects(rows *sql.Rows) ([]*Object[any], error) {
+	defer rows.Close()
+	columns, err := rowColumns(rows)
+	if err != nil {
+		return nil, err
+	}
+	var objects []*Object[any]
+	for rows.Next() {
+		obj, err := scanRow(rows, columns)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// RowToObject reads the current row into an ordered object keyed by column name in
+// SELECT order. It must be called after rows.Next returns true.
+// []byte column values are converted to strings. A result set naming a column twice
+// returns ErrDuplicateKey.
+func RowToObject(rows *sql.Rows) (*Object[any], error) {
+	columns, err := rowColumns(rows)
+	if err != nil {
+		return nil, err
+	}
+	return scanRow(rows, columns)
+}
+
+// rowColumns returns the column names of rows, which must be unique to be keys.
+func rowColumns(rows *sql.Rows) ([]string, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		if seen[column] {
+			return nil, fmt.Errorf("%w: column %q", ErrDuplicateKey, column)
+		}
+		seen[column] = true
+	}
+	return columns, nil
+}
+
+// scanRow scans the current row into an ordered object with the given columns.
+func scanRow(rows *sql.Rows, columns []string) (*Object[any], error) {
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	obj := NewObject[any](len(columns))
+	for i, column := range columns {
+		value := values[i]
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+		obj.entries = append(obj.entries, Entry[any]{Key: column, Value: value})
+	}
+	return obj, nil
+}
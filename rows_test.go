@@ -0,0 +1,118 @@
+package orderedobject
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver serves fixed rows for every query, with the columns it selects.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query: query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type fakeStmt struct {
+	query string
+}
+
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	list, _, _ := strings.Cut(strings.TrimPrefix(s.query, "SELECT "), " FROM")
+	return &fakeRows{columns: strings.Split(list, ", "), data: [][]driver.Value{
+		{int64(1), []byte("alice"), true},
+		{int64(2), []byte("bob"), nil},
+	}}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	data    [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (*fakeRows) Close() error        { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.data) {
+		return io.EOF
+	}
+	copy(dest, r.data[r.next])
+	r.next++
+	return nil
+}
+
+func init() {
+	sql.Register("orderedobject-fake", fakeDriver{})
+}
+
+func TestRowsToObjects(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("orderedobject-fake", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows, err := db.QueryContext(context.Background(), "SELECT zid, name, active FROM users")
+	require.NoError(t, err)
+	objects, err := RowsToObjects(rows)
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	data, err := objects[0].ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"zid":1,"name":"alice","active":true}`, string(data))
+	assert.Equal(t, []any{int64(2), "bob", nil}, objects[1].Values())
+}
+
+func TestRowToObject(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("orderedobject-fake", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows, err := db.QueryContext(context.Background(), "SELECT zid, name, active FROM users")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	require.True(t, rows.Next())
+	obj, err := RowToObject(rows)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zid", "name", "active"}, obj.Keys())
+	assert.Equal(t, "alice", obj.Values()[1])
+}
+
+func TestRowsDuplicateColumns(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("orderedobject-fake", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows, err := db.QueryContext(context.Background(), "SELECT id, name, id FROM users JOIN teams")
+	require.NoError(t, err)
+	_, err = RowsToObjects(rows)
+	require.ErrorIs(t, err, ErrDuplicateKey)
+	assert.ErrorContains(t, err, `column "id"`)
+
+	rows, err = db.QueryContext(context.Background(), "SELECT id, name, id FROM users JOIN teams")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+	_, err = RowToObject(rows)
+	require.ErrorIs(t, err, ErrDuplicateKey)
+}
@@ -0,0 +1,793 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidSchema is returned by Validate when the schema itself is malformed
+var ErrInvalidSchema = errors.New("invalid schema")
+
+// maxSchemaRefDepth bounds the number of $ref indirections followed at once, so that
+// a reference cycle that never descends into the instance cannot recurse forever.
+const maxSchemaRefDepth = 1000
+
+// SchemaViolation describes a value that does not satisfy a keyword of a JSON Schema.
+type SchemaViolation struct {
+	// Path is the key path from the root object to the value. Array elements
+	// are identified by their index, and the root object by an empty path.
+	Path []string
+	// Keyword is the schema keyword that failed, such as "required" or "type".
+	Keyword string
+	// Message describes the failure.
+	Message string
+}
+
+// Error returns a description of the violation.
+func (violation SchemaViolation) Error() string {
+	p := formatPath(violation.Path)
+	if p == "" {
+		p = "(root)"
+	}
+	return fmt.Sprintf("%s: %s", p, violation.Message)
+}
+
+// Validate checks the object against a JSON Schema (draft 2020-12), given as an
+// object such as one returned by FromJSONDeep. The object is validated in place, with
+// nested *Object[any] values, maps and slices descended into, and violations are
+// reported in document order: those of a container come before those of its members,
+// which follow the order of the entries.
+//
+// The validation keywords of the core and applicator vocabularies are supported,
+// including local references ("#" and "#/$defs/name"). Format and the other annotation
+// keywords are ignored, as are unevaluatedProperties and unevaluatedItems. Patterns
+// use Go regular expression syntax.
+// A nil slice is returned for a valid object. An error wrapping ErrInvalidSchema is
+// returned only for a malformed schema.
+func (object *Object[V]) Validate(schema *Object[any]) ([]SchemaViolation, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("%w: nil schema", ErrInvalidSchema)
+	}
+	v := &schemaValidator{root: schema}
+	violations := v.validate(nil, object.schemaInstance(), schema)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return violations, nil
+}
+
+// schemaInstance returns the object as a value the validator can descend into,
+// without copying it when V is any.
+func (object *Object[V]) schemaInstance() any {
+	if obj, ok := any(object).(*Object[any]); ok {
+		return obj
+	}
+	object.expire()
+	entries := make([]Entry[any], len(object.entries))
+	for i, entry := range object.entries {
+		entries[i] = Entry[any]{Key: entry.Key, Value: entry.Value}
+	}
+	return &Object[any]{entries: entries}
+}
+
+// schemaValidator holds the state of a validation: the root schema for resolving
+// references, compiled patterns and the first schema error found.
+type schemaValidator struct {
+	root     *Object[any]
+	patterns map[string]*regexp.Regexp
+	refDepth int
+	err      error
+}
+
+// fail records a schema error. Only the first one is kept.
+func (v *schemaValidator) fail(keyword, format string, args ...any) {
+	if v.err == nil {
+		v.err = fmt.Errorf("%w: %s: %s", ErrInvalidSchema, keyword, fmt.Sprintf(format, args...))
+	}
+}
+
+// valid reports whether value satisfies schema.
+func (v *schemaValidator) valid(path []string, value any, schema any) bool {
+	return len(v.validate(path, value, schema)) == 0
+}
+
+// validate returns the violations of schema by the value at path.
+func (v *schemaValidator) validate(path []string, value any, schema any) []SchemaViolation {
+	if v.err != nil {
+		return nil
+	}
+	var s *Object[any]
+	switch x := schema.(type) {
+	case bool:
+		if x {
+			return nil
+		}
+		return []SchemaViolation{{Path: path, Keyword: "false", Message: "no value is allowed"}}
+	case *Object[any]:
+		if x == nil {
+			v.fail("schema", "nil subschema")
+			return nil
+		}
+		s = x
+	default:
+		v.fail("schema", "expected object or boolean, got %T", schema)
+		return nil
+	}
+
+	var violations []SchemaViolation
+	report := func(keyword, format string, args ...any) {
+		violations = append(violations, SchemaViolation{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+	for _, entry := range s.entries {
+		violations = append(violations, v.keyword(path, value, s, entry.Key, entry.Value, report)...)
+		if v.err != nil {
+			return nil
+		}
+	}
+
+	if members, ok := schemaMembers(value); ok {
+		violations = append(violations, v.members(path, members, s)...)
+	} else if items, ok := schemaItems(value); ok {
+		violations = append(violations, v.items(path, items, s)...)
+	}
+	if v.err != nil {
+		return nil
+	}
+	return violations
+}
+
+// keyword applies a keyword that concerns the value as a whole, calling report for
+// its own failures and returning the violations of the subschemas it applies.
+func (v *schemaValidator) keyword(path []string, value any, s *Object[any], name string, arg any, report func(keyword, format string, args ...any)) []SchemaViolation {
+	switch name {
+	case "$ref":
+		return v.ref(path, value, arg)
+	case "type":
+		v.checkType(value, arg, report)
+	case "enum":
+		values, ok := arg.([]any)
+		if !ok {
+			v.fail(name, "expected array, got %T", arg)
+			return nil
+		}
+		if !slices.ContainsFunc(values, func(item any) bool { return schemaEqual(value, item) }) {
+			report(name, "value is not one of the allowed values")
+		}
+	case "const":
+		if !schemaEqual(value, arg) {
+			report(name, "value does not equal the constant")
+		}
+	case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf":
+		v.checkNumber(value, name, arg, report)
+	case "minLength", "maxLength", "pattern":
+		v.checkString(value, name, arg, report)
+	case "minItems", "maxItems", "uniqueItems":
+		v.checkArray(value, name, arg, report)
+	case "contains":
+		return v.contains(path, value, s, arg, report)
+	case "required", "minProperties", "maxProperties", "dependentRequired":
+		v.checkObject(value, name, arg, report)
+	case "dependentSchemas":
+		return v.dependentSchemas(path, value, arg)
+	case "allOf", "anyOf", "oneOf":
+		return v.combine(path, value, name, arg, report)
+	case "not":
+		if v.valid(path, value, arg) {
+			report(name, "value must not match the schema")
+		}
+	case "if":
+		branch := "else"
+		if v.valid(path, value, arg) {
+			branch = "then"
+		}
+		if sub, ok := s.Get(branch); ok {
+			return v.validate(path, value, sub)
+		}
+	}
+	return nil
+}
+
+// ref validates the value against the schema a local reference points to.
+func (v *schemaValidator) ref(path []string, value any, arg any) []SchemaViolation {
+	ref, ok := arg.(string)
+	if !ok {
+		v.fail("$ref", "expected string, got %T", arg)
+		return nil
+	}
+	target, ok := v.resolve(ref)
+	if !ok {
+		v.fail("$ref", "cannot resolve %q", ref)
+		return nil
+	}
+	if v.refDepth >= maxSchemaRefDepth {
+		v.fail("$ref", "too many nested references at %q", ref)
+		return nil
+	}
+	v.refDepth++
+	defer func() { v.refDepth-- }()
+	return v.validate(path, value, target)
+}
+
+// resolve looks up a JSON pointer fragment such as "#/$defs/name" in the root schema.
+func (v *schemaValidator) resolve(ref string) (any, bool) {
+	fragment, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, false
+	}
+	fragment, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, false
+	}
+	var current any = v.root
+	if fragment == "" {
+		return current, true
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return nil, false
+	}
+	for _, segment := range strings.Split(fragment[1:], "/") {
+		segment = pointerUnescaper.Replace(segment)
+		switch c := current.(type) {
+		case *Object[any]:
+			if current, ok = c.Get(segment); !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			current = c[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// checkType reports a value whose type is not among the ones listed by arg.
+func (v *schemaValidator) checkType(value any, arg any, report func(keyword, format string, args ...any)) {
+	var types []string
+	switch t := arg.(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok {
+				v.fail("type", "expected string, got %T", item)
+				return
+			}
+			types = append(types, name)
+		}
+	default:
+		v.fail("type", "expected string or array, got %T", arg)
+		return
+	}
+	actual := schemaType(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return
+		}
+	}
+	report("type", "expected %s, got %s", strings.Join(types, " or "), actual)
+}
+
+// checkNumber applies a numeric keyword to a number. Other values are ignored.
+func (v *schemaValidator) checkNumber(value any, name string, arg any, report func(keyword, format string, args ...any)) {
+	limit, ok := schemaNumber(arg)
+	if !ok {
+		v.fail(name, "expected number, got %T", arg)
+		return
+	}
+	n, ok := schemaNumber(value)
+	if !ok {
+		return
+	}
+	switch name {
+	case "minimum":
+		if n < limit {
+			report(name, "%v is less than %v", n, limit)
+		}
+	case "maximum":
+		if n > limit {
+			report(name, "%v is greater than %v", n, limit)
+		}
+	case "exclusiveMinimum":
+		if n <= limit {
+			report(name, "%v is not greater than %v", n, limit)
+		}
+	case "exclusiveMaximum":
+		if n >= limit {
+			report(name, "%v is not less than %v", n, limit)
+		}
+	case "multipleOf":
+		if limit <= 0 {
+			v.fail(name, "expected positive number, got %v", limit)
+			return
+		}
+		if q := n / limit; math.IsInf(q, 0) || math.Abs(q-math.Round(q)) > 1e-9 {
+			report(name, "%v is not a multiple of %v", n, limit)
+		}
+	}
+}
+
+// checkString applies a string keyword to a string. Other values are ignored.
+func (v *schemaValidator) checkString(value any, name string, arg any, report func(keyword, format string, args ...any)) {
+	if name == "pattern" {
+		re := v.pattern(name, arg)
+		if s, ok := value.(string); ok && re != nil && !re.MatchString(s) {
+			report(name, "%q does not match %q", s, re.String())
+		}
+		return
+	}
+	limit, ok := schemaCount(arg)
+	if !ok {
+		v.fail(name, "expected non-negative integer, got %v", arg)
+		return
+	}
+	s, ok := value.(string)
+	if !ok {
+		return
+	}
+	n := utf8.RuneCountInString(s)
+	if name == "minLength" && n < limit {
+		report(name, "length %d is less than %d", n, limit)
+	} else if name == "maxLength" && n > limit {
+		report(name, "length %d is greater than %d", n, limit)
+	}
+}
+
+// pattern compiles the regular expression held by arg, caching it for the validation.
+func (v *schemaValidator) pattern(keyword string, arg any) *regexp.Regexp {
+	expr, ok := arg.(string)
+	if !ok {
+		v.fail(keyword, "expected string, got %T", arg)
+		return nil
+	}
+	if re, ok := v.patterns[expr]; ok {
+		return re
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		v.fail(keyword, "%v", err)
+		return nil
+	}
+	if v.patterns == nil {
+		v.patterns = make(map[string]*regexp.Regexp)
+	}
+	v.patterns[expr] = re
+	return re
+}
+
+// checkArray applies an array keyword to an array. Other values are ignored.
+func (v *schemaValidator) checkArray(value any, name string, arg any, report func(keyword, format string, args ...any)) {
+	if name == "uniqueItems" {
+		unique, ok := arg.(bool)
+		if !ok {
+			v.fail(name, "expected boolean, got %T", arg)
+			return
+		}
+		items, ok := schemaItems(value)
+		if !ok || !unique {
+			return
+		}
+		for i := range items {
+			for j := range i {
+				if schemaEqual(items[i], items[j]) {
+					report(name, "items %d and %d are equal", j, i)
+					return
+				}
+			}
+		}
+		return
+	}
+	limit, ok := schemaCount(arg)
+	if !ok {
+		v.fail(name, "expected non-negative integer, got %v", arg)
+		return
+	}
+	items, ok := schemaItems(value)
+	if !ok {
+		return
+	}
+	if name == "minItems" && len(items) < limit {
+		report(name, "%d items, expected at least %d", len(items), limit)
+	} else if name == "maxItems" && len(items) > limit {
+		report(name, "%d items, expected at most %d", len(items), limit)
+	}
+}
+
+// contains checks the number of array items matching the contains schema against
+// minContains, which defaults to 1, and maxContains.
+func (v *schemaValidator) contains(path []string, value any, s *Object[any], arg any, report func(keyword, format string, args ...any)) []SchemaViolation {
+	minimum, maximum := 1, -1
+	if n, ok := s.Get("minContains"); ok {
+		if minimum, ok = schemaCount(n); !ok {
+			v.fail("minContains", "expected non-negative integer, got %v", n)
+			return nil
+		}
+	}
+	if n, ok := s.Get("maxContains"); ok {
+		if maximum, ok = schemaCount(n); !ok {
+			v.fail("maxContains", "expected non-negative integer, got %v", n)
+			return nil
+		}
+	}
+	items, ok := schemaItems(value)
+	if !ok {
+		return nil
+	}
+	matched := 0
+	for i, item := range items {
+		if v.valid(appendPath(path, strconv.Itoa(i)), item, arg) {
+			matched++
+		}
+	}
+	switch {
+	case matched < minimum:
+		report("contains", "%d items match, expected at least %d", matched, minimum)
+	case maximum >= 0 && matched > maximum:
+		report("contains", "%d items match, expected at most %d", matched, maximum)
+	}
+	return nil
+}
+
+// checkObject applies an object keyword to an object. Other values are ignored.
+func (v *schemaValidator) checkObject(value any, name string, arg any, report func(keyword, format string, args ...any)) {
+	switch name {
+	case "required":
+		keys, ok := schemaStrings(arg)
+		if !ok {
+			v.fail(name, "expected array of strings, got %v", arg)
+			return
+		}
+		members, ok := schemaMembers(value)
+		if !ok {
+			return
+		}
+		for _, key := range keys {
+			if !hasMember(members, key) {
+				report(name, "missing required property %q", key)
+			}
+		}
+	case "dependentRequired":
+		deps, ok := arg.(*Object[any])
+		if !ok {
+			v.fail(name, "expected object, got %T", arg)
+			return
+		}
+		members, ok := schemaMembers(value)
+		if !ok {
+			return
+		}
+		for _, dep := range deps.entries {
+			keys, ok := schemaStrings(dep.Value)
+			if !ok {
+				v.fail(name, "expected array of strings for %q", dep.Key)
+				return
+			}
+			if !hasMember(members, dep.Key) {
+				continue
+			}
+			for _, key := range keys {
+				if !hasMember(members, key) {
+					report(name, "property %q requires property %q", dep.Key, key)
+				}
+			}
+		}
+	default:
+		limit, ok := schemaCount(arg)
+		if !ok {
+			v.fail(name, "expected non-negative integer, got %v", arg)
+			return
+		}
+		members, ok := schemaMembers(value)
+		if !ok {
+			return
+		}
+		if name == "minProperties" && len(members) < limit {
+			report(name, "%d properties, expected at least %d", len(members), limit)
+		} else if name == "maxProperties" && len(members) > limit {
+			report(name, "%d properties, expected at most %d", len(members), limit)
+		}
+	}
+}
+
+// dependentSchemas validates the object against the schemas of the keys it has.
+func (v *schemaValidator) dependentSchemas(path []string, value any, arg any) []SchemaViolation {
+	deps, ok := arg.(*Object[any])
+	if !ok {
+		v.fail("dependentSchemas", "expected object, got %T", arg)
+		return nil
+	}
+	members, ok := schemaMembers(value)
+	if !ok {
+		return nil
+	}
+	var violations []SchemaViolation
+	for _, dep := range deps.entries {
+		if hasMember(members, dep.Key) {
+			violations = append(violations, v.validate(path, value, dep.Value)...)
+		}
+	}
+	return violations
+}
+
+// combine applies allOf, anyOf or oneOf. The violations of every subschema are
+// returned for allOf, while anyOf and oneOf report a single violation.
+func (v *schemaValidator) combine(path []string, value any, name string, arg any, report func(keyword, format string, args ...any)) []SchemaViolation {
+	schemas, ok := arg.([]any)
+	if !ok || len(schemas) == 0 {
+		v.fail(name, "expected non-empty array, got %v", arg)
+		return nil
+	}
+	if name == "allOf" {
+		var violations []SchemaViolation
+		for _, schema := range schemas {
+			violations = append(violations, v.validate(path, value, schema)...)
+		}
+		return violations
+	}
+	matched := 0
+	for _, schema := range schemas {
+		if v.valid(path, value, schema) {
+			matched++
+		}
+	}
+	switch {
+	case matched == 0:
+		report(name, "value does not match any schema")
+	case name == "oneOf" && matched > 1:
+		report(name, "value matches %d schemas, expected exactly one", matched)
+	}
+	return nil
+}
+
+// members validates each member of an object, in order, against the subschemas
+// given by properties, patternProperties, additionalProperties and propertyNames.
+func (v *schemaValidator) members(path []string, members []Entry[any], s *Object[any]) []SchemaViolation {
+	var properties *Object[any]
+	if arg, ok := s.Get("properties"); ok {
+		if properties, ok = arg.(*Object[any]); !ok {
+			v.fail("properties", "expected object, got %T", arg)
+			return nil
+		}
+	}
+	type patternSchema struct {
+		re     *regexp.Regexp
+		schema any
+	}
+	var patterns []patternSchema
+	if arg, ok := s.Get("patternProperties"); ok {
+		obj, ok := arg.(*Object[any])
+		if !ok {
+			v.fail("patternProperties", "expected object, got %T", arg)
+			return nil
+		}
+		for _, entry := range obj.entries {
+			re := v.pattern("patternProperties", entry.Key)
+			if re == nil {
+				return nil
+			}
+			patterns = append(patterns, patternSchema{re: re, schema: entry.Value})
+		}
+	}
+	additional, hasAdditional := s.Get("additionalProperties")
+	names, hasNames := s.Get("propertyNames")
+
+	var violations []SchemaViolation
+	for _, member := range members {
+		memberPath := appendPath(path, member.Key)
+		if hasNames && !v.valid(memberPath, member.Key, names) {
+			violations = append(violations, SchemaViolation{
+				Path:    memberPath,
+				Keyword: "propertyNames",
+				Message: fmt.Sprintf("property name %q does not match the schema", member.Key),
+			})
+		}
+		evaluated := false
+		if properties != nil {
+			if schema, ok := properties.Get(member.Key); ok {
+				evaluated = true
+				violations = append(violations, v.validate(memberPath, member.Value, schema)...)
+			}
+		}
+		for _, p := range patterns {
+			if p.re.MatchString(member.Key) {
+				evaluated = true
+				violations = append(violations, v.validate(memberPath, member.Value, p.schema)...)
+			}
+		}
+		if !evaluated && hasAdditional {
+			if additional == false {
+				violations = append(violations, SchemaViolation{
+					Path:    memberPath,
+					Keyword: "additionalProperties",
+					Message: fmt.Sprintf("property %q is not allowed", member.Key),
+				})
+			} else {
+				violations = append(violations, v.validate(memberPath, member.Value, additional)...)
+			}
+		}
+		if v.err != nil {
+			return nil
+		}
+	}
+	return violations
+}
+
+// items validates each item of an array, in order, against prefixItems and items.
+func (v *schemaValidator) items(path []string, items []any, s *Object[any]) []SchemaViolation {
+	var prefix []any
+	if arg, ok := s.Get("prefixItems"); ok {
+		if prefix, ok = arg.([]any); !ok {
+			v.fail("prefixItems", "expected array, got %T", arg)
+			return nil
+		}
+	}
+	rest, hasRest := s.Get("items")
+
+	var violations []SchemaViolation
+	for i, item := range items {
+		itemPath := appendPath(path, strconv.Itoa(i))
+		switch {
+		case i < len(prefix):
+			violations = append(violations, v.validate(itemPath, item, prefix[i])...)
+		case hasRest && rest == false:
+			violations = append(violations, SchemaViolation{
+				Path:    itemPath,
+				Keyword: "items",
+				Message: fmt.Sprintf("%d items, expected at most %d", len(items), len(prefix)),
+			})
+		case hasRest:
+			violations = append(violations, v.validate(itemPath, item, rest)...)
+		}
+		if v.err != nil {
+			return nil
+		}
+	}
+	return violations
+}
+
+// schemaType returns the JSON Schema type name of a value. Whole numbers are
+// reported as "integer".
+func schemaType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	}
+	if _, ok := schemaMembers(value); ok {
+		return "object"
+	}
+	if _, ok := schemaItems(value); ok {
+		return "array"
+	}
+	if n, ok := schemaNumber(value); ok {
+		if n == math.Trunc(n) && !math.IsInf(n, 0) {
+			return "integer"
+		}
+		return "number"
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// schemaNumber converts a Go number to a float64. Numeric strings are not numbers.
+func schemaNumber(value any) (float64, bool) {
+	if _, ok := value.(string); ok {
+		return 0, false
+	}
+	return coerceFloat64(value)
+}
+
+// schemaCount converts a keyword argument to a non-negative int.
+func schemaCount(value any) (int, bool) {
+	n, ok := schemaNumber(value)
+	if !ok || n < 0 || n != math.Trunc(n) || n > math.MaxInt32 {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// schemaStrings converts a keyword argument to a slice of strings.
+func schemaStrings(value any) ([]string, bool) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	strs := make([]string, len(items))
+	for i, item := range items {
+		if strs[i], ok = item.(string); !ok {
+			return nil, false
+		}
+	}
+	return strs, true
+}
+
+// schemaMembers returns the members of an *Object[any] or a map, the latter in
+// sorted key order.
+func schemaMembers(value any) ([]Entry[any], bool) {
+	switch v := value.(type) {
+	case *Object[any]:
+		if v == nil {
+			return nil, false
+		}
+		v.expire()
+		return v.entries, true
+	case map[string]any:
+		members := make([]Entry[any], 0, len(v))
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			members = append(members, Entry[any]{Key: k, Value: v[k]})
+		}
+		return members, true
+	}
+	return nil, false
+}
+
+// schemaItems returns the items of a slice.
+func schemaItems(value any) ([]any, bool) {
+	if items, ok := value.([]any); ok {
+		return items, true
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice || rv.IsNil() {
+		return nil, false
+	}
+	items := make([]any, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
+
+// hasMember reports whether members contains key.
+func hasMember(members []Entry[any], key string) bool {
+	return slices.ContainsFunc(members, func(member Entry[any]) bool { return member.Key == key })
+}
+
+// schemaEqual reports whether two values are equal as JSON values: numbers are
+// compared by value and object members regardless of order.
+func schemaEqual(a, b any) bool {
+	if x, ok := schemaNumber(a); ok {
+		y, ok := schemaNumber(b)
+		return ok && x == y
+	}
+	if x, ok := schemaMembers(a); ok {
+		y, ok := schemaMembers(b)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for _, member := range x {
+			i := slices.IndexFunc(y, func(other Entry[any]) bool { return other.Key == member.Key })
+			if i < 0 || !schemaEqual(member.Value, y[i].Value) {
+				return false
+			}
+		}
+		return true
+	}
+	if x, ok := schemaItems(a); ok {
+		y, ok := schemaItems(b)
+		return ok && slices.EqualFunc(x, y, schemaEqual)
+	}
+	switch a.(type) {
+	case nil, bool, string:
+		return a == b
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// violationKeys returns the dotted paths and keywords of violations.
+func violationKeys(violations []SchemaViolation) []string {
+	var keys []string
+	for _, violation := range violations {
+		keys = append(keys, formatPath(violation.Path)+" "+violation.Keyword)
+	}
+	return keys
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	schema := MustFromJSONDeep([]byte(`{
+		"type": "object",
+		"required": ["name", "port"],
+		"properties": {
+			"name": {"type": "string", "minLength": 1},
+			"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+			"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true},
+			"server": {"$ref": "#/$defs/server"}
+		},
+		"additionalProperties": false,
+		"$defs": {
+			"server": {
+				"type": "object",
+				"properties": {"host": {"type": "string", "pattern": "^[a-z.]+$"}},
+				"required": ["host"]
+			}
+		}
+	}`))
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		obj := MustFromJSONDeep([]byte(`{"name":"app","port":8080,"tags":["a","b"],"server":{"host":"example.com"}}`))
+		violations, err := obj.Validate(schema)
+		require.NoError(t, err)
+		assert.Nil(t, violations)
+	})
+
+	t.Run("document order", func(t *testing.T) {
+		t.Parallel()
+		obj := MustFromJSONDeep([]byte(`{"server":{"host":"Example"},"debug":true,"tags":["a",1,"a"],"port":0}`))
+		violations, err := obj.Validate(schema)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			" required",
+			"server.host pattern",
+			"debug additionalProperties",
+			"tags uniqueItems",
+			"tags.1 type",
+			"port minimum",
+		}, violationKeys(violations))
+		assert.Equal(t, `(root): missing required property "name"`, violations[0].Error())
+		assert.Equal(t, "tags.1: expected string, got integer", violations[4].Error())
+	})
+
+	t.Run("typed object", func(t *testing.T) {
+		t.Parallel()
+		obj := NewObject[int]().Set("port", 70000).Set("name", 1)
+		violations, err := obj.Validate(schema)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"port maximum", "name type"}, violationKeys(violations))
+	})
+
+	t.Run("recursive ref", func(t *testing.T) {
+		t.Parallel()
+		tree := MustFromJSONDeep([]byte(`{"properties": {"child": {"$ref": "#"}}, "required": ["id"]}`))
+		obj := NewObject[any]().Set("id", 1).Set("child", NewObject[any]().Set("child", NewObject[any]()))
+		violations, err := obj.Validate(tree)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"child required", "child.child required"}, violationKeys(violations))
+	})
+}
+
+func TestValidateKeywords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		schema string
+		value  any
+		want   []string
+	}{
+		{"const", `{"const": 1}`, 1.0, nil},
+		{"const mismatch", `{"const": {"a": [1]}}`, map[string]any{"a": []any{2}}, []string{" const"}},
+		{"enum unordered object", `{"enum": [{"a": 1, "b": 2}]}`, NewObject[any]().Set("b", 2).Set("a", 1), nil},
+		{"type list", `{"type": ["string", "null"]}`, nil, nil},
+		{"integer is number", `{"type": "number"}`, 3, nil},
+		{"exclusive bounds", `{"exclusiveMinimum": 1, "exclusiveMaximum": 2}`, 2.0, []string{" exclusiveMaximum"}},
+		{"multipleOf", `{"multipleOf": 0.1}`, 0.3, nil},
+		{"multipleOf mismatch", `{"multipleOf": 2}`, 3, []string{" multipleOf"}},
+		{"maxLength counts runes", `{"maxLength": 2}`, "héé", []string{" maxLength"}},
+		{"strings ignore numeric keywords", `{"minimum": 5}`, "1", nil},
+		{"prefixItems", `{"prefixItems": [{"type": "string"}], "items": false}`, []any{"a", 1}, []string{"1 items"}},
+		{"contains", `{"contains": {"type": "string"}, "maxContains": 1}`, []any{"a", "b"}, []string{" contains"}},
+		{"minItems", `{"minItems": 2}`, []string{"a"}, []string{" minItems"}},
+		{"patternProperties", `{"patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": {"type": "integer"}}`,
+			NewObject[any]().Set("x-a", 1).Set("b", "c"), []string{"x-a type", "b type"}},
+		{"propertyNames", `{"propertyNames": {"maxLength": 1}}`, map[string]any{"ab": 1, "c": 2}, []string{"ab propertyNames"}},
+		{"dependentRequired", `{"dependentRequired": {"cert": ["key"]}}`, map[string]any{"cert": "x"}, []string{" dependentRequired"}},
+		{"dependentSchemas", `{"dependentSchemas": {"cert": {"required": ["key"]}}}`, map[string]any{"cert": "x"}, []string{" required"}},
+		{"maxProperties", `{"maxProperties": 1}`, map[string]any{"a": 1, "b": 2}, []string{" maxProperties"}},
+		{"allOf", `{"allOf": [{"minimum": 5}, {"multipleOf": 2}]}`, 3, []string{" minimum", " multipleOf"}},
+		{"anyOf", `{"anyOf": [{"type": "string"}, {"type": "boolean"}]}`, 1, []string{" anyOf"}},
+		{"oneOf", `{"oneOf": [{"type": "integer"}, {"minimum": 0}]}`, 1, []string{" oneOf"}},
+		{"not", `{"not": {"type": "null"}}`, nil, []string{" not"}},
+		{"if then", `{"if": {"type": "string"}, "then": {"minLength": 3}, "else": {"minimum": 0}}`, "ab", []string{" minLength"}},
+		{"if else", `{"if": {"type": "string"}, "then": {"minLength": 3}, "else": {"minimum": 0}}`, -1, []string{" minimum"}},
+		{"false schema", `{"properties": {"a": false}}`, map[string]any{"a": 1}, []string{"a false"}},
+		{"annotations ignored", `{"title": "t", "format": "email", "default": 1}`, "x", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			obj := NewObject[any]().Set("v", tt.value)
+			schema := NewObject[any]().Set("properties", NewObject[any]().Set("v", MustFromJSONDeep([]byte(tt.schema))))
+			violations, err := obj.Validate(schema)
+			require.NoError(t, err)
+			var want []string
+			for _, w := range tt.want {
+				// Paths in the table are relative to the validated value
+				if w[0] == ' ' {
+					want = append(want, "v"+w)
+				} else {
+					want = append(want, "v."+w)
+				}
+			}
+			assert.Equal(t, want, violationKeys(violations))
+		})
+	}
+}
+
+func TestValidateInvalidSchema(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().Set("a", "x").Set("b", []any{1})
+	for _, schema := range []string{
+		`{"type": 1}`,
+		`{"required": "a"}`,
+		`{"properties": {"a": {"pattern": "("}}}`,
+		`{"properties": {"a": {"$ref": "#/$defs/missing"}}}`,
+		`{"$ref": "other.json"}`,
+		`{"properties": {"b": {"items": 1}}}`,
+		`{"properties": {"a": {"minLength": -1}}}`,
+		`{"anyOf": []}`,
+		`{"$defs": {"loop": {"$ref": "#/$defs/loop"}}, "$ref": "#/$defs/loop"}`,
+	} {
+		_, err := obj.Validate(MustFromJSONDeep([]byte(schema)))
+		assert.ErrorIs(t, err, ErrInvalidSchema, schema)
+	}
+
+	_, err := obj.Validate(nil)
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+}
@@ -0,0 +1,32 @@
+package orderedobject
+
+// ContainsValue reports whether any entry of the object holds value. Values are
+// compared with ==, which panics on an Object[any] holding the searched value's type
+// when that type is not comparable, such as []any.
+func ContainsValue[V comparable](object *Object[V], value V) bool {
+	return IndexOfValue(object, value) >= 0
+}
+
+// IndexOfValue returns the position of the first entry holding value, or -1 if no
+// entry holds it.
+func IndexOfValue[V comparable](object *Object[V], value V) int {
+	object.expire()
+	for i, entry := range object.entries {
+		if entry.Value == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// KeysOf returns the keys of the entries holding value, in order.
+func KeysOf[V comparable](object *Object[V], value V) []string {
+	object.expire()
+	var keys []string
+	for _, entry := range object.entries {
+		if entry.Value == value {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,43 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueSearch(t *testing.T) {
+	t.Parallel()
+
+	roles := NewObject[string]().
+		Set("alice", "admin").
+		Set("bob", "dev").
+		Set("carol", "admin")
+
+	t.Run("ContainsValue", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, ContainsValue(roles, "dev"))
+		assert.False(t, ContainsValue(roles, "ops"))
+		assert.False(t, ContainsValue(NewObject[int](), 0))
+	})
+
+	t.Run("IndexOfValue", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, 0, IndexOfValue(roles, "admin"))
+		assert.Equal(t, 1, IndexOfValue(roles, "dev"))
+		assert.Equal(t, -1, IndexOfValue(roles, "ops"))
+	})
+
+	t.Run("KeysOf", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, []string{"alice", "carol"}, KeysOf(roles, "admin"))
+		assert.Nil(t, KeysOf(roles, "ops"))
+	})
+
+	t.Run("any values", func(t *testing.T) {
+		t.Parallel()
+		obj := NewObject[any]().Set("a", 1).Set("b", "1").Set("c", nil)
+		assert.Equal(t, 1, IndexOfValue(obj, any("1")))
+		assert.Equal(t, []string{"c"}, KeysOf(obj, nil))
+	})
+}
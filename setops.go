@@ -0,0 +1,61 @@
+package orderedobject
+
+// Union returns a new object holding the keys of both objects: the entries of the
+// object in order, followed by the entries of other whose keys the object lacks, in
+// the order of other. Keys present in both keep the value of the object.
+func (object *Object[V]) Union(other *Object[V]) *Object[V] {
+	return object.UnionFunc(other, func(_ string, value, _ V) V { return value })
+}
+
+// UnionFunc is like Union, but calls choose with the key and both values to select
+// the value of each key present in both objects.
+func (object *Object[V]) UnionFunc(other *Object[V], choose func(key string, value, otherValue V) V) *Object[V] {
+	object.expire()
+	other.expire()
+	result := NewObject[V](len(object.entries) + len(other.entries))
+	for _, entry := range object.entries {
+		if idx := other.findKeyIndex(entry.Key); idx >= 0 {
+			entry.Value = choose(entry.Key, entry.Value, other.entries[idx].Value)
+		}
+		result.entries = append(result.entries, entry)
+	}
+	for _, entry := range other.entries {
+		if object.findKeyIndex(entry.Key) < 0 {
+			result.entries = append(result.entries, entry)
+		}
+	}
+	return result
+}
+
+// Intersect returns a new object holding the entries of the object whose keys are
+// also present in other, in the order of the object.
+func (object *Object[V]) Intersect(other *Object[V]) *Object[V] {
+	return object.IntersectFunc(other, func(_ string, value, _ V) V { return value })
+}
+
+// IntersectFunc is like Intersect, but calls choose with the key and both values to
+// select the value of each key.
+func (object *Object[V]) IntersectFunc(other *Object[V], choose func(key string, value, otherValue V) V) *Object[V] {
+	object.expire()
+	result := NewObject[V]()
+	for _, entry := range object.entries {
+		if idx := other.findKeyIndex(entry.Key); idx >= 0 {
+			entry.Value = choose(entry.Key, entry.Value, other.entries[idx].Value)
+			result.entries = append(result.entries, entry)
+		}
+	}
+	return result
+}
+
+// Difference returns a new object holding the entries of the object whose keys are
+// not present in other, in the order of the object.
+func (object *Object[V]) Difference(other *Object[V]) *Object[V] {
+	object.expire()
+	result := NewObject[V]()
+	for _, entry := range object.entries {
+		if other.findKeyIndex(entry.Key) < 0 {
+			result.entries = append(result.entries, entry)
+		}
+	}
+	return result
+}
@@ -0,0 +1,61 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeySetOperations(t *testing.T) {
+	t.Parallel()
+
+	local := NewObject[int]().Set("host", 1).Set("port", 2).Set("debug", 3)
+	remote := NewObject[int]().Set("timeout", 10).Set("port", 20).Set("host", 30).Set("retries", 40)
+
+	t.Run("Union", func(t *testing.T) {
+		t.Parallel()
+		union := local.Union(remote)
+		assert.Equal(t, []string{"host", "port", "debug", "timeout", "retries"}, union.Keys())
+		assert.Equal(t, []int{1, 2, 3, 10, 40}, union.Values())
+	})
+
+	t.Run("UnionFunc", func(t *testing.T) {
+		t.Parallel()
+		var keys []string
+		union := local.UnionFunc(remote, func(key string, value, otherValue int) int {
+			keys = append(keys, key)
+			return value + otherValue
+		})
+		assert.Equal(t, []string{"host", "port"}, keys)
+		assert.Equal(t, []int{31, 22, 3, 10, 40}, union.Values())
+	})
+
+	t.Run("Intersect", func(t *testing.T) {
+		t.Parallel()
+		intersect := local.Intersect(remote)
+		assert.Equal(t, []string{"host", "port"}, intersect.Keys())
+		assert.Equal(t, []int{1, 2}, intersect.Values())
+
+		latest := local.IntersectFunc(remote, func(_ string, _, otherValue int) int { return otherValue })
+		assert.Equal(t, []int{30, 20}, latest.Values())
+		assert.True(t, local.Intersect(NewObject[int]()).IsEmpty())
+	})
+
+	t.Run("Difference", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, []string{"debug"}, local.Difference(remote).Keys())
+		assert.Equal(t, []string{"timeout", "retries"}, remote.Difference(local).Keys())
+		assert.Equal(t, local.Keys(), local.Difference(NewObject[int]()).Keys())
+	})
+
+	t.Run("aliases", func(t *testing.T) {
+		t.Parallel()
+		// Keys of other are looked up in the object through its aliases
+		aliased := NewObject[int]().Alias("server_port", "port").Set("port", 1)
+		other := NewObject[int]().Set("server_port", 2)
+		assert.Equal(t, []string{"port"}, aliased.Union(other).Keys())
+	})
+
+	assert.Equal(t, []string{"host", "port", "debug"}, local.Keys())
+	assert.Equal(t, []int{1, 2, 3}, local.Values())
+}
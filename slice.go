@@ -0,0 +1,27 @@
+package orderedobject
+
+// Slice returns a new ordered object holding the entries at positions [i, j), such as
+// one page of a large document. Positions are clamped to the bounds of the object, so
+// an out-of-range window yields fewer entries, or none, instead of panicking.
+func (object *Object[V]) Slice(i, j int) *Object[V] {
+	object.expire()
+	n := len(object.entries)
+	i = min(max(i, 0), n)
+	j = min(max(j, i), n)
+	result := NewObject[V](j - i)
+	result.entries = append(result.entries, object.entries[i:j]...)
+	return result
+}
+
+// Head returns a new ordered object holding the first n entries, or all of them if
+// the object has fewer.
+func (object *Object[V]) Head(n int) *Object[V] {
+	return object.Slice(0, n)
+}
+
+// Tail returns a new ordered object holding the last n entries, or all of them if
+// the object has fewer.
+func (object *Object[V]) Tail(n int) *Object[V] {
+	object.expire()
+	return object.Slice(len(object.entries)-max(n, 0), len(object.entries))
+}
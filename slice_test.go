@@ -0,0 +1,44 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlice(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("a", 1).Set("b", 2).Set("c", 3).Set("d", 4).Set("e", 5)
+
+	tests := []struct {
+		name string
+		got  *Object[int]
+		want []string
+	}{
+		{"middle", obj.Slice(1, 3), []string{"b", "c"}},
+		{"all", obj.Slice(0, 5), []string{"a", "b", "c", "d", "e"}},
+		{"clamped", obj.Slice(-2, 10), []string{"a", "b", "c", "d", "e"}},
+		{"past end", obj.Slice(7, 9), []string{}},
+		{"reversed", obj.Slice(3, 1), []string{}},
+		{"head", obj.Head(2), []string{"a", "b"}},
+		{"head beyond length", obj.Head(9), []string{"a", "b", "c", "d", "e"}},
+		{"head zero", obj.Head(0), []string{}},
+		{"tail", obj.Tail(2), []string{"d", "e"}},
+		{"tail beyond length", obj.Tail(9), []string{"a", "b", "c", "d", "e"}},
+		{"tail negative", obj.Tail(-1), []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.got.Keys())
+		})
+	}
+
+	// The result does not share entries with the object
+	page := obj.Slice(0, 2)
+	page.Set("a", 10).Set("f", 6)
+	a, _ := obj.Get("a")
+	assert.Equal(t, 1, a)
+	assert.Equal(t, 5, obj.Length())
+}
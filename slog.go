@@ -0,0 +1,25 @@
+package orderedobject
+
+import (
+	"log/slog"
+	"regexp"
+)
+
+// LogValue implements slog.LogValuer, expanding the object into a group that keeps
+// the key order. Nested objects are expanded as well, and keys are redacted as when
+// marshaling if Redact is enabled.
+func (object *Object[V]) LogValue() slog.Value {
+	var redact []*regexp.Regexp
+	if object.ext != nil {
+		redact = object.ext.redact
+	}
+	attrs := make([]slog.Attr, 0, len(object.entries))
+	for _, entry := range object.entries {
+		value := any(entry.Value)
+		if len(redact) > 0 {
+			value = redactEntry(entry.Key, value, redact)
+		}
+		attrs = append(attrs, slog.Any(entry.Key, value))
+	}
+	return slog.GroupValue(attrs...)
+}
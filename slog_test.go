@@ -0,0 +1,34 @@
+package orderedobject
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogValue(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("name", "app").
+		Set("server", NewObject[any]().Set("port", 8080).Set("host", "localhost")).
+		Set("password", "hunter2").
+		Redact(true)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+	logger.Info("loaded", "config", obj)
+
+	assert.Equal(t,
+		"level=INFO msg=loaded config.name=app config.server.port=8080 config.server.host=localhost config.password=***\n",
+		buf.String())
+}
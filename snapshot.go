@@ -0,0 +1,69 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrSnapshotNotFound is returned when no snapshot with the requested name exists
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// snapshot is a named copy of the entries of an object.
+type snapshot[V any] struct {
+	name    string
+	entries []Entry[V]
+}
+
+// Snapshot records the current entries under name, replacing an earlier snapshot with
+// the same name. Only the entry list is copied; values, including nested objects, maps
+// and slices, are shared with the object, so mutate nested values through a DeepClone
+// if the snapshot must stay unaffected.
+// Returns the object for chaining.
+func (object *Object[V]) Snapshot(name string) *Object[V] {
+	object.DeleteSnapshot(name)
+	ext := object.extend()
+	ext.snapshots = append(ext.snapshots, snapshot[V]{
+		name:    name,
+		entries: slices.Clone(object.entries),
+	})
+	return object
+}
+
+// RestoreSnapshot replaces the entries with those recorded by Snapshot under name.
+// The snapshot is kept, so it can be restored again. Subscribers are not notified.
+func (object *Object[V]) RestoreSnapshot(name string) error {
+	if object.ext == nil {
+		return fmt.Errorf("%w: %q", ErrSnapshotNotFound, name)
+	}
+	snapshots := object.ext.snapshots
+	idx := slices.IndexFunc(snapshots, func(s snapshot[V]) bool { return s.name == name })
+	if idx < 0 {
+		return fmt.Errorf("%w: %q", ErrSnapshotNotFound, name)
+	}
+	object.entries = slices.Clone(snapshots[idx].entries)
+	object.reindex()
+	return nil
+}
+
+// DeleteSnapshot discards the snapshot recorded under name and reports whether it existed.
+func (object *Object[V]) DeleteSnapshot(name string) bool {
+	if object.ext == nil {
+		return false
+	}
+	n := len(object.ext.snapshots)
+	object.ext.snapshots = slices.DeleteFunc(object.ext.snapshots, func(s snapshot[V]) bool { return s.name == name })
+	return len(object.ext.snapshots) < n
+}
+
+// ListSnapshots returns the names of the recorded snapshots, oldest first.
+func (object *Object[V]) ListSnapshots() []string {
+	if object.ext == nil {
+		return []string{}
+	}
+	names := make([]string, len(object.ext.snapshots))
+	for i, s := range object.ext.snapshots {
+		names[i] = s.name
+	}
+	return names
+}
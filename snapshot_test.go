@@ -0,0 +1,59 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Restore reverts entries and order", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1).Set("b", 2)
+		obj.Snapshot("before")
+
+		obj.Set("a", 10).Delete("b").Set("c", 3)
+		require.NoError(t, obj.RestoreSnapshot("before"))
+
+		assert.Equal(t, []string{"a", "b"}, obj.Keys())
+		assert.Equal(t, 1, obj.GetOrDefault("a", 0))
+	})
+
+	t.Run("Snapshot can be restored repeatedly", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1).Snapshot("s")
+
+		obj.Set("a", 2)
+		require.NoError(t, obj.RestoreSnapshot("s"))
+		obj.Set("a", 3)
+		require.NoError(t, obj.RestoreSnapshot("s"))
+
+		assert.Equal(t, 1, obj.GetOrDefault("a", 0))
+	})
+
+	t.Run("Unknown snapshot", func(t *testing.T) {
+		obj := NewObject[int]()
+		assert.ErrorIs(t, obj.RestoreSnapshot("missing"), ErrSnapshotNotFound)
+	})
+
+	t.Run("List and delete", func(t *testing.T) {
+		obj := NewObject[int]().Snapshot("a").Snapshot("b").Snapshot("a")
+		assert.Equal(t, []string{"b", "a"}, obj.ListSnapshots())
+
+		assert.True(t, obj.DeleteSnapshot("b"))
+		assert.False(t, obj.DeleteSnapshot("b"))
+		assert.Equal(t, []string{"a"}, obj.ListSnapshots())
+	})
+
+	t.Run("Clone shares snapshots", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1).Snapshot("s")
+		clone := obj.Clone().Set("a", 2)
+
+		require.NoError(t, clone.RestoreSnapshot("s"))
+		assert.Equal(t, 1, clone.GetOrDefault("a", 0))
+
+		clone.Snapshot("other")
+		assert.Equal(t, []string{"s"}, obj.ListSnapshots())
+	})
+}
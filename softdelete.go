@@ -0,0 +1,88 @@
+package orderedobject
+
+// deletedEntry is a soft-deleted entry together with the position it held
+// before it was removed.
+type deletedEntry[V any] struct {
+	entry Entry[V]
+	index int
+}
+
+// SoftDelete hides a key-value pair from the ordered object while keeping it
+// available for Restore. Soft-deleted entries are excluded from lookups,
+// iteration and marshaling until they are restored.
+// If the key does not exist, it does nothing.
+// Returns the object for chaining.
+func (object *Object[V]) SoftDelete(key string) *Object[V] {
+	idx := object.findKeyIndex(key)
+	if idx < 0 {
+		return object
+	}
+	object.forgetDeleted(object.entries[idx].Key)
+	ext := object.extend()
+	ext.deleted = append(ext.deleted, deletedEntry[V]{entry: object.entries[idx], index: idx})
+	object.removeIndex(idx)
+	return object
+}
+
+// Restore brings back a soft-deleted key-value pair at the position it held
+// when it was deleted, or at the end if the object has since shrunk.
+// It returns false if the key is not soft-deleted.
+func (object *Object[V]) Restore(key string) bool {
+	i := object.findDeletedIndex(key)
+	if i < 0 {
+		return false
+	}
+	d := object.ext.deleted[i]
+	object.ext.deleted = append(object.ext.deleted[:i], object.ext.deleted[i+1:]...)
+	object.insertAt(min(d.index, len(object.entries)), d.entry)
+	return true
+}
+
+// ListDeleted returns the keys of all soft-deleted entries in the order they
+// were deleted.
+func (object *Object[V]) ListDeleted() []string {
+	if object.ext == nil {
+		return []string{}
+	}
+	keys := make([]string, len(object.ext.deleted))
+	for i, d := range object.ext.deleted {
+		keys[i] = d.entry.Key
+	}
+	return keys
+}
+
+// PurgeDeleted permanently discards all soft-deleted entries.
+// Returns the object for chaining.
+func (object *Object[V]) PurgeDeleted() *Object[V] {
+	if object.ext != nil {
+		object.ext.deleted = nil
+	}
+	return object
+}
+
+// findDeletedIndex returns the index of the key in the deleted slice, or -1 if not found.
+// The key is resolved through aliases and key normalization and compared with KeyEqual,
+// like a lookup.
+func (object *Object[V]) findDeletedIndex(key string) int {
+	ext := object.ext
+	if ext == nil {
+		return -1
+	}
+	key = object.resolveKey(key)
+	for i, d := range ext.deleted {
+		if d.entry.Key == key || ext.keyEqual != nil && ext.keyEqual(d.entry.Key, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// forgetDeleted drops the soft-deleted entry for key, if any.
+func (object *Object[V]) forgetDeleted(key string) {
+	if object.ext == nil || len(object.ext.deleted) == 0 {
+		return
+	}
+	if i := object.findDeletedIndex(key); i >= 0 {
+		object.ext.deleted = append(object.ext.deleted[:i], object.ext.deleted[i+1:]...)
+	}
+}
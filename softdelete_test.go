@@ -0,0 +1,138 @@
+package orderedobject
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDelete(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("a", 1).
+		Set("b", 2).
+		Set("c", 3)
+
+	obj.SoftDelete("b").SoftDelete("missing")
+
+	assert.False(t, obj.Has("b"))
+	assert.Equal(t, []string{"a", "c"}, obj.Keys())
+	assert.Equal(t, []string{"b"}, obj.ListDeleted())
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1,"c":3}`, string(data))
+}
+
+func TestRestore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Restores original position", func(t *testing.T) {
+		obj := NewObject[any]().
+			Set("a", 1).
+			Set("b", 2).
+			Set("c", 3)
+
+		obj.SoftDelete("b")
+		assert.True(t, obj.Restore("b"))
+		assert.Equal(t, []string{"a", "b", "c"}, obj.Keys())
+		assert.Empty(t, obj.ListDeleted())
+
+		value, found := obj.Get("b")
+		assert.True(t, found)
+		assert.Equal(t, 2, value)
+	})
+
+	t.Run("Clamps position when object shrank", func(t *testing.T) {
+		obj := NewObject[any]().
+			Set("a", 1).
+			Set("b", 2).
+			Set("c", 3)
+
+		obj.SoftDelete("c")
+		obj.Delete("a")
+		assert.True(t, obj.Restore("c"))
+		assert.Equal(t, []string{"b", "c"}, obj.Keys())
+	})
+
+	t.Run("Unknown key", func(t *testing.T) {
+		obj := NewObject[any]().Set("a", 1)
+		assert.False(t, obj.Restore("a"))
+		assert.False(t, obj.Restore("missing"))
+	})
+
+	t.Run("Set discards soft-deleted entry", func(t *testing.T) {
+		obj := NewObject[any]().Set("a", 1)
+		obj.SoftDelete("a")
+		obj.Set("a", 2)
+
+		assert.Empty(t, obj.ListDeleted())
+		assert.False(t, obj.Restore("a"))
+		value, _ := obj.Get("a")
+		assert.Equal(t, 2, value)
+	})
+
+	t.Run("Delete discards soft-deleted entry", func(t *testing.T) {
+		obj := NewObject[any]().Set("a", 1)
+		obj.SoftDelete("a")
+		obj.Delete("a")
+
+		assert.Empty(t, obj.ListDeleted())
+		assert.False(t, obj.Restore("a"))
+	})
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("a", 1).
+		Set("b", 2)
+
+	obj.SoftDelete("a").SoftDelete("b").PurgeDeleted()
+
+	assert.Empty(t, obj.ListDeleted())
+	assert.False(t, obj.Restore("a"))
+	assert.Equal(t, 0, obj.Length())
+}
+
+func TestSoftDeleteClone(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("a", 1).
+		Set("b", 2)
+	obj.SoftDelete("a")
+
+	clone := obj.Clone()
+	assert.True(t, clone.Restore("a"))
+	assert.Equal(t, []string{"a", "b"}, clone.Keys())
+
+	// The original keeps its own soft-deleted entry
+	assert.Equal(t, []string{"a"}, obj.ListDeleted())
+	assert.Equal(t, []string{"b"}, obj.Keys())
+}
+
+func TestSoftDeleteResolvesKeys(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().NormalizeKeys(strings.ToLower).Set("Host", "a").Set("port", 1)
+	obj.Alias("address", "host")
+	obj.SoftDelete("ADDRESS")
+	assert.Equal(t, []string{"host"}, obj.ListDeleted())
+
+	// Deleting or setting the key through an alias forgets the soft-deleted entry
+	obj.Delete("Address")
+	assert.False(t, obj.Restore("host"))
+	obj.Set("host", "b").SoftDelete("host")
+	assert.True(t, obj.Restore("ADDRESS"))
+	assert.Equal(t, []string{"port", "host"}, obj.Keys())
+
+	folded := NewObject[any]().KeyEqual(strings.EqualFold).Set("Name", "x")
+	folded.SoftDelete("name")
+	assert.True(t, folded.Restore("NAME"))
+	assert.Equal(t, []string{"Name"}, folded.Keys())
+}
@@ -0,0 +1,112 @@
+package orderedobject
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ExportSorted returns a copy of the object with its keys sorted alphabetically,
+// leaving the receiver untouched. When recursive is true, nested *Object[any] values,
+// including those inside []any values, are replaced with sorted copies as well.
+func (object *Object[V]) ExportSorted(recursive bool) *Object[V] {
+	sorted := object.Clone()
+	slices.SortStableFunc(sorted.entries, func(a, b Entry[V]) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	if recursive {
+		for i, entry := range sorted.entries {
+			if value, ok := sortedValue(any(entry.Value)).(V); ok {
+				sorted.entries[i].Value = value
+			}
+		}
+		sorted.reindex()
+	}
+	return sorted
+}
+
+// sortedValue returns value with nested objects replaced by sorted copies.
+func sortedValue(value any) any {
+	switch v := value.(type) {
+	case *Object[any]:
+		if v != nil {
+			return v.ExportSorted(true)
+		}
+	case []any:
+		if v != nil {
+			s := make([]any, len(v))
+			for i, item := range v {
+				s[i] = sortedValue(item)
+			}
+			return s
+		}
+	}
+	return value
+}
+
+// SortObjectsBy sorts a slice of objects in place by the value at a dotted path such
+// as "user.name", as for ordering API records before rendering a list. The sort is
+// stable, and objects without a value at path are placed after the others, keeping
+// their relative order. A nil less orders numbers numerically and strings
+// lexicographically, placing numbers before strings and other values after both.
+func SortObjectsBy(objs []*Object[any], path string, less func(a, b any) bool) {
+	if less == nil {
+		less = lessValues
+	}
+	type keyed struct {
+		obj   *Object[any]
+		value any
+		ok    bool
+	}
+	items := make([]keyed, len(objs))
+	for i, obj := range objs {
+		items[i].obj = obj
+		if obj != nil {
+			items[i].value, items[i].ok = obj.GetPath(path)
+		}
+	}
+	slices.SortStableFunc(items, func(a, b keyed) int {
+		switch {
+		case a.ok != b.ok:
+			if a.ok {
+				return -1
+			}
+			return 1
+		case !a.ok:
+			return 0
+		case less(a.value, b.value):
+			return -1
+		case less(b.value, a.value):
+			return 1
+		}
+		return 0
+	})
+	for i, item := range items {
+		objs[i] = item.obj
+	}
+}
+
+// lessValues is the default ordering of SortObjectsBy: numbers, then strings, then
+// other values, which compare equal.
+func lessValues(a, b any) bool {
+	rank := func(value any) int {
+		if _, ok := schemaNumber(value); ok {
+			return 0
+		}
+		if _, ok := value.(string); ok {
+			return 1
+		}
+		return 2
+	}
+	ra, rb := rank(a), rank(b)
+	switch {
+	case ra != rb:
+		return ra < rb
+	case ra == 0:
+		x, _ := schemaNumber(a)
+		y, _ := schemaNumber(b)
+		return x < y
+	case ra == 1:
+		return a.(string) < b.(string)
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSorted(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("name", "app").
+		Set("server", NewObject[any]().Set("port", 8080).Set("host", "localhost")).
+		Set("items", []any{NewObject[any]().Set("z", 1).Set("a", 2)}).
+		Set("debug", true)
+
+	shallow := obj.ExportSorted(false)
+	data, err := shallow.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"debug":true,"items":[{"z":1,"a":2}],"name":"app","server":{"port":8080,"host":"localhost"}}`,
+		string(data))
+
+	deep := obj.ExportSorted(true)
+	data, err = deep.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"debug":true,"items":[{"a":2,"z":1}],"name":"app","server":{"host":"localhost","port":8080}}`,
+		string(data))
+
+	// The receiver is left untouched
+	data, err = obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"name":"app","server":{"port":8080,"host":"localhost"},"items":[{"z":1,"a":2}],"debug":true}`,
+		string(data))
+}
+
+func TestSortObjectsBy(t *testing.T) {
+	t.Parallel()
+
+	record := func(id string, age any) *Object[any] {
+		obj := NewObject[any]().Set("id", id)
+		if age != nil {
+			obj.Set("profile", NewObject[any]().Set("age", age))
+		}
+		return obj
+	}
+	ids := func(objs []*Object[any]) []string {
+		var result []string
+		for _, obj := range objs {
+			id, _ := obj.Get("id")
+			result = append(result, id.(string))
+		}
+		return result
+	}
+
+	t.Run("custom less", func(t *testing.T) {
+		t.Parallel()
+		objs := []*Object[any]{record("a", 30.0), record("b", nil), record("c", 25.0), record("d", 30.0), record("e", 20.0)}
+		SortObjectsBy(objs, "profile.age", func(a, b any) bool {
+			return a.(float64) > b.(float64)
+		})
+		assert.Equal(t, []string{"a", "d", "c", "e", "b"}, ids(objs))
+	})
+
+	t.Run("default order", func(t *testing.T) {
+		t.Parallel()
+		objs := []*Object[any]{record("a", "x"), record("b", true), record("c", 3), record("d", nil), record("e", 1.5), record("f", "w")}
+		SortObjectsBy(objs, "profile.age", nil)
+		assert.Equal(t, []string{"e", "c", "f", "a", "b", "d"}, ids(objs))
+	})
+
+	t.Run("top-level key", func(t *testing.T) {
+		t.Parallel()
+		objs := []*Object[any]{record("b", nil), record("c", nil), record("a", nil)}
+		SortObjectsBy(objs, "id", nil)
+		assert.Equal(t, []string{"a", "b", "c"}, ids(objs))
+	})
+}
@@ -0,0 +1,38 @@
+package orderedobject
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedScanType is returned when Scan receives a value that is not JSON text
+var ErrUnsupportedScanType = errors.New("unsupported scan type")
+
+// Scan implements sql.Scanner, decoding a JSON or JSONB column into the object in key order.
+// A NULL column leaves the object empty.
+func (object *Object[V]) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		object.entries = object.entries[:0]
+		if object.ext != nil {
+			object.ext.deleted = nil
+			object.reindex()
+		}
+		return nil
+	case []byte:
+		return object.UnmarshalJSON(v)
+	case string:
+		return object.UnmarshalJSON([]byte(v))
+	}
+	return fmt.Errorf("%w: %T", ErrUnsupportedScanType, src)
+}
+
+// Value implements driver.Valuer, encoding the object as JSON in key order.
+// A nil object is written as NULL.
+func (object *Object[V]) Value() (driver.Value, error) {
+	if object == nil {
+		return nil, nil
+	}
+	return object.ToJSON()
+}
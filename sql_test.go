@@ -0,0 +1,44 @@
+package orderedobject
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ sql.Scanner   = (*Object[any])(nil)
+	_ driver.Valuer = (*Object[any])(nil)
+)
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]()
+	require.NoError(t, obj.Scan([]byte(`{"zeta":1,"alpha":2}`)))
+	assert.Equal(t, []string{"zeta", "alpha"}, obj.Keys())
+
+	require.NoError(t, obj.Scan(`{"b":true}`))
+	assert.Equal(t, []string{"b"}, obj.Keys())
+
+	require.NoError(t, obj.Scan(nil))
+	assert.True(t, obj.IsEmpty())
+
+	require.ErrorIs(t, obj.Scan(42), ErrUnsupportedScanType)
+}
+
+func TestValue(t *testing.T) {
+	t.Parallel()
+
+	value, err := NewObject[int]().Set("zeta", 1).Set("alpha", 2).Value()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"zeta":1,"alpha":2}`), value)
+
+	var nilObj *Object[int]
+	value, err = nilObj.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
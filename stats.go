@@ -0,0 +1,137 @@
+package orderedobject
+
+import (
+	"strconv"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+)
+
+// Depth returns the maximum nesting depth of the object, counting the object itself
+// and every nested *Object[any] value, map and slice as one level.
+func (object *Object[V]) Depth() int {
+	depth := 0
+	for _, entry := range object.entries {
+		depth = max(depth, valueDepth(any(entry.Value)))
+	}
+	return depth + 1
+}
+
+// valueDepth returns the nesting depth of a value, which is 0 for scalars.
+func valueDepth(value any) int {
+	depth := 0
+	switch v := value.(type) {
+	case *Object[any]:
+		if v == nil {
+			return 0
+		}
+		return v.Depth()
+	case map[string]any:
+		for _, item := range v {
+			depth = max(depth, valueDepth(item))
+		}
+	case []any:
+		for _, item := range v {
+			depth = max(depth, valueDepth(item))
+		}
+	default:
+		return 0
+	}
+	return depth + 1
+}
+
+// TotalKeys returns the number of keys in the object and in every nested *Object[any]
+// value and map, including those inside slices.
+func (object *Object[V]) TotalKeys() int {
+	total := len(object.entries)
+	for _, entry := range object.entries {
+		total += nestedKeys(any(entry.Value))
+	}
+	return total
+}
+
+// nestedKeys returns the number of keys nested in a value.
+func nestedKeys(value any) int {
+	total := 0
+	switch v := value.(type) {
+	case *Object[any]:
+		if v != nil {
+			total = v.TotalKeys()
+		}
+	case map[string]any:
+		total = len(v)
+		for _, item := range v {
+			total += nestedKeys(item)
+		}
+	case []any:
+		for _, item := range v {
+			total += nestedKeys(item)
+		}
+	}
+	return total
+}
+
+// EstimatedSize returns an estimate of the size in bytes of the compact JSON encoding
+// of the object without encoding it. Strings are counted without escaping, and values
+// of other types than JSON scalars, *Object[any], maps and slices are encoded to be measured.
+func (object *Object[V]) EstimatedSize() int {
+	size := 2 // {}
+	for i, entry := range object.entries {
+		if i > 0 {
+			size++ // ,
+		}
+		size += len(entry.Key) + 3 // "":
+		size += estimatedSize(any(entry.Value))
+	}
+	return size
+}
+
+// estimatedSize returns an estimate of the size in bytes of the JSON encoding of a value.
+func estimatedSize(value any) int {
+	switch v := value.(type) {
+	case nil:
+		return len("null")
+	case bool:
+		if v {
+			return len("true")
+		}
+		return len("false")
+	case string:
+		return len(v) + 2
+	case int:
+		return len(strconv.Itoa(v))
+	case int64:
+		return len(strconv.FormatInt(v, 10))
+	case float64:
+		return len(strconv.FormatFloat(v, 'g', -1, 64))
+	case *Object[any]:
+		if v == nil {
+			return len("null")
+		}
+		return v.EstimatedSize()
+	case map[string]any:
+		size := 2
+		i := 0
+		for k, item := range v {
+			if i > 0 {
+				size++
+			}
+			size += len(k) + 3 + estimatedSize(item)
+			i++
+		}
+		return size
+	case []any:
+		size := 2
+		for i, item := range v {
+			if i > 0 {
+				size++
+			}
+			size += estimatedSize(item)
+		}
+		return size
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
@@ -0,0 +1,43 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepthAndTotalKeys(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1, NewObject[int]().Depth())
+	assert.Equal(t, 0, NewObject[int]().TotalKeys())
+
+	obj := NewObject[any]().
+		Set("name", "app").
+		Set("server", NewObject[any]().
+			Set("port", 8080).
+			Set("tls", map[string]any{"cert": "c"})).
+		Set("tags", []any{"a", map[string]any{"k": 1, "v": 2}})
+
+	assert.Equal(t, 3, obj.Depth())
+	assert.Equal(t, 8, obj.TotalKeys())
+}
+
+func TestEstimatedSize(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("name", "app").
+		Set("enabled", true).
+		Set("ratio", 0.5).
+		Set("nothing", nil).
+		Set("server", NewObject[any]().Set("port", 8080)).
+		Set("tags", []any{"a", "b"}).
+		Set("meta", map[string]any{"k": int64(1)}).
+		Set("ids", []int{1, 2, 3})
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, len(data), obj.EstimatedSize())
+}
@@ -0,0 +1,106 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// EntryDecoder reads the entries of a JSON object from a stream one at a time, in
+// document order, without materializing the whole object. It is intended for objects
+// too large to hold in memory, which can be filtered or re-keyed entry by entry.
+type EntryDecoder struct {
+	dec     *jsontext.Decoder
+	started bool
+	done    bool
+}
+
+// NewEntryDecoder returns an EntryDecoder reading a JSON object from r.
+func NewEntryDecoder(r io.Reader) *EntryDecoder {
+	return &EntryDecoder{dec: jsontext.NewDecoder(r)}
+}
+
+// Next returns the key and raw JSON value of the next entry. It returns io.EOF once the
+// closing brace of the object has been read. The returned value is only valid until the
+// next call to Next and must be copied to be retained.
+func (d *EntryDecoder) Next() (string, []byte, error) {
+	if d.done {
+		return "", nil, io.EOF
+	}
+	if !d.started {
+		tok, err := d.dec.ReadToken()
+		if err != nil {
+			return "", nil, unexpectedEOF(err)
+		}
+		if tok.Kind() != '{' {
+			return "", nil, fmt.Errorf("%w, got %v", ErrExpectedObjectStart, tok.Kind())
+		}
+		d.started = true
+	}
+
+	if d.dec.PeekKind() == '}' {
+		if _, err := d.dec.ReadToken(); err != nil {
+			return "", nil, unexpectedEOF(err)
+		}
+		d.done = true
+		return "", nil, io.EOF
+	}
+
+	tok, err := d.dec.ReadToken()
+	if err != nil {
+		return "", nil, unexpectedEOF(err)
+	}
+	if tok.Kind() != '"' {
+		return "", nil, fmt.Errorf("%w, got %v", ErrExpectedStringKey, tok.Kind())
+	}
+	key := tok.String()
+
+	value, err := d.dec.ReadValue()
+	if err != nil {
+		return "", nil, unexpectedEOF(err)
+	}
+	return key, value, nil
+}
+
+// Decoder reads a sequence of JSON objects from a stream, such as API event streams or
+// log archives holding concatenated or whitespace-separated objects.
+type Decoder struct {
+	dec *jsontext.Decoder
+}
+
+// NewDecoder returns a Decoder reading JSON objects from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: jsontext.NewDecoder(r)}
+}
+
+// Next decodes the next object in the stream, decoding nested objects as ordered
+// objects. It returns io.EOF when the stream ends between objects, and
+// ErrExpectedObjectStart when the next value is not an object.
+func (d *Decoder) Next() (*Object[any], error) {
+	switch kind := d.dec.PeekKind(); kind {
+	case '{':
+	case 0:
+		// Read the token to surface the error, which is io.EOF at the end of the stream
+		_, err := d.dec.ReadToken()
+		return nil, err
+	default:
+		return nil, fmt.Errorf("%w, got %v", ErrExpectedObjectStart, kind)
+	}
+
+	value, err := decodeOrderedFrom(d.dec)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return value.(*Object[any]), nil
+}
+
+// unexpectedEOF converts io.EOF into io.ErrUnexpectedEOF, since the stream ended before
+// the object was complete.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,122 @@
+package orderedobject
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryDecoder(t *testing.T) {
+	t.Parallel()
+
+	dec := NewEntryDecoder(strings.NewReader(`{"zeta":1,"alpha":{"b":2,"a":[1,2]},"skip":null,"name":"x"}`))
+
+	result := NewObject[any]()
+	for {
+		key, value, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if key == "skip" {
+			continue
+		}
+		result.Set("item_"+key, string(value))
+	}
+
+	assert.Equal(t, []string{"item_zeta", "item_alpha", "item_name"}, result.Keys())
+	alpha, _ := result.Get("item_alpha")
+	assert.JSONEq(t, `{"b":2,"a":[1,2]}`, alpha.(string))
+
+	// Further calls keep returning io.EOF
+	_, _, err := dec.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestEntryDecoderDecodeValue(t *testing.T) {
+	t.Parallel()
+
+	dec := NewEntryDecoder(strings.NewReader(`{"server":{"port":8080,"host":"localhost"}}`))
+	key, value, err := dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "server", key)
+
+	server := NewObject[any]()
+	require.NoError(t, json.Unmarshal(value, server))
+	assert.Equal(t, []string{"port", "host"}, server.Keys())
+}
+
+func TestEntryDecoderErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{name: "array", input: `[1,2]`, want: ErrExpectedObjectStart},
+		{name: "empty", input: ``, want: io.ErrUnexpectedEOF},
+		{name: "truncated", input: `{"a":1,"b":`, want: io.ErrUnexpectedEOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dec := NewEntryDecoder(strings.NewReader(tt.input))
+			var err error
+			for err == nil {
+				_, _, err = dec.Next()
+			}
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	t.Parallel()
+
+	dec := NewDecoder(strings.NewReader("{\"b\":1,\"a\":{\"y\":2,\"x\":3}}\n{\"event\":\"stop\"}{\"c\":[{\"z\":1}]}  \n"))
+
+	var objects []*Object[any]
+	for {
+		obj, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		objects = append(objects, obj)
+	}
+
+	require.Len(t, objects, 3)
+	assert.Equal(t, []string{"b", "a"}, objects[0].Keys())
+	nested, _ := objects[0].Get("a")
+	assert.Equal(t, []string{"y", "x"}, nested.(*Object[any]).Keys())
+	assert.Equal(t, []string{"event"}, objects[1].Keys())
+
+	data, err := objects[2].ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"c":[{"z":1}]}`, string(data))
+}
+
+func TestDecoderErrors(t *testing.T) {
+	t.Parallel()
+
+	dec := NewDecoder(strings.NewReader(`{"a":1} [1]`))
+	_, err := dec.Next()
+	require.NoError(t, err)
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, ErrExpectedObjectStart)
+
+	dec = NewDecoder(strings.NewReader(`{"a":1} {"b":`))
+	_, err = dec.Next()
+	require.NoError(t, err)
+	_, err = dec.Next()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = NewDecoder(strings.NewReader(``)).Next()
+	assert.Equal(t, io.EOF, err)
+}
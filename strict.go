@@ -0,0 +1,128 @@
+package orderedobject
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+var (
+	// ErrDuplicateKey is returned by strict decoding when an object has the same key twice,
+	// and by Concat when objects share a key and the policy is CollisionError
+	ErrDuplicateKey = errors.New("duplicate key")
+	// ErrTrailingData is returned by strict decoding when data follows the decoded object
+	ErrTrailingData = errors.New("trailing data after object")
+	// ErrDisallowedKind is returned by strict decoding when a value has a kind that is not allowed
+	ErrDisallowedKind = errors.New("value kind not allowed")
+)
+
+// StrictOptions enables checks that are stricter than the default decoding, for
+// parsers of untrusted input. Violations are reported as *PathError values wrapping
+// the typed errors above. The zero value enables no checks.
+type StrictOptions struct {
+	// RejectDuplicateKeys reports a key appearing twice in an object as ErrDuplicateKey,
+	// including keys that only collide once aliases, case fallback or key normalization
+	// are applied, which are otherwise merged.
+	RejectDuplicateKeys bool
+	// RejectNonStringKeys makes UnmarshalJSON report a non-string key at any level as
+	// ErrExpectedStringKey instead of a syntax error.
+	RejectNonStringKeys bool
+	// RejectTrailingData makes UnmarshalJSON report anything but whitespace after the
+	// closing brace as ErrTrailingData.
+	RejectTrailingData bool
+	// AllowedKinds lists the kinds of values accepted at every level: 'n' for null,
+	// 't' for booleans, '"' for strings, '0' for numbers, '{' and '['. Values of other
+	// kinds are reported as ErrDisallowedKind. Nil allows every kind.
+	AllowedKinds []jsontext.Kind
+}
+
+// SetStrict sets the strict checks applied by UnmarshalJSON and UnmarshalJSONFrom.
+// The encoded object is checked before any value is decoded.
+// Returns the object for chaining.
+func (object *Object[V]) SetStrict(opts StrictOptions) *Object[V] {
+	object.extend().strict = opts
+	return object
+}
+
+// FromJSONStrict creates an ordered object from JSON, applying the strict checks.
+func FromJSONStrict[V any](data []byte, opts StrictOptions) (*Object[V], error) {
+	obj := NewObject[V]().SetStrict(opts)
+	if err := obj.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return obj, nil
+}
+
+// enabled reports whether any check of the encoded object is set.
+func (s StrictOptions) enabled() bool {
+	return s.RejectDuplicateKeys || s.RejectNonStringKeys || s.AllowedKinds != nil
+}
+
+// allowed reports whether values of kind are allowed.
+func (s StrictOptions) allowed(kind jsontext.Kind) bool {
+	if s.AllowedKinds == nil {
+		return true
+	}
+	if kind == 'f' {
+		kind = 't'
+	}
+	return slices.Contains(s.AllowedKinds, kind)
+}
+
+// check scans an encoded object for violations, reporting offsets counted from base.
+func (s StrictOptions) check(value jsontext.Value, base int64) error {
+	dec := jsontext.NewDecoder(bytes.NewReader(value))
+	for {
+		depth := dec.StackDepth()
+		kind := dec.PeekKind()
+		parent, n := dec.StackIndex(depth)
+		isKey := parent == '{' && n%2 == 0 && kind != '}'
+		switch {
+		case isKey && kind != '"' && s.RejectNonStringKeys:
+			// The pointer is at the object itself or at its previous member
+			pointer := dec.StackPointer()
+			if n > 0 {
+				pointer = pointer.Parent()
+			}
+			return &PathError{
+				Path:   pointerPath(pointer),
+				Offset: base + dec.InputOffset(),
+				Err:    fmt.Errorf("%w, got %v", ErrExpectedStringKey, kind),
+			}
+		case !isKey && depth > 0 && kind != '}' && kind != ']' && !s.allowed(kind):
+			raw, err := dec.ReadValue()
+			if err != nil {
+				return err
+			}
+			return &PathError{
+				Path:   pointerPath(dec.StackPointer()),
+				Offset: base + dec.InputOffset() - int64(len(raw)),
+				Err:    fmt.Errorf("%w: %v", ErrDisallowedKind, kind),
+			}
+		}
+
+		if _, err := dec.ReadToken(); err != nil {
+			return s.duplicateError(err, base)
+		}
+		if dec.StackDepth() == 0 {
+			return nil
+		}
+	}
+}
+
+// duplicateError reports err as ErrDuplicateKey if it is a duplicate name error of the
+// decoder and duplicate keys are rejected, and returns it unchanged otherwise.
+func (s StrictOptions) duplicateError(err error, base int64) error {
+	var syntactic *jsontext.SyntacticError
+	if !s.RejectDuplicateKeys || !errors.Is(err, jsontext.ErrDuplicateName) || !errors.As(err, &syntactic) {
+		return err
+	}
+	return &PathError{
+		Path:   pointerPath(syntactic.JSONPointer),
+		Offset: base + syntactic.ByteOffset,
+		Err:    fmt.Errorf("%w: %w", ErrDuplicateKey, err),
+	}
+}
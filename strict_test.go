@@ -0,0 +1,86 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Zero options accept input", func(t *testing.T) {
+		obj, err := FromJSONStrict[any]([]byte(`{"a": 1, "b": [true, null]} `), StrictOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, obj.Keys())
+	})
+
+	t.Run("Duplicate keys", func(t *testing.T) {
+		_, err := FromJSONStrict[any]([]byte(`{"a": {"b": 1, "b": 2}}`), StrictOptions{RejectDuplicateKeys: true})
+
+		require.ErrorIs(t, err, ErrDuplicateKey)
+		var pathErr *PathError
+		require.ErrorAs(t, err, &pathErr)
+		assert.Equal(t, "a.b", pathErr.Path)
+	})
+
+	t.Run("Keys colliding after normalization", func(t *testing.T) {
+		obj := NewObject[int]().
+			Alias("colour", "color").
+			SetStrict(StrictOptions{RejectDuplicateKeys: true})
+
+		err := obj.UnmarshalJSON([]byte(`{"color": 1, "colour": 2}`))
+		require.ErrorIs(t, err, ErrDuplicateKey)
+
+		require.NoError(t, NewObject[int]().Alias("colour", "color").
+			UnmarshalJSON([]byte(`{"color": 1, "colour": 2}`)), "merged by default")
+	})
+
+	t.Run("Non-string keys", func(t *testing.T) {
+		opts := StrictOptions{RejectNonStringKeys: true}
+		for data, path := range map[string]string{
+			`{1: 2}`:                 "",
+			`{"a": {"b": 1, 2: 3}}`:  "a",
+			`{"a": [{"b": {3: 4}}]}`: "a.0.b",
+		} {
+			_, err := FromJSONStrict[any]([]byte(data), opts)
+			require.ErrorIs(t, err, ErrExpectedStringKey, data)
+			var pathErr *PathError
+			require.ErrorAs(t, err, &pathErr)
+			assert.Equal(t, path, pathErr.Path, data)
+		}
+	})
+
+	t.Run("Trailing data", func(t *testing.T) {
+		opts := StrictOptions{RejectTrailingData: true}
+
+		_, err := FromJSONStrict[any]([]byte(`{"a": 1} {"b": 2}`), opts)
+		require.ErrorIs(t, err, ErrTrailingData)
+		_, err = FromJSONStrict[any]([]byte(`{"a": 1} x`), opts)
+		require.ErrorIs(t, err, ErrTrailingData)
+
+		_, err = FromJSONStrict[any]([]byte("{\"a\": 1}\n"), opts)
+		require.NoError(t, err)
+		_, err = FromJSON[any]([]byte(`{"a": 1} x`))
+		require.NoError(t, err, "ignored by default")
+	})
+
+	t.Run("Allowed kinds", func(t *testing.T) {
+		opts := StrictOptions{AllowedKinds: []jsontext.Kind{'"', '0', 't', '{'}}
+
+		_, err := FromJSONStrict[any]([]byte(`{"a": "x", "b": {"c": false, "d": 1}}`), opts)
+		require.NoError(t, err)
+
+		_, err = FromJSONStrict[any]([]byte(`{"a": "x", "b": {"c": null}}`), opts)
+		require.ErrorIs(t, err, ErrDisallowedKind)
+		var pathErr *PathError
+		require.ErrorAs(t, err, &pathErr)
+		assert.Equal(t, "b.c", pathErr.Path)
+		assert.Equal(t, int64(22), pathErr.Offset)
+
+		_, err = FromJSONStrict[any]([]byte(`{"a": [1]}`), opts)
+		require.ErrorIs(t, err, ErrDisallowedKind)
+	})
+}
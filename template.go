@@ -0,0 +1,41 @@
+package orderedobject
+
+import "iter"
+
+// All returns an iterator over the key-value pairs in order. Templates can range over
+// it to iterate in insertion order: {{range $key, $value := .All}}.
+func (object *Object[V]) All() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		object.expire()
+		for _, entry := range object.entries {
+			if !yield(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// TemplateFuncs returns template functions for ordered objects, assignable to both
+// text/template.FuncMap and html/template.FuncMap:
+//
+//	get     {{get .Config "name"}}        the value for a key, or nil
+//	haskey  {{if haskey .Config "debug"}} whether a key exists
+//	keys    {{range keys .Config}}        the keys in order
+//	entries {{range entries .Config}}     the key-value pairs in order, with .Key and .Value
+func TemplateFuncs() map[string]any {
+	return map[string]any{
+		"get": func(object *Object[any], key string) any {
+			value, _ := object.Get(key)
+			return value
+		},
+		"haskey": func(object *Object[any], key string) bool {
+			return object.Has(key)
+		},
+		"keys": func(object *Object[any]) []string {
+			return object.Keys()
+		},
+		"entries": func(object *Object[any]) []Entry[any] {
+			return object.Entries()
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package orderedobject
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("z", 1).Set("a", 2).Set("m", 3)
+
+	var keys []string
+	for key, value := range obj.All() {
+		keys = append(keys, key)
+		if value == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"z", "a"}, keys)
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	t.Parallel()
+
+	config := NewObject[any]().
+		Set("name", "app").
+		Set("port", 8080).
+		Set("debug", true)
+
+	tmpl := template.Must(template.New("config").Funcs(TemplateFuncs()).Parse(
+		`{{get . "name"}}|{{if haskey . "debug"}}debug{{end}}|{{range keys .}}{{.}},{{end}}|` +
+			`{{range entries .}}{{.Key}}={{.Value}};{{end}}|{{range $k, $v := .All}}{{$k}}:{{$v}} {{end}}`))
+
+	var sb strings.Builder
+	require.NoError(t, tmpl.Execute(&sb, config))
+	assert.Equal(t, "app|debug|name,port,debug,|name=app;port=8080;debug=true;|name:app port:8080 debug:true ",
+		sb.String())
+
+	html := htmltemplate.Must(htmltemplate.New("config").Funcs(TemplateFuncs()).Parse(
+		`<b>{{get . "name"}}</b>`))
+	sb.Reset()
+	require.NoError(t, html.Execute(&sb, config.Clone().Set("name", "<app>")))
+	assert.Equal(t, "<b>&lt;app&gt;</b>", sb.String())
+}
@@ -0,0 +1,100 @@
+package orderedobject
+
+import (
+	"slices"
+	"sync"
+)
+
+// LookupEvent describes a single key lookup recorded by a trace hook.
+type LookupEvent struct {
+	// Key is the key as requested by the caller.
+	Key string
+	// Found reports whether the lookup was a hit.
+	Found bool
+	// Label is the caller-supplied label passed to Trace.
+	Label string
+}
+
+// Trace sets a hook invoked for every Get on the object, tagging each event with label.
+// Passing a nil fn disables tracing.
+// Returns the object for chaining.
+func (object *Object[V]) Trace(label string, fn func(event LookupEvent)) *Object[V] {
+	ext := object.extend()
+	ext.traceLabel = label
+	ext.onLookup = fn
+	return object
+}
+
+// traceLookup invokes the trace hook, if any, for a lookup of key.
+func (object *Object[V]) traceLookup(key string, found bool) {
+	if ext := object.ext; ext != nil && ext.onLookup != nil {
+		ext.onLookup(LookupEvent{Key: key, Found: found, Label: ext.traceLabel})
+	}
+}
+
+// DefaultLookupEvents is the number of recent events a LookupRecorder keeps by default.
+const DefaultLookupEvents = 1024
+
+// LookupRecorder collects lookup events from one or more traced objects. It counts
+// every lookup but keeps only the most recent events, so it can stay attached to
+// long-running objects. It is safe for concurrent use; pass its Record method to Trace.
+type LookupRecorder struct {
+	mu     sync.Mutex
+	events []LookupEvent
+	next   int
+	reads  map[string]int
+}
+
+// NewLookupRecorder returns an empty lookup recorder keeping the given number of recent
+// events, DefaultLookupEvents if omitted. A limit of zero keeps counts only.
+func NewLookupRecorder(limit ...int) *LookupRecorder {
+	n := DefaultLookupEvents
+	if len(limit) > 0 {
+		n = max(limit[0], 0)
+	}
+	return &LookupRecorder{events: make([]LookupEvent, 0, n), reads: make(map[string]int)}
+}
+
+// Record counts a lookup and stores its event, replacing the oldest event once the
+// recorder is full.
+func (recorder *LookupRecorder) Record(event LookupEvent) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.reads[event.Key]++
+	switch {
+	case cap(recorder.events) == 0:
+	case len(recorder.events) < cap(recorder.events):
+		recorder.events = append(recorder.events, event)
+	default:
+		recorder.events[recorder.next] = event
+		recorder.next = (recorder.next + 1) % len(recorder.events)
+	}
+}
+
+// Events returns the recent events in the order they occurred.
+func (recorder *LookupRecorder) Events() []LookupEvent {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return slices.Concat(recorder.events[recorder.next:], recorder.events[:recorder.next])
+}
+
+// Count returns how many times key has been looked up.
+func (recorder *LookupRecorder) Count(key string) int {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return recorder.reads[key]
+}
+
+// Unread returns the keys that have never been looked up, in the given order.
+// Pass the Keys of a configuration object to find configuration that is never read.
+func (recorder *LookupRecorder) Unread(keys []string) []string {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	var unread []string
+	for _, key := range keys {
+		if recorder.reads[key] == 0 {
+			unread = append(unread, key)
+		}
+	}
+	return unread
+}
@@ -0,0 +1,69 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrace(t *testing.T) {
+	t.Parallel()
+
+	var events []LookupEvent
+	obj := NewObject[any]().
+		Set("host", "localhost").
+		Set("port", 8080).
+		Trace("server", func(event LookupEvent) {
+			events = append(events, event)
+		})
+
+	obj.Get("host")
+	obj.Get("timeout")
+
+	assert.Equal(t, []LookupEvent{
+		{Key: "host", Found: true, Label: "server"},
+		{Key: "timeout", Found: false, Label: "server"},
+	}, events)
+
+	obj.Trace("", nil)
+	obj.Get("port")
+	assert.Len(t, events, 2)
+}
+
+func TestLookupRecorder(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewLookupRecorder()
+	obj := NewObject[any]().
+		Set("host", "localhost").
+		Set("port", 8080).
+		Set("legacy", true).
+		Trace("config", recorder.Record)
+
+	obj.Get("host")
+	obj.Get("host")
+	obj.Get("port")
+
+	assert.Equal(t, 2, recorder.Count("host"))
+	assert.Len(t, recorder.Events(), 3)
+	assert.Equal(t, []string{"legacy"}, recorder.Unread(obj.Keys()))
+
+	// Only the most recent events are kept, but every lookup is counted
+	recent := NewLookupRecorder(2)
+	obj.Trace("config", recent.Record)
+	for _, key := range []string{"host", "port", "legacy", "missing"} {
+		obj.Get(key)
+	}
+	assert.Equal(t, []LookupEvent{
+		{Key: "legacy", Found: true, Label: "config"},
+		{Key: "missing", Found: false, Label: "config"},
+	}, recent.Events())
+	assert.Equal(t, 1, recent.Count("host"))
+	assert.Empty(t, recent.Unread([]string{"host", "port", "legacy"}))
+
+	counts := NewLookupRecorder(0)
+	obj.Trace("config", counts.Record)
+	obj.Get("host")
+	assert.Empty(t, counts.Events())
+	assert.Equal(t, 1, counts.Count("host"))
+}
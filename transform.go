@@ -0,0 +1,46 @@
+package orderedobject
+
+import "strconv"
+
+// TransformValues walks the object depth-first in order and replaces every value for
+// which fn returns true, in place. Path holds the keys leading to the value, with
+// slice indexes as decimal strings. Values nested in *Object[any] values, maps and
+// slices are visited after their container, so a replacement container is walked as well.
+// Replaced top-level values must be of type V; other replacements are ignored.
+// Returns the object for chaining.
+func (object *Object[V]) TransformValues(fn func(path []string, value any) (any, bool)) *Object[V] {
+	for i, entry := range object.entries {
+		value := transformValue([]string{entry.Key}, any(entry.Value), fn)
+		if v, ok := value.(V); ok {
+			object.entries[i].Value = v
+			object.notifySet(entry.Key, v, entry.Value, true)
+		}
+	}
+	return object
+}
+
+// transformValue applies fn to value and then to its children, returning the result.
+func transformValue(path []string, value any, fn func(path []string, value any) (any, bool)) any {
+	if replaced, ok := fn(path, value); ok {
+		value = replaced
+	}
+
+	switch v := value.(type) {
+	case *Object[any]:
+		if v == nil {
+			return v
+		}
+		for i, entry := range v.entries {
+			v.entries[i].Value = transformValue(appendPath(path, entry.Key), entry.Value, fn)
+		}
+	case map[string]any:
+		for k, item := range v {
+			v[k] = transformValue(appendPath(path, k), item, fn)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = transformValue(appendPath(path, strconv.Itoa(i)), item, fn)
+		}
+	}
+	return value
+}
@@ -0,0 +1,52 @@
+package orderedobject
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformValues(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("port", "80").
+		Set("servers", []any{
+			NewObject[any]().Set("host", "a").Set("port", "8080"),
+			map[string]any{"host": "b", "port": "9090"},
+		}).
+		Set("name", "app")
+
+	var paths [][]string
+	obj.TransformValues(func(path []string, value any) (any, bool) {
+		paths = append(paths, path)
+		s, ok := value.(string)
+		if !ok || path[len(path)-1] != "port" {
+			return nil, false
+		}
+		n, err := strconv.Atoi(s)
+		return n, err == nil
+	})
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"port":80,"servers":[{"host":"a","port":8080},{"host":"b","port":9090}],"name":"app"}`,
+		string(data))
+	assert.Contains(t, paths, []string{"servers", "1", "port"})
+}
+
+func TestTransformValuesTyped(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[int]().Set("a", 1).Set("b", 2)
+	obj.TransformValues(func(path []string, value any) (any, bool) {
+		if path[0] == "a" {
+			return "not an int", true
+		}
+		return value.(int) * 10, true
+	})
+	assert.Equal(t, []int{1, 20}, obj.Values())
+}
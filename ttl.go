@@ -0,0 +1,108 @@
+package orderedobject
+
+import "time"
+
+// SetWithTTL sets the value for a key like Set and expires the key after ttl.
+// Expired keys are removed lazily, the next time the object is read, or by
+// PurgeExpired. Setting the key again with Set, deleting it or decoding into the
+// object makes it permanent. Since reads remove expired keys, reads of an object
+// holding keys with a TTL are not safe for concurrent use: guard them with the same
+// lock as writes. Returns the object for chaining.
+func (object *Object[V]) SetWithTTL(key string, value V, ttl time.Duration) *Object[V] {
+	object.Set(key, value)
+	key = object.entries[object.findKeyIndex(key)].Key
+	ext := object.extend()
+	if ext.expires == nil {
+		ext.expires = make(map[string]time.Time)
+	}
+	deadline := object.now().Add(ttl)
+	ext.expires[key] = deadline
+	if ext.nextExpiry.IsZero() || deadline.Before(ext.nextExpiry) {
+		ext.nextExpiry = deadline
+	}
+	return object
+}
+
+// TTL returns the time left before key expires and whether it has an expiry.
+func (object *Object[V]) TTL(key string) (time.Duration, bool) {
+	idx := object.findKeyIndex(key)
+	if idx < 0 || object.ext == nil {
+		return 0, false
+	}
+	deadline, ok := object.ext.expires[object.entries[idx].Key]
+	if !ok {
+		return 0, false
+	}
+	return deadline.Sub(object.now()), true
+}
+
+// PurgeExpired removes every expired key and returns how many were removed.
+// Call it periodically, under the same lock as other mutations, to release expired
+// entries of objects that are rarely read.
+func (object *Object[V]) PurgeExpired() int {
+	if object.ext == nil || len(object.ext.expires) == 0 {
+		return 0
+	}
+	object.ext.nextExpiry = time.Time{}
+	return object.removeExpired(object.now())
+}
+
+// expire removes the expired keys once the earliest deadline has passed.
+func (object *Object[V]) expire() {
+	ext := object.ext
+	if ext == nil || ext.expires == nil || ext.nextExpiry.IsZero() {
+		return
+	}
+	now := object.now()
+	if now.Before(ext.nextExpiry) {
+		return
+	}
+	ext.nextExpiry = time.Time{}
+	object.removeExpired(now)
+}
+
+// removeExpired removes the keys whose deadline is not after now, records the next
+// deadline and returns the number of keys removed.
+func (object *Object[V]) removeExpired(now time.Time) int {
+	ext := object.ext
+	remove := make(map[int]bool)
+	for i, entry := range object.entries {
+		deadline, ok := ext.expires[entry.Key]
+		switch {
+		case !ok:
+		case !deadline.After(now):
+			remove[i] = true
+		case ext.nextExpiry.IsZero() || deadline.Before(ext.nextExpiry):
+			ext.nextExpiry = deadline
+		}
+	}
+	// Drop deadlines of keys deleted before they expired
+	if len(ext.expires) > 0 {
+		present := make(map[string]bool, len(object.entries))
+		for _, entry := range object.entries {
+			present[entry.Key] = true
+		}
+		for key := range ext.expires {
+			if !present[key] {
+				delete(ext.expires, key)
+			}
+		}
+	}
+	object.removeIndexes(remove)
+	return len(remove)
+}
+
+// forgetExpiry drops the deadline of key, which is being removed or added anew.
+func (object *Object[V]) forgetExpiry(key string) {
+	if object.ext != nil && object.ext.expires != nil {
+		delete(object.ext.expires, key)
+	}
+}
+
+// now returns the current time from the object's clock.
+func (object *Object[V]) now() time.Time {
+	if object.ext != nil && object.ext.clock != nil {
+		return object.ext.clock()
+	}
+	return time.Now()
+}
@@ -0,0 +1,189 @@
+package orderedobject
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns an object clock and a function advancing it.
+func fakeClock() (func() time.Time, func(d time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestSetWithTTL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Expires lazily on read", func(t *testing.T) {
+		clock, advance := fakeClock()
+		obj := NewObject[int]()
+		obj.extend().clock = clock
+
+		obj.Set("keep", 1).SetWithTTL("session", 2, time.Minute)
+		assert.True(t, obj.Has("session"))
+
+		advance(time.Minute)
+		assert.False(t, obj.Has("session"))
+		assert.Equal(t, []string{"keep"}, obj.Keys())
+	})
+
+	t.Run("Expired keys are not encoded", func(t *testing.T) {
+		clock, advance := fakeClock()
+		obj := NewObject[int]()
+		obj.extend().clock = clock
+		obj.SetWithTTL("a", 1, time.Second).Set("b", 2)
+
+		advance(2 * time.Second)
+		data, err := obj.ToJSON()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"b":2}`, string(data))
+	})
+
+	t.Run("TTL reports remaining time", func(t *testing.T) {
+		clock, advance := fakeClock()
+		obj := NewObject[int]()
+		obj.extend().clock = clock
+		obj.SetWithTTL("a", 1, time.Minute).Set("b", 2)
+
+		advance(20 * time.Second)
+		ttl, ok := obj.TTL("a")
+		assert.True(t, ok)
+		assert.Equal(t, 40*time.Second, ttl)
+
+		_, ok = obj.TTL("b")
+		assert.False(t, ok)
+		_, ok = obj.TTL("missing")
+		assert.False(t, ok)
+	})
+
+	t.Run("Set makes a key permanent", func(t *testing.T) {
+		clock, advance := fakeClock()
+		obj := NewObject[int]()
+		obj.extend().clock = clock
+		obj.SetWithTTL("a", 1, time.Minute).Set("a", 2)
+
+		advance(time.Hour)
+		assert.Equal(t, 2, obj.GetOrDefault("a", 0))
+	})
+
+	t.Run("PurgeExpired removes in bulk", func(t *testing.T) {
+		clock, advance := fakeClock()
+		obj := NewObject[int]()
+		obj.extend().clock = clock
+		obj.SetWithTTL("a", 1, time.Second).
+			SetWithTTL("b", 2, time.Minute).
+			SetWithTTL("c", 3, time.Second)
+
+		var deleted []string
+		obj.Subscribe(func(event Event) { deleted = append(deleted, event.Key) })
+		advance(time.Second)
+
+		assert.Equal(t, 2, obj.PurgeExpired())
+		assert.Equal(t, []string{"a", "c"}, deleted)
+		assert.Zero(t, obj.PurgeExpired())
+
+		advance(time.Minute)
+		assert.Zero(t, obj.Length())
+	})
+
+	t.Run("Deleted key does not expire its replacement", func(t *testing.T) {
+		clock, advance := fakeClock()
+		obj := NewObject[int]()
+		obj.extend().clock = clock
+		obj.SetWithTTL("a", 1, time.Second).SetWithTTL("b", 2, time.Second)
+		obj.SoftDelete("a")
+
+		advance(time.Second)
+		assert.Equal(t, 1, obj.PurgeExpired())
+		obj.Restore("a")
+
+		assert.True(t, obj.Has("a"))
+	})
+	t.Run("Delete and decoding clear the deadline", func(t *testing.T) {
+		clock, advance := fakeClock()
+		obj := NewObject[int]()
+		obj.extend().clock = clock
+		obj.SetWithTTL("a", 1, time.Second).Delete("a")
+		obj.Set("a", 2)
+		require.NoError(t, obj.UnmarshalJSON([]byte(`{"a":3}`)))
+		obj.SetWithTTL("b", 4, time.Second)
+		require.NoError(t, obj.UnmarshalJSON([]byte(`{"a":3,"b":5}`)))
+
+		advance(time.Hour)
+		assert.Equal(t, []string{"a", "b"}, obj.Keys())
+		_, ok := obj.TTL("b")
+		assert.False(t, ok)
+	})
+
+	t.Run("Every read applies expiry", func(t *testing.T) {
+		clock, advance := fakeClock()
+		obj := NewObject[int]()
+		obj.extend().clock = clock
+		obj.SetWithTTL("first", 1, time.Second).Set("keep", 2).SetWithTTL("last", 3, time.Second)
+		all := obj.All()
+		advance(time.Second)
+
+		var keys []string
+		for key := range all {
+			keys = append(keys, key)
+		}
+		assert.Equal(t, []string{"keep"}, keys)
+
+		reads := map[string]func(*Object[int]) []string{
+			"ForEach": func(o *Object[int]) (keys []string) {
+				o.ForEach(func(key string, _ int) { keys = append(keys, key) })
+				return keys
+			},
+			"Visit": func(o *Object[int]) (keys []string) {
+				o.Visit(func(key string, _ int) bool { keys = append(keys, key); return true })
+				return keys
+			},
+			"Walk": func(o *Object[int]) (keys []string) {
+				_ = o.Walk(func(_ []string, key string, _ any) error { keys = append(keys, key); return nil })
+				return keys
+			},
+			"ToMap": func(o *Object[int]) []string { return slices.Collect(maps.Keys(o.ToMap())) },
+			"First": func(o *Object[int]) []string { e, _ := o.First(); return []string{e.Key} },
+			"Last":  func(o *Object[int]) []string { e, _ := o.Last(); return []string{e.Key} },
+			"PopFirst": func(o *Object[int]) []string {
+				e, _ := o.PopFirst()
+				return []string{e.Key}
+			},
+			"PopLast": func(o *Object[int]) []string { e, _ := o.PopLast(); return []string{e.Key} },
+		}
+		for name, read := range reads {
+			clock, advance := fakeClock()
+			o := NewObject[int]()
+			o.extend().clock = clock
+			o.SetWithTTL("first", 1, time.Second).Set("keep", 2).SetWithTTL("last", 3, time.Second)
+			advance(time.Second)
+			assert.Equal(t, []string{"keep"}, read(o), name)
+		}
+
+		clock, advance = fakeClock()
+		empty := NewObject[int]()
+		empty.extend().clock = clock
+		empty.SetWithTTL("a", 1, time.Second)
+		advance(time.Second)
+		assert.True(t, empty.IsEmpty())
+	})
+
+	t.Run("Transactions commit deadlines", func(t *testing.T) {
+		clock, advance := fakeClock()
+		obj := NewObject[int]()
+		obj.extend().clock = clock
+		obj.SetWithTTL("a", 1, time.Second).SetWithTTL("b", 2, time.Second)
+
+		txn := obj.Begin()
+		txn.Set("a", 3)
+		require.NoError(t, txn.Commit())
+
+		advance(time.Second)
+		assert.Equal(t, []string{"a"}, obj.Keys())
+	})
+}
@@ -0,0 +1,89 @@
+package orderedobject
+
+import "errors"
+
+// ErrTxnDone is returned when a transaction is used after Commit or Rollback
+var ErrTxnDone = errors.New("transaction already committed or rolled back")
+
+// Txn buffers Set, Delete and Reorder operations on an object and applies them
+// together on Commit, so the object never holds a partially applied edit.
+// A Txn is not safe for concurrent use; guard the object as for any other mutation.
+type Txn[V any] struct {
+	object *Object[V]
+	ops    []func(work *Object[V])
+	done   bool
+}
+
+// Begin starts a transaction on the object. Nothing is changed until Commit.
+func (object *Object[V]) Begin() *Txn[V] {
+	return &Txn[V]{object: object}
+}
+
+// Set buffers setting key to value.
+// Returns the transaction for chaining.
+func (txn *Txn[V]) Set(key string, value V) *Txn[V] {
+	txn.ops = append(txn.ops, func(work *Object[V]) { work.Set(key, value) })
+	return txn
+}
+
+// Delete buffers removing key.
+// Returns the transaction for chaining.
+func (txn *Txn[V]) Delete(key string) *Txn[V] {
+	txn.ops = append(txn.ops, func(work *Object[V]) { work.Delete(key) })
+	return txn
+}
+
+// Reorder buffers reordering the keys according to profile, as ApplyOrder does.
+// Returns the transaction for chaining.
+func (txn *Txn[V]) Reorder(profile Profile) *Txn[V] {
+	txn.ops = append(txn.ops, func(work *Object[V]) { work.ApplyOrder(profile) })
+	return txn
+}
+
+// Len returns the number of buffered operations.
+func (txn *Txn[V]) Len() int {
+	return len(txn.ops)
+}
+
+// Commit applies the buffered operations in order to a copy of the object and then
+// replaces its entries in one step. Subscribers are notified of each change after
+// the whole transaction has been applied.
+func (txn *Txn[V]) Commit() error {
+	if txn.done {
+		return ErrTxnDone
+	}
+	txn.done = true
+
+	work := txn.object.Clone()
+	var events []Event
+	if txn.object.observed() {
+		work.Subscribe(func(event Event) { events = append(events, event) })
+	}
+	for _, op := range txn.ops {
+		op(work)
+	}
+	txn.ops = nil
+
+	txn.object.entries = work.entries
+	if work.ext != nil {
+		ext := txn.object.extend()
+		ext.deleted = work.ext.deleted
+		ext.version, ext.changes = work.ext.version, work.ext.changes
+		ext.expires, ext.nextExpiry = work.ext.expires, work.ext.nextExpiry
+		txn.object.reindex()
+	}
+	for _, event := range events {
+		txn.object.notify(event)
+	}
+	return nil
+}
+
+// Rollback discards the buffered operations, leaving the object unchanged.
+func (txn *Txn[V]) Rollback() error {
+	if txn.done {
+		return ErrTxnDone
+	}
+	txn.done = true
+	txn.ops = nil
+	return nil
+}
@@ -0,0 +1,65 @@
+package orderedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxn(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Commit applies buffered operations", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1).Set("b", 2)
+
+		txn := obj.Begin().
+			Set("c", 3).
+			Delete("a").
+			Set("b", 20).
+			Reorder(Profile{Keys: []string{"c"}})
+		assert.Equal(t, 4, txn.Len())
+		assert.Equal(t, []string{"a", "b"}, obj.Keys(), "nothing applied before commit")
+
+		require.NoError(t, txn.Commit())
+		assert.Equal(t, []string{"c", "b"}, obj.Keys())
+		assert.Equal(t, 20, obj.GetOrDefault("b", 0))
+	})
+
+	t.Run("Rollback discards buffered operations", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1)
+
+		txn := obj.Begin().Set("a", 2).Delete("a")
+		require.NoError(t, txn.Rollback())
+
+		assert.Equal(t, 1, obj.GetOrDefault("a", 0))
+		assert.ErrorIs(t, txn.Commit(), ErrTxnDone)
+	})
+
+	t.Run("Finished transaction cannot be reused", func(t *testing.T) {
+		txn := NewObject[int]().Begin()
+		require.NoError(t, txn.Commit())
+
+		assert.ErrorIs(t, txn.Commit(), ErrTxnDone)
+		assert.ErrorIs(t, txn.Rollback(), ErrTxnDone)
+	})
+
+	t.Run("Subscribers see the committed state", func(t *testing.T) {
+		obj := NewObject[int]().Set("a", 1)
+		var events []Event
+		var lengths []int
+		obj.Subscribe(func(event Event) {
+			events = append(events, event)
+			lengths = append(lengths, obj.Length())
+		})
+
+		require.NoError(t, obj.Begin().Set("b", 2).Set("c", 3).Delete("a").Commit())
+
+		assert.Equal(t, []Event{
+			{Kind: EventSet, Key: "b", Value: 2},
+			{Kind: EventSet, Key: "c", Value: 3},
+			{Kind: EventDelete, Key: "a", Value: 1},
+		}, events)
+		assert.Equal(t, []int{2, 2, 2}, lengths)
+	})
+}
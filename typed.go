@@ -0,0 +1,190 @@
+package orderedobject
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+)
+
+// TypedDecoder is a registry of the Go types expected at keys or paths of a JSON
+// object, such as int for "port" and *SSLConfig for "server.ssl". Attached to an
+// object with DecodeTypes, it makes UnmarshalJSON and UnmarshalJSONFrom decode the
+// values at registered paths into their types, so an Object[any] holds concrete
+// values instead of generic maps and float64s.
+type TypedDecoder struct {
+	types []typedPath
+}
+
+// typedPath is a registered path and the type of its values.
+type typedPath struct {
+	keys []string
+	typ  reflect.Type
+}
+
+// NewTypedDecoder returns an empty type registry.
+func NewTypedDecoder() *TypedDecoder {
+	return &TypedDecoder{}
+}
+
+// Register sets the type of the values at path, a key such as "port" or a dotted path
+// such as "server.ssl" in which a "*" segment matches any key or array index, as in
+// "servers.*.port". When several registered paths match a value, the one registered
+// last wins. Register panics with ErrInvalidPath on a malformed path, since paths are
+// usually constants. Returns the decoder for chaining.
+func (d *TypedDecoder) Register(path string, typ reflect.Type) *TypedDecoder {
+	keys, err := splitPath(path)
+	if err != nil {
+		panic(err)
+	}
+	d.types = append(d.types, typedPath{keys: keys, typ: typ})
+	return d
+}
+
+// RegisterType sets T as the type of the values at path. See TypedDecoder.Register.
+func RegisterType[T any](d *TypedDecoder, path string) *TypedDecoder {
+	return d.Register(path, reflect.TypeFor[T]())
+}
+
+// Decode parses a JSON object into an ordered object, decoding the values at
+// registered paths into their types.
+func (d *TypedDecoder) Decode(data []byte) (*Object[any], error) {
+	obj := NewObject[any]().DecodeTypes(d)
+	if err := obj.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return obj, nil
+}
+
+// DecodeTypes sets the type registry used by UnmarshalJSON and UnmarshalJSONFrom.
+// A value at a registered path is decoded into its type, which must be assignable to V.
+// Objects and arrays enclosing a registered path are decoded as *Object[any] and []any
+// so the registered values nested in them can be typed, and all other values are
+// decoded as usual. Passing nil restores the default decoding.
+// Returns the object for chaining.
+func (object *Object[V]) DecodeTypes(types *TypedDecoder) *Object[V] {
+	object.extend().types = types
+	return object
+}
+
+// decodeValue decodes the value of key into value, applying the registered types.
+func (object *Object[V]) decodeValue(dec *jsontext.Decoder, key string, value *V) error {
+	if object.ext == nil || object.ext.types == nil {
+		return unmarshalValue(dec, value)
+	}
+	types, path := object.ext.types, []string{key}
+	if !types.covers(path) {
+		return unmarshalValue(dec, value)
+	}
+	decoded, err := types.decode(dec, path)
+	if err != nil {
+		return err
+	}
+	if decoded == nil {
+		var zero V
+		*value = zero
+		return nil
+	}
+	v, ok := decoded.(V)
+	if !ok {
+		return mismatch(key, decoded, fmt.Sprintf("%T", *value))
+	}
+	*value = v
+	return nil
+}
+
+// lookup returns the type registered for path, or nil.
+func (d *TypedDecoder) lookup(path []string) reflect.Type {
+	for _, t := range slices.Backward(d.types) {
+		if len(t.keys) == len(path) && matchTypedPath(t.keys, path) {
+			return t.typ
+		}
+	}
+	return nil
+}
+
+// covers reports whether a path is registered at or below path.
+func (d *TypedDecoder) covers(path []string) bool {
+	return slices.ContainsFunc(d.types, func(t typedPath) bool {
+		return len(t.keys) >= len(path) && matchTypedPath(t.keys[:len(path)], path)
+	})
+}
+
+// decode decodes the value at path, which covers a registered path.
+func (d *TypedDecoder) decode(dec *jsontext.Decoder, path []string) (any, error) {
+	if typ := d.lookup(path); typ != nil {
+		target := reflect.New(typ)
+		if err := unmarshalInto(dec, target.Interface()); err != nil {
+			return nil, err
+		}
+		return target.Elem().Interface(), nil
+	}
+
+	switch dec.PeekKind() {
+	case '{':
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		obj := NewObject[any]()
+		for dec.PeekKind() != '}' {
+			tok, err := dec.ReadToken()
+			if err != nil {
+				return nil, err
+			}
+			if tok.Kind() != '"' {
+				return nil, fmt.Errorf("%w, got %v", ErrExpectedStringKey, tok.Kind())
+			}
+			key := tok.String()
+			value, err := d.decodeChild(dec, appendPath(path, key))
+			if err != nil {
+				return nil, err
+			}
+			obj.Set(key, value)
+		}
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case '[':
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		values := []any{}
+		for dec.PeekKind() != ']' {
+			value, err := d.decodeChild(dec, appendPath(path, strconv.Itoa(len(values))))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+	var value any
+	err := unmarshalInto(dec, &value)
+	return value, err
+}
+
+// decodeChild decodes a value nested in a container being decoded by decode.
+func (d *TypedDecoder) decodeChild(dec *jsontext.Decoder, path []string) (any, error) {
+	if d.covers(path) {
+		return d.decode(dec, path)
+	}
+	var value any
+	err := unmarshalInto(dec, &value)
+	return value, err
+}
+
+// matchTypedPath reports whether the registered keys match path segment by segment.
+func matchTypedPath(keys, path []string) bool {
+	for i, key := range keys {
+		if key != "*" && key != path[i] {
+			return false
+		}
+	}
+	return true
+}
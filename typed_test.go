@@ -0,0 +1,113 @@
+package orderedobject
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sslConfig struct {
+	Cert string `json:"cert"`
+	Key  string `json:"key"`
+}
+
+func TestTypedDecoder(t *testing.T) {
+	t.Parallel()
+
+	types := NewTypedDecoder().
+		Register("port", reflect.TypeFor[int]()).
+		Register("updated", reflect.TypeFor[time.Time]())
+	RegisterType[*sslConfig](types, "server.ssl")
+	RegisterType[uint16](types, "servers.*.port")
+
+	obj, err := types.Decode([]byte(`{
+		"name": "app",
+		"port": 8080,
+		"updated": "2024-01-02T03:04:05Z",
+		"server": {"host": "localhost", "ssl": {"cert": "a.pem", "key": "a.key"}},
+		"servers": [{"port": 80, "tags": {"a": 1}}, {"port": 443}],
+		"extra": {"n": 1}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "port", "updated", "server", "servers", "extra"}, obj.Keys())
+
+	port, _ := obj.Get("port")
+	assert.Equal(t, 8080, port)
+	updated, _ := obj.Get("updated")
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), updated)
+	ssl, ok := obj.GetPath("server.ssl")
+	require.True(t, ok)
+	assert.Equal(t, &sslConfig{Cert: "a.pem", Key: "a.key"}, ssl)
+	host, _ := obj.GetPath("server.host")
+	assert.Equal(t, "localhost", host)
+	first, _ := obj.GetPath("servers.0.port")
+	assert.Equal(t, uint16(80), first)
+	second, _ := obj.GetPath("servers.1.port")
+	assert.Equal(t, uint16(443), second)
+
+	// Containers of unregistered values decode as usual
+	tags, _ := obj.GetPath("servers.0.tags")
+	assert.Equal(t, map[string]any{"a": 1.0}, tags)
+	extra, _ := obj.Get("extra")
+	assert.Equal(t, map[string]any{"n": 1.0}, extra)
+	name, _ := obj.Get("name")
+	assert.Equal(t, "app", name)
+
+	data, err := obj.ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"app","port":8080,"updated":"2024-01-02T03:04:05Z","server":{"host":"localhost","ssl":{"cert":"a.pem","key":"a.key"}},"servers":[{"port":80,"tags":{"a":1}},{"port":443}],"extra":{"n":1}}`, string(data))
+}
+
+func TestTypedDecoderErrors(t *testing.T) {
+	t.Parallel()
+
+	types := NewTypedDecoder().Register("port", reflect.TypeFor[int]()).Register("a.b", reflect.TypeFor[bool]())
+
+	_, err := types.Decode([]byte(`{"port":"80"}`))
+	var pathErr *PathError
+	require.ErrorAs(t, err, &pathErr)
+	assert.Equal(t, "port", pathErr.Path)
+
+	_, err = types.Decode([]byte(`{"a":{"b":1}}`))
+	require.ErrorAs(t, err, &pathErr)
+	assert.Equal(t, "a.b", pathErr.Path)
+
+	// Registered values must be assignable to the value type of the object
+	typed := NewObject[string]().DecodeTypes(types)
+	err = typed.UnmarshalJSON([]byte(`{"port":80}`))
+	require.ErrorIs(t, err, ErrTypeMismatch)
+
+	// Invalid values are collected like other decode failures
+	obj := NewObject[any]().DecodeTypes(types).CollectErrors(true)
+	err = obj.UnmarshalJSON([]byte(`{"port":"80","name":"app"}`))
+	require.Error(t, err)
+	assert.Equal(t, []string{"name"}, obj.Keys())
+
+	assert.PanicsWithError(t, `invalid path: "a..b"`, func() {
+		NewTypedDecoder().Register("a..b", reflect.TypeFor[int]())
+	})
+}
+
+func TestTypedDecoderPrecedence(t *testing.T) {
+	t.Parallel()
+
+	types := NewTypedDecoder().
+		Register("*", reflect.TypeFor[string]()).
+		Register("n", reflect.TypeFor[float32]())
+	obj, err := types.Decode([]byte(`{"s":"x","n":1.5,"null":null}`))
+	require.NoError(t, err)
+	n, _ := obj.Get("n")
+	assert.Equal(t, float32(1.5), n)
+	s, _ := obj.Get("s")
+	assert.Equal(t, "x", s)
+	null, _ := obj.Get("null")
+	assert.Empty(t, null)
+
+	clone := NewObject[any]().DecodeTypes(types).Clone()
+	require.NoError(t, clone.UnmarshalJSON([]byte(`{"n":2}`)))
+	n, _ = clone.Get("n")
+	assert.Equal(t, float32(2), n)
+}
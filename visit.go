@@ -0,0 +1,49 @@
+package orderedobject
+
+import "fmt"
+
+// Visit calls fn for each key-value pair in order until fn returns false.
+func (object *Object[V]) Visit(fn func(key string, value V) bool) {
+	object.expire()
+	for _, entry := range object.entries {
+		if !fn(entry.Key, entry.Value) {
+			return
+		}
+	}
+}
+
+// Apply replaces each value with the result of fn, keeping the key order.
+// Returns the object for chaining.
+func (object *Object[V]) Apply(fn func(key string, value V) V) *Object[V] {
+	for i, entry := range object.entries {
+		object.entries[i].Value = fn(entry.Key, entry.Value)
+		object.notifySet(entry.Key, object.entries[i].Value, entry.Value, true)
+	}
+	return object
+}
+
+// MapEntries converts an Object[T] into a new Object[U] with the same key order,
+// converting each value with fn.
+func MapEntries[T, U any](object *Object[T], fn func(key string, value T) U) *Object[U] {
+	result := NewObject[U](len(object.entries))
+	for _, entry := range object.entries {
+		result.entries = append(result.entries, Entry[U]{Key: entry.Key, Value: fn(entry.Key, entry.Value)})
+	}
+	return result
+}
+
+// Convert converts an Object[T] into a new Object[U] with the same key order,
+// converting each value with fn, such as decoding the values of an
+// Object[jsontext.Value] into a struct type. It stops at the first value fn fails
+// on and returns an error naming its key.
+func Convert[T, U any](object *Object[T], fn func(value T) (U, error)) (*Object[U], error) {
+	result := NewObject[U](len(object.entries))
+	for _, entry := range object.entries {
+		value, err := fn(entry.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %q: %w", entry.Key, err)
+		}
+		result.entries = append(result.entries, Entry[U]{Key: entry.Key, Value: value})
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package orderedobject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	json "github.com/kaptinlin/orderedobject/internal/json"
+	"github.com/kaptinlin/orderedobject/internal/jsontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type visitUser struct {
+	Name string
+	Age  int
+}
+
+func TestVisit(t *testing.T) {
+	t.Parallel()
+
+	users := NewObject[visitUser]().
+		Set("u1", visitUser{Name: "John", Age: 30}).
+		Set("u2", visitUser{Name: "Alice", Age: 17}).
+		Set("u3", visitUser{Name: "Bob", Age: 45})
+
+	var visited []string
+	users.Visit(func(key string, user visitUser) bool {
+		visited = append(visited, key)
+		return user.Age >= 18
+	})
+	assert.Equal(t, []string{"u1", "u2"}, visited)
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	users := NewObject[visitUser]().
+		Set("u1", visitUser{Name: "John", Age: 30}).
+		Set("u2", visitUser{Name: "Alice", Age: 25})
+
+	users.Apply(func(_ string, user visitUser) visitUser {
+		user.Age++
+		return user
+	})
+
+	assert.Equal(t, []visitUser{{Name: "John", Age: 31}, {Name: "Alice", Age: 26}}, users.Values())
+}
+
+func TestMapEntries(t *testing.T) {
+	t.Parallel()
+
+	users := NewObject[visitUser]().
+		Set("u2", visitUser{Name: "Alice", Age: 25}).
+		Set("u1", visitUser{Name: "John", Age: 30})
+
+	labels := MapEntries(users, func(key string, user visitUser) string {
+		return fmt.Sprintf("%s:%s", key, user.Name)
+	})
+
+	assert.Equal(t, []string{"u2", "u1"}, labels.Keys())
+	assert.Equal(t, []string{"u2:Alice", "u1:John"}, labels.Values())
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	raw := NewObject[jsontext.Value]().
+		Set("u2", jsontext.Value(`{"Name":"Alice","Age":17}`)).
+		Set("u1", jsontext.Value(`{"Name":"John","Age":30}`))
+	decode := func(value jsontext.Value) (visitUser, error) {
+		var user visitUser
+		err := json.Unmarshal(value, &user)
+		return user, err
+	}
+
+	users, err := Convert(raw, decode)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u1"}, users.Keys())
+	u1, _ := users.Get("u1")
+	assert.Equal(t, visitUser{Name: "John", Age: 30}, u1)
+
+	raw.Set("u3", jsontext.Value(`{"Name":3}`))
+	_, err = Convert(raw, decode)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `failed to convert "u3"`)
+
+	errNegative := errors.New("negative")
+	_, err = Convert(NewObject[int]().Set("a", 1).Set("b", -1), func(n int) (uint, error) {
+		if n < 0 {
+			return 0, errNegative
+		}
+		return uint(n), nil
+	})
+	assert.ErrorIs(t, err, errNegative)
+}
@@ -0,0 +1,72 @@
+package orderedobject
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+var (
+	// ErrSkipChildren can be returned by a Walk callback to skip the children of the current value
+	ErrSkipChildren = errors.New("skip children")
+	// ErrStopWalk can be returned by a Walk callback to stop the walk without reporting an error
+	ErrStopWalk = errors.New("stop walk")
+)
+
+// Walk traverses the object depth-first in order, calling fn for every value including
+// those nested in *Object[any] values, maps and slices. Path holds the keys of the
+// containers enclosing the value, and key is the key of the value in its container,
+// or its index for slice elements. Map keys are visited in sorted order.
+// If fn returns ErrSkipChildren the children of the value are skipped, and if it
+// returns ErrStopWalk the walk stops and Walk returns nil. Any other error stops the
+// walk and is returned.
+func (object *Object[V]) Walk(fn func(path []string, key string, value any) error) error {
+	object.expire()
+	for _, entry := range object.entries {
+		if err := walkValue(nil, entry.Key, any(entry.Value), fn); err != nil {
+			if errors.Is(err, ErrStopWalk) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// walkValue calls fn for the value at key and walks its children.
+func walkValue(path []string, key string, value any, fn func(path []string, key string, value any) error) error {
+	if err := fn(path, key, value); err != nil {
+		if errors.Is(err, ErrSkipChildren) {
+			return nil
+		}
+		return err
+	}
+
+	path = appendPath(path, key)
+	switch v := value.(type) {
+	case *Object[any]:
+		if v == nil {
+			return nil
+		}
+		v.expire()
+		for _, entry := range v.entries {
+			if err := walkValue(path, entry.Key, entry.Value, fn); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			if err := walkValue(path, k, v[k], fn); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if err := walkValue(path, strconv.Itoa(i), item, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package orderedobject
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("name", "app").
+		Set("server", NewObject[any]().
+			Set("port", 8080).
+			Set("tls", map[string]any{"key": "k", "cert": "c"})).
+		Set("tags", []any{"a", "b"})
+
+	var visited []string
+	err := obj.Walk(func(path []string, key string, value any) error {
+		visited = append(visited, strings.Join(append(path, key), "."))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"name", "server", "server.port", "server.tls", "server.tls.cert", "server.tls.key",
+		"tags", "tags.0", "tags.1",
+	}, visited)
+}
+
+func TestWalkControl(t *testing.T) {
+	t.Parallel()
+
+	obj := NewObject[any]().
+		Set("a", NewObject[any]().Set("x", 1)).
+		Set("b", 2).
+		Set("c", 3)
+
+	var keys []string
+	err := obj.Walk(func(_ []string, key string, _ any) error {
+		keys = append(keys, key)
+		switch key {
+		case "a":
+			return ErrSkipChildren
+		case "b":
+			return ErrStopWalk
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	errFound := errors.New("found")
+	err = obj.Walk(func(_ []string, key string, _ any) error {
+		if key == "x" {
+			return errFound
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errFound)
+}
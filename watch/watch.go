@@ -0,0 +1,154 @@
+// Package watch reloads a JSON or YAML configuration file into an ordered object
+// whenever it changes and notifies subscribers with the changes, for hot-reloadable
+// configuration.
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaptinlin/orderedobject"
+)
+
+// ErrUnsupportedFormat is returned when the file extension is not .json, .yaml or .yml
+var ErrUnsupportedFormat = errors.New("unsupported config file format")
+
+// DefaultInterval is the polling interval used when Options.Interval is zero.
+const DefaultInterval = time.Second
+
+// Options configures a Watcher.
+type Options struct {
+	// Interval is how often Run checks the file for changes.
+	Interval time.Duration
+	// OnError is called from Run when the file cannot be read or parsed. The last
+	// successfully loaded object is kept and Run continues polling.
+	OnError func(err error)
+}
+
+// Watcher holds the most recently loaded version of a configuration file.
+// It is safe for concurrent use.
+type Watcher struct {
+	path  string
+	parse func(data []byte) (*orderedobject.Object[any], error)
+	opts  Options
+
+	// checkMu serializes Check, so reloads are installed and reported in order.
+	checkMu sync.Mutex
+
+	mu          sync.Mutex
+	data        []byte
+	current     *orderedobject.Object[any]
+	subscribers []func(obj *orderedobject.Object[any], changes []orderedobject.Change)
+}
+
+// New loads the file at path and returns a watcher for it. The format is chosen by
+// the extension: .json, or .yaml and .yml.
+func New(path string, opts Options) (*Watcher, error) {
+	w := &Watcher{path: path, opts: opts}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		w.parse = orderedobject.FromJSONDeep
+	case ".yaml", ".yml":
+		w.parse = parseYAML
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, path)
+	}
+	if w.opts.Interval <= 0 {
+		w.opts.Interval = DefaultInterval
+	}
+
+	data, obj, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.data, w.current = data, obj
+	return w, nil
+}
+
+// Current returns the most recently loaded object. It is replaced, not modified, on
+// reload, so it can be read without locking but must not be modified.
+func (w *Watcher) Current() *orderedobject.Object[any] {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Subscribe registers fn to be called after every reload that changed the object,
+// with the new object and the changes from the previous one as reported by
+// orderedobject.Diff. Calls are made one at a time in reload order, and fn must not
+// call Check.
+func (w *Watcher) Subscribe(fn func(obj *orderedobject.Object[any], changes []orderedobject.Change)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Check reads the file once and, if its content changed, reloads it and notifies the
+// subscribers. It reports whether the object changed. Concurrent calls run one after
+// another, so an older version of the file never replaces a newer one.
+func (w *Watcher) Check() (bool, error) {
+	w.checkMu.Lock()
+	defer w.checkMu.Unlock()
+
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(data, w.data) {
+		return false, nil
+	}
+
+	obj, err := w.parse(data)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", w.path, err)
+	}
+	w.mu.Lock()
+	changes := orderedobject.Diff(w.current, obj)
+	w.data, w.current = data, obj
+	subscribers := w.subscribers
+	w.mu.Unlock()
+
+	if len(changes) == 0 {
+		return false, nil
+	}
+	for _, fn := range subscribers {
+		fn(obj, changes)
+	}
+	return true, nil
+}
+
+// Run checks the file every interval until ctx is done and returns ctx.Err().
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := w.Check(); err != nil && w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+		}
+	}
+}
+
+// load reads and parses the file.
+func (w *Watcher) load() ([]byte, *orderedobject.Object[any], error) {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return nil, nil, err
+	}
+	obj, err := w.parse(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", w.path, err)
+	}
+	return data, obj, nil
+}
@@ -0,0 +1,150 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/orderedobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestWatcherJSON(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{"name":"app","port":8080}`)
+
+	w, err := New(path, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "port"}, w.Current().Keys())
+
+	var got []orderedobject.Change
+	w.Subscribe(func(obj *orderedobject.Object[any], changes []orderedobject.Change) {
+		assert.Same(t, w.Current(), obj)
+		got = changes
+	})
+
+	changed, err := w.Check()
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Formatting changes do not notify subscribers
+	writeFile(t, path, `{ "name": "app", "port": 8080 }`)
+	changed, err = w.Check()
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Nil(t, got)
+
+	writeFile(t, path, `{"name":"app","port":9090,"debug":true}`)
+	changed, err = w.Check()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.Len(t, got, 2)
+	assert.Equal(t, orderedobject.ChangeModified, got[0].Type)
+	assert.Equal(t, []string{"port"}, got[0].Path)
+	assert.Equal(t, orderedobject.ChangeAdded, got[1].Type)
+	assert.Equal(t, []string{"debug"}, got[1].Path)
+}
+
+func TestWatcherYAML(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "name: app\nserver:\n  port: 8080\n  host: localhost\nfeatures: [a, b]\nextra: null\n")
+
+	w, err := New(path, Options{})
+	require.NoError(t, err)
+
+	data, err := w.Current().ToJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"app","server":{"port":8080,"host":"localhost"},"features":["a","b"],"extra":null}`, string(data))
+}
+
+func TestWatcherErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	_, err := New(filepath.Join(dir, "config.toml"), Options{})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	_, err = New(filepath.Join(dir, "missing.json"), Options{})
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	path := filepath.Join(dir, "list.yaml")
+	writeFile(t, path, "- a\n- b\n")
+	_, err = New(path, Options{})
+	require.ErrorIs(t, err, orderedobject.ErrExpectedObjectStart)
+
+	path = filepath.Join(dir, "config.json")
+	writeFile(t, path, `{"a":1}`)
+	w, err := New(path, Options{})
+	require.NoError(t, err)
+	writeFile(t, path, `{"a":`)
+	_, err = w.Check()
+	require.Error(t, err)
+	assert.Equal(t, []string{"a"}, w.Current().Keys(), "keeps the last good object")
+}
+
+func TestWatcherRun(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{"a":1}`)
+	w, err := New(path, Options{Interval: time.Millisecond})
+	require.NoError(t, err)
+
+	reloaded := make(chan *orderedobject.Object[any], 1)
+	w.Subscribe(func(obj *orderedobject.Object[any], _ []orderedobject.Change) {
+		reloaded <- obj
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+
+	writeFile(t, path, `{"a":2}`)
+	select {
+	case obj := <-reloaded:
+		assert.Equal(t, []string{"a"}, obj.Keys())
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload")
+	}
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestWatcherConcurrentCheck(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{"a":1}`)
+	w, err := New(path, Options{})
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+	w.Subscribe(func(*orderedobject.Object[any], []orderedobject.Change) { calls.Add(1) })
+	writeFile(t, path, `{"a":2}`)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			_, err := w.Check()
+			assert.NoError(t, err)
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+	value, _ := w.Current().Get("a")
+	assert.Equal(t, 2.0, value)
+}
@@ -0,0 +1,71 @@
+package watch
+
+import (
+	"fmt"
+
+	"github.com/kaptinlin/orderedobject"
+	"gopkg.in/yaml.v3"
+)
+
+// parseYAML decodes a YAML document holding a mapping, decoding nested mappings as
+// ordered objects. Numbers are decoded as float64, as they are from JSON.
+func parseYAML(data []byte) (*orderedobject.Object[any], error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
+	}
+	root := &doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to unmarshal YAML: %w", orderedobject.ErrExpectedObjectStart)
+	}
+	value, err := yamlValue(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
+	}
+	return value.(*orderedobject.Object[any]), nil
+}
+
+// yamlValue converts a YAML node to the value FromJSONDeep would produce.
+func yamlValue(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return yamlValue(node.Alias)
+	case yaml.MappingNode:
+		obj := orderedobject.NewObject[any](len(node.Content) / 2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := yamlValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			obj.Set(node.Content[i].Value, value)
+		}
+		return obj, nil
+	case yaml.SequenceNode:
+		values := make([]any, len(node.Content))
+		for i, child := range node.Content {
+			value, err := yamlValue(child)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		err := node.Decode(&b)
+		return b, err
+	case "!!int", "!!float":
+		var f float64
+		err := node.Decode(&f)
+		return f, err
+	}
+	return node.Value, nil
+}